@@ -1,11 +1,14 @@
 package config
 
 type ServiceConfig struct {
-	ServiceName string   `mapstructure:"service_name" json:"service_name" yaml:"service_name"`
-	WorkerHost  string   `json:"worker_host" yaml:"worker_host" mapstructure:"worker_host"`
-	WorkerPort  int      `yaml:"worker_port" json:"worker_port" mapstructure:"worker_port"`
-	MasterHost  string   `json:"master_host" yaml:"master_host" mapstructure:"master_host"`
-	MasterPort  int      `json:"master_port" yaml:"master_port" mapstructure:"master_port"`
-	NodeName    string   `json:"node_name" yaml:"node_name" mapstructure:"node_name"`
-	Etcd        []string `yaml:"etcd" json:"etcd" mapstructure:"etcd"`
+	ServiceName     string   `mapstructure:"service_name" json:"service_name" yaml:"service_name"`
+	WorkerHost      string   `json:"worker_host" yaml:"worker_host" mapstructure:"worker_host"`
+	WorkerPort      int      `yaml:"worker_port" json:"worker_port" mapstructure:"worker_port"`
+	MasterHost      string   `json:"master_host" yaml:"master_host" mapstructure:"master_host"`
+	MasterPort      int      `json:"master_port" yaml:"master_port" mapstructure:"master_port"`
+	NodeName        string   `json:"node_name" yaml:"node_name" mapstructure:"node_name"`
+	Etcd            []string `yaml:"etcd" json:"etcd" mapstructure:"etcd"`
+	ResultCacheSize int      `yaml:"result_cache_size" json:"result_cache_size" mapstructure:"result_cache_size"` // 查询结果缓存的条数，0 表示不开启
+	ResultCacheTTL  int      `yaml:"result_cache_ttl" json:"result_cache_ttl" mapstructure:"result_cache_ttl"`    // 查询结果缓存的有效期，单位秒
+	TTLReapInterval int      `yaml:"ttl_reap_interval" json:"ttl_reap_interval" mapstructure:"ttl_reap_interval"` // 清理过期文档的间隔，单位秒，0 表示不清理
 }
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+)
+
+// IndexAlias 别名指向的索引，读请求查询全部索引，写请求只写入 WriteIndex
+type IndexAlias struct {
+	Indexes    []string `json:"indexes"`
+	WriteIndex string   `json:"writeIndex"`
+}
+
+// SwitchAlias
+// @Description 原子地替换别名指向的索引，切换前后的读写请求只会看到切换前或者切换后的索引。
+// 校验和切换都在管理器的锁内完成，不会与并发的 CreateIndex、DropIndex 交错
+// @Param alias 别名，不能与已有的索引同名
+// @Param indexes 别名指向的全部索引，为空时删除别名
+// @Param writeIndex 写入使用的索引，需要在 indexes 中，只有一个索引时可以为空
+// @Return 切换前的别名，别名不存在时为空；索引不存在或者参数不合法时返回错误
+func (idm *IndexManager) SwitchAlias(alias string, indexes []string, writeIndex string) (IndexAlias, error) {
+	if alias == "" {
+		return IndexAlias{}, fmt.Errorf("alias name can not be empty")
+	}
+	if writeIndex == "" && len(indexes) == 1 {
+		writeIndex = indexes[0]
+	}
+	if writeIndex != "" && !slices.Contains(indexes, writeIndex) {
+		return IndexAlias{}, fmt.Errorf("write index [%v] is not one of the indexes of alias [%v]", writeIndex, alias)
+	}
+	idm.managerLock.Lock()
+	if _, ok := idm.IndexInfos[alias]; ok {
+		idm.managerLock.Unlock()
+		return IndexAlias{}, fmt.Errorf("alias [%v] conflicts with an existing index", alias)
+	}
+	for _, name := range indexes {
+		if _, ok := idm.indexers[name]; !ok {
+			idm.managerLock.Unlock()
+			return IndexAlias{}, fmt.Errorf("index[%v] not found", name)
+		}
+	}
+	idm.aliasLock.Lock()
+	var previous IndexAlias
+	if old, ok := idm.Aliases[alias]; ok {
+		previous = *old
+	}
+	if len(indexes) == 0 {
+		delete(idm.Aliases, alias)
+	} else {
+		idm.Aliases[alias] = &IndexAlias{Indexes: slices.Clone(indexes), WriteIndex: writeIndex}
+	}
+	idm.aliasLock.Unlock()
+	idm.managerLock.Unlock()
+	return previous, idm.storeIndexManager()
+}
+
+// GetAliases
+// @Description 返回所有别名的副本
+// @Return 别名 -> 指向的索引
+func (idm *IndexManager) GetAliases() map[string]IndexAlias {
+	idm.aliasLock.RLock()
+	defer idm.aliasLock.RUnlock()
+	result := make(map[string]IndexAlias, len(idm.Aliases))
+	for name, alias := range idm.Aliases {
+		result[name] = IndexAlias{Indexes: slices.Clone(alias.Indexes), WriteIndex: alias.WriteIndex}
+	}
+	return result
+}
+
+// ResolveRead
+// @Description 返回读请求需要查询的索引，不是别名时返回名字本身
+// @Param name 索引名或者别名
+// @Return 索引名
+func (idm *IndexManager) ResolveRead(name string) []string {
+	idm.aliasLock.RLock()
+	defer idm.aliasLock.RUnlock()
+	if alias, ok := idm.Aliases[name]; ok {
+		return slices.Clone(alias.Indexes)
+	}
+	return []string{name}
+}
+
+// ResolveWrite
+// @Description 返回写请求以及只能作用于一个索引的请求使用的索引，不是别名时返回名字本身
+// @Param name 索引名或者别名
+// @Return 索引名，别名没有写索引时返回错误
+func (idm *IndexManager) ResolveWrite(name string) (string, error) {
+	idm.aliasLock.RLock()
+	defer idm.aliasLock.RUnlock()
+	alias, ok := idm.Aliases[name]
+	if !ok {
+		return name, nil
+	}
+	if alias.WriteIndex == "" {
+		return "", fmt.Errorf("alias [%v] has no write index", name)
+	}
+	return alias.WriteIndex, nil
+}
+
+// 内部方法，判断名字是否是别名
+func (idm *IndexManager) isAlias(name string) bool {
+	idm.aliasLock.RLock()
+	defer idm.aliasLock.RUnlock()
+	_, ok := idm.Aliases[name]
+	return ok
+}
+
+// 内部方法，返回指向该索引的别名，没有时返回空
+func (idm *IndexManager) aliasOf(indexName string) string {
+	idm.aliasLock.RLock()
+	defer idm.aliasLock.RUnlock()
+	for name, alias := range idm.Aliases {
+		if slices.Contains(alias.Indexes, indexName) {
+			return name
+		}
+	}
+	return ""
+}
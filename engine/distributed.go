@@ -3,19 +3,26 @@ package engine
 import (
 	"context"
 	"fmt"
+	"github.com/cylScripter/NexusFind/types"
 	"github.com/cylScripter/NexusFind/types/doc"
 	"github.com/cylScripter/NexusFind/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"hash/fnv"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 type Sentinel struct {
-	hub      *HubProxy
-	connPool sync.Map // 与各个IndexServiceWorker建立的连接。把连接缓存起来，避免每次都重建连接
+	hub         *HubProxy
+	connPool    sync.Map     // 与各个IndexServiceWorker建立的连接。把连接缓存起来，避免每次都重建连接
+	resultCache *ResultCache // 查询结果缓存，为 nil 时不缓存
+	logger      *utils.Log
 }
 
 func NewSentinel(etcdServers []string, logger *utils.Log) *Sentinel {
@@ -23,6 +30,7 @@ func NewSentinel(etcdServers []string, logger *utils.Log) *Sentinel {
 		// hub: GetServiceHub(etcdServers, 10), //直接访问ServiceHub
 		hub:      GetServiceHubProxy(etcdServers, 10, 100, logger), //走代理HubProxy
 		connPool: sync.Map{},
+		logger:   logger,
 	}
 }
 
@@ -54,25 +62,420 @@ func (sentinel *Sentinel) GetGrpcConn(endpoint string) *grpc.ClientConn {
 	return conn
 }
 
-func (sentinel *Sentinel) Add(request *AddRequest) (int, error) {
+// Add
+// @Description 根据负载均衡策略选择一台 worker 写入文档
+// @Param request 索引名及文档
+// @Return 文档的 docId 以及新文档命中的已注册查询
+func (sentinel *Sentinel) Add(request *AddRequest) (*Code, error) {
+	defer sentinel.invalidateGenerations()
 	endpoint := sentinel.hub.GetServiceEndpoint(INDEX_SERVICE) // 根据负载均衡策略，选择一台index worker，把doc添加到它上面去
 	if len(endpoint) == 0 {
-		return 0, fmt.Errorf("there is no alive index worker")
+		return nil, fmt.Errorf("there is no alive index worker")
 	}
 	conn := sentinel.GetGrpcConn(endpoint)
 	if conn == nil {
-		return 0, fmt.Errorf("connect to worker %s failed", endpoint)
+		return nil, fmt.Errorf("connect to worker %s failed", endpoint)
 	}
 	client := NewIndexServiceClient(conn)
-	affected, err := client.Add(context.Background(), request)
-	if err != nil {
-		return 0, err
+	return client.Add(context.Background(), request)
+}
+
+// WatchChanges
+// @Description 同时订阅所有 worker 上索引的变更，转发时在 Shard 中填写 worker 的地址。
+// 各个 worker 的序号相互独立，恢复时在 FromSeqs 中给出每个 worker 最后收到的序号加一
+// @Param ctx 控制订阅结束
+// @Param request 索引名及起始序号
+// @Param send 转发一条变更，返回错误时结束所有订阅
+// @Return 有 worker 订阅失败或者转发失败时返回第一个错误
+func (sentinel *Sentinel) WatchChanges(ctx context.Context, request *WatchRequest, send func(*types.ChangeEvent) error) error {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return fmt.Errorf("there is no alive index worker")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	lock := sync.Mutex{}
+	fail := func(err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		cancel()
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				fail(fmt.Errorf("connect to worker %s failed", endpoint))
+				return
+			}
+			stream, err := NewIndexServiceClient(conn).WatchChanges(ctx, request)
+			if err != nil {
+				fail(err)
+				return
+			}
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if ctx.Err() == nil {
+						fail(err)
+					}
+					return
+				}
+				if event.Shard == "" {
+					event.Shard = endpoint
+				}
+				lock.Lock()
+				err = send(event)
+				lock.Unlock()
+				if err != nil {
+					fail(err)
+					return
+				}
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// RegisterPercolator
+// @Description 在所有 worker 上注册查询，新文档可能写入任何一台 worker。只在部分 worker 上注册成功时
+// 新文档是否命中会取决于写入了哪台 worker，因此删除已经注册成功的查询，返回的错误中列出失败的 worker
+// @Param request 索引名及查询
+// @Return 注册成功的 worker 数，有 worker 失败时为 0
+func (sentinel *Sentinel) RegisterPercolator(request *PercolatorRequest) (int, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return 0, fmt.Errorf("there is no alive index worker")
+	}
+	succeeded, failures := sentinel.broadcastTo(endpoints, func(client IndexServiceClient) (*Code, error) {
+		return client.RegisterPercolator(context.Background(), request)
+	})
+	if len(failures) == 0 {
+		return len(succeeded), nil
+	}
+	rollback := &PercolatorRequest{IndexName: request.IndexName, Query: &types.PercolateQuery{Id: request.Query.GetId()}}
+	_, rollbackFailures := sentinel.broadcastTo(succeeded, func(client IndexServiceClient) (*Code, error) {
+		return client.DeletePercolator(context.Background(), rollback)
+	})
+	if len(rollbackFailures) > 0 {
+		return 0, fmt.Errorf("register percolator [%v] %v, rollback %v", request.Query.GetId(), describeFailures(failures), describeFailures(rollbackFailures))
+	}
+	return 0, fmt.Errorf("register percolator [%v] %v, rolled back on %v", request.Query.GetId(), describeFailures(failures), succeeded)
+}
+
+// DeletePercolator
+// @Description 删除所有 worker 上注册的查询
+// @Param request 索引名及查询的 Id
+// @Return 删除成功的 worker 数，有 worker 失败时返回第一个错误
+func (sentinel *Sentinel) DeletePercolator(request *PercolatorRequest) (int, error) {
+	return sentinel.broadcast(func(client IndexServiceClient) (*Code, error) {
+		return client.DeletePercolator(context.Background(), request)
+	})
+}
+
+// DropIndex
+// @Description 在所有 worker 上删除索引
+// @Param request 索引名
+// @Return 删除成功的 worker 数，有 worker 失败时返回第一个错误
+func (sentinel *Sentinel) DropIndex(request *DropIndexRequest) (int, error) {
+	defer sentinel.invalidateGenerations()
+	return sentinel.broadcast(func(client IndexServiceClient) (*Code, error) {
+		return client.DropIndex(context.Background(), request)
+	})
+}
+
+// DeleteByQuery
+// @Description 文档分散在各个 worker 上，并行在所有 worker 上按查询删除文档
+// @Param request 索引名、查询及过滤条件
+// @Return 所有 worker 删除的文档数之和，有 worker 失败时返回第一个错误
+func (sentinel *Sentinel) DeleteByQuery(request *DeleteByQueryRequest) (*DeleteByQueryResult, error) {
+	defer sentinel.invalidateGenerations()
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	var deleted uint64
+	var firstErr error
+	errLock := sync.Mutex{}
+	setErr := func(err error) {
+		errLock.Lock()
+		defer errLock.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				setErr(fmt.Errorf("connect to worker %s failed", endpoint))
+				return
+			}
+			result, err := NewIndexServiceClient(conn).DeleteByQuery(context.Background(), request)
+			if err != nil {
+				setErr(err)
+				return
+			}
+			atomic.AddUint64(&deleted, result.Deleted)
+		}(endpoint)
 	}
+	wg.Wait()
+	// 部分 worker 失败时已经删除的文档不会恢复，同时返回删除数和错误
+	return &DeleteByQueryResult{Deleted: atomic.LoadUint64(&deleted)}, firstErr
+}
 
-	return int(affected.StatusCode), nil
+// SwitchAlias
+// @Description 在所有 worker 上切换别名，每个 worker 上的切换是原子的。
+// 有 worker 失败时把已经切换的 worker 恢复到各自切换前的状态
+// @Param request 别名、指向的索引及写索引
+// @Return 切换成功的 worker 数，有 worker 失败时返回 0 以及失败和回滚的情况
+func (sentinel *Sentinel) SwitchAlias(request *SwitchAliasRequest) (int, error) {
+	defer sentinel.invalidateGenerations()
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return 0, fmt.Errorf("there is no alive index worker")
+	}
+	rollbacks := make(map[string]*SwitchAliasRequest)
+	lock := sync.Mutex{}
+	succeeded, failures := sentinel.broadcastEach(endpoints, func(endpoint string, client IndexServiceClient) (*Code, error) {
+		code, err := client.SwitchAlias(context.Background(), request)
+		if err != nil {
+			return nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		rollbacks[endpoint] = &SwitchAliasRequest{Alias: request.Alias, Indexes: code.PreviousIndexes, WriteIndex: code.PreviousWriteIndex}
+		return code, nil
+	})
+	if len(failures) == 0 {
+		return len(succeeded), nil
+	}
+	_, rollbackFailures := sentinel.broadcastEach(succeeded, func(endpoint string, client IndexServiceClient) (*Code, error) {
+		return client.SwitchAlias(context.Background(), rollbacks[endpoint])
+	})
+	if len(rollbackFailures) > 0 {
+		return 0, fmt.Errorf("switch alias [%v] %v, rollback %v", request.Alias, describeFailures(failures), describeFailures(rollbackFailures))
+	}
+	return 0, fmt.Errorf("switch alias [%v] %v, rolled back on %v", request.Alias, describeFailures(failures), succeeded)
+}
+
+// Reindex
+// @Description 在所有 worker 上用同一个任务 Id 启动重建索引任务，每个 worker 复制自己的文档
+// @Param request 源索引、目标索引以及可选的查询条件，任务 Id 为空时自动生成
+// @Return 所有 worker 合并后的进度
+func (sentinel *Sentinel) Reindex(request *ReindexRequest) (*ReindexStatus, error) {
+	if request.JobId == "" {
+		request = proto.Clone(request).(*ReindexRequest)
+		request.JobId = fmt.Sprintf("reindex-%v", time.Now().UnixNano())
+	}
+	return sentinel.reindexStatus(request.JobId, func(client IndexServiceClient) (*ReindexStatus, error) {
+		return client.Reindex(context.Background(), request)
+	})
+}
+
+// GetReindexStatus
+// @Description 汇总所有 worker 上重建索引任务的进度
+// @Param request 任务 Id
+// @Return 合并后的进度，所有 worker 都结束时 Done 为 true
+func (sentinel *Sentinel) GetReindexStatus(request *ReindexStatusRequest) (*ReindexStatus, error) {
+	return sentinel.reindexStatus(request.JobId, func(client IndexServiceClient) (*ReindexStatus, error) {
+		return client.GetReindexStatus(context.Background(), request)
+	})
+}
+
+// 内部方法，把请求并行发给所有 worker 并合并各个 worker 的进度，文档数相加，时间取最早开始和最晚结束
+func (sentinel *Sentinel) reindexStatus(jobId string, call func(client IndexServiceClient) (*ReindexStatus, error)) (*ReindexStatus, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	merged := &ReindexStatus{JobId: jobId, Done: true}
+	var firstErr error
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			var status *ReindexStatus
+			var err error
+			if conn := sentinel.GetGrpcConn(endpoint); conn == nil {
+				err = fmt.Errorf("connect to worker %s failed", endpoint)
+			} else {
+				status, err = call(NewIndexServiceClient(conn))
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			merged.Source, merged.Dest = status.Source, status.Dest
+			merged.Total += status.Total
+			merged.Copied += status.Copied
+			merged.Failed += status.Failed
+			merged.Done = merged.Done && status.Done
+			if merged.Error == "" {
+				merged.Error = status.Error
+			}
+			if merged.StartTime == 0 || status.StartTime < merged.StartTime {
+				merged.StartTime = status.StartTime
+			}
+			merged.EndTime = max(merged.EndTime, status.EndTime)
+		}(endpoint)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if !merged.Done {
+		merged.EndTime = 0
+	}
+	return merged, nil
+}
+
+// Stats
+// @Description 并行取得所有 worker 的统计信息，按 worker 排序后返回
+// @Param request 统计请求
+// @Return 各个 worker 的统计信息，有 worker 取不到时返回错误
+func (sentinel *Sentinel) Stats(request *StatsRequest) (*StatsResult, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	merged := &StatsResult{}
+	var firstErr error
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			var result *StatsResult
+			var err error
+			if conn := sentinel.GetGrpcConn(endpoint); conn == nil {
+				err = fmt.Errorf("connect to worker %s failed", endpoint)
+			} else {
+				result, err = NewIndexServiceClient(conn).Stats(context.Background(), request)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("get stats of worker %s failed: %v", endpoint, err)
+				}
+				return
+			}
+			merged.Workers = append(merged.Workers, result.Workers...)
+		}(endpoint)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sort.Slice(merged.Workers, func(i, j int) bool { return merged.Workers[i].Shard < merged.Workers[j].Shard })
+	if sentinel.resultCache != nil {
+		merged.ResultCache = sentinel.resultCache.Stats()
+	}
+	return merged, nil
+}
+
+// Percolate
+// @Description 所有 worker 上注册的查询相同，选择一台 worker 判断文档命中了哪些查询
+// @Param request 索引名及文档
+// @Return 命中的查询的 Id
+func (sentinel *Sentinel) Percolate(request *PercolateRequest) (*PercolateResult, error) {
+	endpoint := sentinel.hub.GetServiceEndpoint(INDEX_SERVICE)
+	if len(endpoint) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	conn := sentinel.GetGrpcConn(endpoint)
+	if conn == nil {
+		return nil, fmt.Errorf("connect to worker %s failed", endpoint)
+	}
+	return NewIndexServiceClient(conn).Percolate(context.Background(), request)
+}
+
+// 内部方法，把请求并行发给所有 worker，返回成功的 worker 数以及第一个错误
+func (sentinel *Sentinel) broadcast(call func(client IndexServiceClient) (*Code, error)) (int, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return 0, fmt.Errorf("there is no alive index worker")
+	}
+	succeeded, failures := sentinel.broadcastTo(endpoints, call)
+	for _, endpoint := range endpoints {
+		if err, ok := failures[endpoint]; ok {
+			return len(succeeded), err
+		}
+	}
+	return len(succeeded), nil
+}
+
+// 内部方法，把请求并行发给指定的 worker，返回成功的 worker（已排序）以及每个失败的 worker 的错误
+func (sentinel *Sentinel) broadcastTo(endpoints []string, call func(client IndexServiceClient) (*Code, error)) ([]string, map[string]error) {
+	return sentinel.broadcastEach(endpoints, func(endpoint string, client IndexServiceClient) (*Code, error) {
+		return call(client)
+	})
+}
+
+// 内部方法，与 broadcastTo 相同，回调同时拿到 worker 的地址，用于给每个 worker 发不同的请求
+func (sentinel *Sentinel) broadcastEach(endpoints []string, call func(endpoint string, client IndexServiceClient) (*Code, error)) ([]string, map[string]error) {
+	succeeded := make([]string, 0, len(endpoints))
+	failures := make(map[string]error)
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			var err error
+			if conn := sentinel.GetGrpcConn(endpoint); conn == nil {
+				err = fmt.Errorf("connect to worker %s failed", endpoint)
+			} else {
+				_, err = call(endpoint, NewIndexServiceClient(conn))
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				failures[endpoint] = err
+			} else {
+				succeeded = append(succeeded, endpoint)
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+	sort.Strings(succeeded)
+	return succeeded, failures
+}
+
+// 内部方法，按 worker 排序后把各个 worker 的错误合并成一段描述
+func describeFailures(failures map[string]error) string {
+	endpoints := make([]string, 0, len(failures))
+	for endpoint := range failures {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	messages := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		messages = append(messages, fmt.Sprintf("%v: %v", endpoint, failures[endpoint]))
+	}
+	return fmt.Sprintf("failed on workers [%v]", strings.Join(messages, "; "))
 }
 
 func (sentinel *Sentinel) Delete(request *DocIdRequest) (int, error) {
+	defer sentinel.invalidateGenerations()
 	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
 	if len(endpoints) == 0 {
 		return 0, fmt.Errorf("")
@@ -88,7 +491,7 @@ func (sentinel *Sentinel) Delete(request *DocIdRequest) (int, error) {
 				client := NewIndexServiceClient(conn)
 				affected, err := client.Delete(context.Background(), request)
 				if err != nil {
-					fmt.Println()
+					sentinel.logger.NFLog.Errorf("delete document [%v] on worker %v failed: %v", request.DocId, endpoint, err)
 				} else {
 					if affected.StatusCode == 1 {
 						atomic.AddInt32(&n, 1)
@@ -127,7 +530,7 @@ func (sentinel *Sentinel) CreateIndex(request *CreateIndexRequest) (int, error)
 				client := NewIndexServiceClient(conn)
 				affected, err := client.CreateIndex(context.Background(), request)
 				if err != nil {
-					fmt.Println()
+					sentinel.logger.NFLog.Errorf("create index [%v] on worker %v failed: %v", request.IndexName, endpoint, err)
 				} else {
 					if affected.StatusCode == 1 {
 						atomic.AddInt32(&n, 1)
@@ -140,30 +543,172 @@ func (sentinel *Sentinel) CreateIndex(request *CreateIndexRequest) (int, error)
 	return int(atomic.LoadInt32(&n)), nil
 }
 
-func (sentinel *Sentinel) Search(request *SearchRequest) ([]*doc.Document, error) {
+// 带打分信息的单条搜索结果，hit 为空表示该查询不打分
+type searchHit struct {
+	doc   *doc.Document
+	hit   *types.Hit
+	shard string // 结果所在的 worker，分页时用于区分不同 worker 上相同的 docId
+}
+
+// EnableResultCache
+// @Description 开启查询结果缓存，结果在 ttl 内并且各个 worker 上索引的版本号都没有变化时直接返回缓存的结果，
+// 版本号本身缓存 GENERATION_CACHE_TTL
+// @Param ttl 结果的有效期
+// @Param capacity 最多缓存多少条结果
+func (sentinel *Sentinel) EnableResultCache(ttl time.Duration, capacity int) {
+	sentinel.resultCache = NewResultCache(ttl, capacity)
+}
+
+// 内部方法，经过该 Sentinel 的写入完成后清除缓存的版本号，之后的查询不会读到写入之前缓存的结果
+func (sentinel *Sentinel) invalidateGenerations() {
+	if sentinel.resultCache != nil {
+		sentinel.resultCache.InvalidateGenerations()
+	}
+}
+
+func (sentinel *Sentinel) Search(request *SearchRequest) (*Result, error) {
 	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
 	if len(endpoints) == 0 {
 		return nil, fmt.Errorf("")
 	}
-	docs := make([]*doc.Document, 0, 1000)
-	resultCh := make(chan *doc.Document, 1000)
+	if sentinel.resultCache == nil {
+		result, _, err := sentinel.search(request, endpoints)
+		return result, err
+	}
+	key, cacheable := resultCacheKey(request)
+	if !cacheable {
+		result, _, err := sentinel.search(request, endpoints)
+		return result, err
+	}
+	fingerprint, ok := sentinel.resultCache.Fingerprint(request.IndexName, endpoints, func() (string, bool) {
+		return sentinel.generationFingerprint(request.IndexName, endpoints)
+	})
+	if !ok {
+		result, _, err := sentinel.search(request, endpoints)
+		return result, err
+	}
+	if result, hit := sentinel.resultCache.Get(key, fingerprint); hit {
+		return result, nil
+	}
+	// 版本号在查询之前取得，查询过程中有写入时缓存的结果会在下一次查询时因版本号变化而失效
+	result, complete, err := sentinel.search(request, endpoints)
+	if err == nil && complete {
+		sentinel.resultCache.Put(key, fingerprint, result)
+	}
+	return result, err
+}
+
+// Generation
+// @Description 汇总各个 worker 上索引的版本号，任何一个 worker 上的版本号变化或者 worker 增减时结果都会变化
+// @Param request 索引名
+// @Return 汇总后的版本号
+func (sentinel *Sentinel) Generation(request *GenerationRequest) (*GenerationResult, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	fingerprint, ok := sentinel.generationFingerprint(request.IndexName, endpoints)
+	if !ok {
+		return nil, fmt.Errorf("get generation of index [%v] failed", request.IndexName)
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(fingerprint))
+	return &GenerationResult{Generation: hash.Sum64()}, nil
+}
+
+// 内部方法，并行取得各个 worker 上索引的版本号，拼接成 "endpoint=版本号" 的列表。有 worker 取不到时返回 false
+func (sentinel *Sentinel) generationFingerprint(indexName string, endpoints []string) (string, bool) {
+	generations := make([]string, len(endpoints))
+	var failed int32
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			result, err := NewIndexServiceClient(conn).Generation(context.Background(), &GenerationRequest{IndexName: indexName})
+			if err != nil {
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			generations[i] = fmt.Sprintf("%v=%v", endpoint, result.Generation)
+		}(i, endpoint)
+	}
+	wg.Wait()
+	if atomic.LoadInt32(&failed) > 0 {
+		return "", false
+	}
+	sort.Strings(generations)
+	return strings.Join(generations, ";"), true
+}
+
+// 内部方法，把查询分发给所有 worker 并合并结果，有 worker 查询失败时 complete 为 false
+func (sentinel *Sentinel) search(request *SearchRequest, endpoints []string) (result *Result, complete bool, err error) {
+	if mlt := request.MoreLikeThis; mlt != nil && mlt.Like == nil && mlt.DocId != "" {
+		// 源文档只存在于某一个 worker 上，先取回源文档再分发给所有 worker
+		source, err := sentinel.Get(&DocIdRequest{IndexName: request.IndexName, DocId: mlt.DocId})
+		if err != nil {
+			return nil, false, err
+		}
+		if source == nil {
+			return nil, false, fmt.Errorf("document [%v] no has exists", mlt.DocId)
+		}
+		request = proto.Clone(request).(*SearchRequest)
+		request.MoreLikeThis.Like = source
+	}
+	shardRequest := request
+	if isHybrid(request) {
+		shardRequest = shardFusionRequest(request)
+	}
+	docs := make([]searchHit, 0, 1000)
+	resultCh := make(chan searchHit, 1000)
+	var failed int32
+	var totalHits uint64
+	collapseCounts := make(map[string]uint64)
+	shardProfiles := make([]*types.ShardProfile, 0, len(endpoints))
+	mergeLock := sync.Mutex{}
+	fanOutStart := time.Now()
 	wg := sync.WaitGroup{}
 	wg.Add(len(endpoints))
 	for _, endpoint := range endpoints {
 		go func(endpoint string) {
 			defer wg.Done()
 			conn := sentinel.GetGrpcConn(endpoint)
-			if conn != nil {
+			if conn == nil {
+				atomic.AddInt32(&failed, 1)
+			} else {
 				client := NewIndexServiceClient(conn)
-				result, err := client.Search(context.Background(), request)
-				fmt.Println(result)
+				rpcStart := time.Now()
+				result, err := client.Search(context.Background(), shardRequest)
+				rpcNanos := uint64(time.Since(rpcStart).Nanoseconds())
 				if err != nil {
+					atomic.AddInt32(&failed, 1)
 					fmt.Println(err)
 				} else {
-					if len(result.DocResult) > 0 {
-						for _, d := range result.DocResult {
-							resultCh <- d
+					atomic.AddUint64(&totalHits, result.TotalHits)
+					mergeLock.Lock()
+					for key, count := range result.CollapseCounts {
+						collapseCounts[key] += count
+					}
+					for _, shardProfile := range result.GetProfile().GetShards() {
+						shardProfile.RpcNanos = rpcNanos
+						shardProfiles = append(shardProfiles, shardProfile)
+					}
+					mergeLock.Unlock()
+					shard := result.Shard
+					if shard == "" {
+						shard = endpoint
+					}
+					for i, d := range result.DocResult {
+						var hit *types.Hit
+						if i < len(result.Hits) {
+							hit = result.Hits[i]
 						}
+						resultCh <- searchHit{doc: d, hit: hit, shard: shard}
 					}
 				}
 			}
@@ -183,7 +728,250 @@ func (sentinel *Sentinel) Search(request *SearchRequest) ([]*doc.Document, error
 	wg.Wait()
 	close(resultCh) //1
 	<-receiveFinish //4
-	return docs, nil
+	fanOutNanos := uint64(time.Since(fanOutStart).Nanoseconds())
+	mergeStart := time.Now()
+	if isHybrid(request) {
+		fusion := request.Fusion.Normalize()
+		if request.Collapse != nil {
+			// 先融合窗口内的全部结果，折叠之后再截断到 Size
+			docs = collapseSearchHits(fuseSearchHits(shardFusionRequest(request).Fusion, docs), request.Collapse)
+			if uint64(len(docs)) > fusion.Size {
+				docs = docs[:fusion.Size]
+			}
+		} else {
+			docs = fuseSearchHits(fusion, docs)
+		}
+	} else if size, scored := scoredSize(request); scored {
+		// 各个 worker 的结果已经按得分排好序，合并后重新排序，折叠之后再截断
+		sort.SliceStable(docs, func(i, j int) bool {
+			return docs[i].hit.GetScore() > docs[j].hit.GetScore()
+		})
+		if request.Collapse != nil {
+			docs = collapseSearchHits(docs, request.Collapse)
+		}
+		// 分页时每个 worker 最多返回 size 条，按页截断
+		if uint64(len(docs)) > size && request.Size == 0 {
+			docs = docs[:size]
+		}
+	}
+	var nextCursor *types.Cursor
+	if request.Size > 0 {
+		docs, nextCursor = pageSearchHits(request, docs)
+	}
+	result = &Result{DocResult: make([]*doc.Document, 0, len(docs)), NextCursor: nextCursor}
+	for _, d := range docs {
+		result.DocResult = append(result.DocResult, d.doc)
+		if d.hit != nil {
+			result.Hits = append(result.Hits, d.hit)
+		}
+	}
+	if request.TrackTotalHits {
+		result.TotalHits = atomic.LoadUint64(&totalHits)
+		// kNN 时每个 worker 的命中数是本地的前 K 篇，全局只命中前 K 篇，不能直接相加
+		if k, _ := scoredSize(request); request.Knn != nil && request.MoreLikeThis == nil && !isHybrid(request) && result.TotalHits > k {
+			result.TotalHits = k
+		}
+	}
+	if _, scored := scoredSize(request); request.GeoSort != nil && !scored && request.Size == 0 {
+		// 各个 worker 的结果已经按距离排好序，合并后重新排序
+		result.DocResult, result.Hits = types.SortByDistance(result.DocResult, result.Hits, request.GeoSort)
+	}
+	if request.Collapse != nil {
+		// 各个 worker 已经分别折叠，合并后同一个值可能来自多个 worker，需要再折叠一次，命中数取各个 worker 之和
+		collapseResult(result, request.Collapse)
+		result.CollapseCounts = collapseCounts
+	}
+	result.DocResult = projectDocs(result.DocResult, request.IncludeFields, request.ExcludeFields, request.ExcludeKeywords)
+	if request.Profile {
+		sort.Slice(shardProfiles, func(i, j int) bool { return shardProfiles[i].Shard < shardProfiles[j].Shard })
+		result.Profile = &types.SearchProfile{Shards: shardProfiles, FanOutNanos: fanOutNanos, MergeNanos: uint64(time.Since(mergeStart).Nanoseconds())}
+	}
+	return result, atomic.LoadInt32(&failed) == 0, nil
+}
+
+// Count
+// @Description 把计数请求分发给所有 worker，汇总各个 worker 的命中数
+// @Param request 查询请求，只使用 Query、Filter 和 TerminateAfter
+// @Return 命中的文档数，设置了 TerminateAfter 时不超过 TerminateAfter
+func (sentinel *Sentinel) Count(request *SearchRequest) (*CountResult, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	var count uint64
+	var firstErr error
+	errLock := sync.Mutex{}
+	setErr := func(err error) {
+		errLock.Lock()
+		defer errLock.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				setErr(fmt.Errorf("connect to worker %s failed", endpoint))
+				return
+			}
+			result, err := NewIndexServiceClient(conn).Count(context.Background(), request)
+			if err != nil {
+				setErr(err)
+				return
+			}
+			atomic.AddUint64(&count, result.Count)
+		}(endpoint)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if request.TerminateAfter > 0 && count > request.TerminateAfter {
+		count = request.TerminateAfter
+	}
+	return &CountResult{Count: count, Exists: count > 0}, nil
+}
+
+// Explain
+// @Description 主键只存在于某一个 worker 上，依次询问各个 worker，返回找到该文档的 worker 的解释结果
+// @Param request 索引名、主键、查询及过滤条件
+// @Return 解释结果，所有 worker 上都没有该主键时 Found 为 false
+func (sentinel *Sentinel) Explain(request *ExplainRequest) (*ExplainResult, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	var lastErr error
+	for _, endpoint := range endpoints {
+		conn := sentinel.GetGrpcConn(endpoint)
+		if conn == nil {
+			lastErr = fmt.Errorf("connect to worker %s failed", endpoint)
+			continue
+		}
+		result, err := NewIndexServiceClient(conn).Explain(context.Background(), request)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if result.GetExplanation().GetFound() {
+			return result, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return &ExplainResult{Explanation: &types.Explanation{}}, nil
+}
+
+// 内部方法，判断是否是关键词和向量的混合检索
+func isHybrid(request *SearchRequest) bool {
+	return request.MoreLikeThis == nil && request.Knn != nil && request.Query != nil && !request.Query.Empty()
+}
+
+// 内部方法，混合检索时每个 worker 返回两路结果窗口内的全部文档，而不是只返回本地融合后的前 Size 条，
+// 否则在某个 worker 上排在 Size 之外、全局排名靠前的文档在 Sentinel 融合之前就被丢弃
+func shardFusionRequest(request *SearchRequest) *SearchRequest {
+	fusion := request.Fusion.Normalize()
+	fusion.Size = 2 * fusion.WindowSize
+	shardRequest := proto.Clone(request).(*SearchRequest)
+	shardRequest.Fusion = fusion
+	return shardRequest
+}
+
+// 内部方法，合并各个 worker 的混合检索结果后重新融合。不同 worker 的 docId 可能重复，
+// 所以融合时用结果在 docs 中的下标代替 docId，融合后再换回原来的 docId
+func fuseSearchHits(fusion *types.Fusion, docs []searchHit) []searchHit {
+	hits := make([]*types.Hit, 0, len(docs))
+	for i, d := range docs {
+		if d.hit == nil {
+			continue
+		}
+		hit := proto.Clone(d.hit).(*types.Hit)
+		hit.DocId = uint64(i)
+		hits = append(hits, hit)
+	}
+	lexical, vector := types.SplitFusedHits(hits)
+	fused := types.FuseHits(fusion, lexical, vector)
+	result := make([]searchHit, 0, len(fused))
+	for _, hit := range fused {
+		d := docs[hit.DocId]
+		hit.DocId = d.hit.DocId
+		result = append(result, searchHit{doc: d.doc, hit: hit, shard: d.shard})
+	}
+	return result
+}
+
+// 内部方法，按字段值折叠已经排好序的合并结果
+func collapseSearchHits(docs []searchHit, collapse *types.Collapse) []searchHit {
+	list := make([]*doc.Document, 0, len(docs))
+	for _, d := range docs {
+		list = append(list, d.doc)
+	}
+	kept, _ := types.CollapseDocs(list, collapse)
+	result := make([]searchHit, 0, len(kept))
+	for _, i := range kept {
+		result = append(result, docs[i])
+	}
+	return result
+}
+
+// 内部方法，合并后按 (排序值, docId, worker) 排序，去掉游标之前的结果并截断到 Size。
+// 一页已满时用最后一条结果生成下一页的游标，不满说明已经没有更多结果
+func pageSearchHits(request *SearchRequest, docs []searchHit) ([]searchHit, *types.Cursor) {
+	_, scored := scoredSize(request)
+	type item struct {
+		searchHit
+		key float64
+	}
+	items := make([]item, 0, len(docs))
+	for _, d := range docs {
+		key := types.CursorSortKey(d.hit, scored, request.GeoSort)
+		if request.SearchAfter.After(key, d.hit.GetDocId(), d.shard) {
+			items = append(items, item{searchHit: d, key: key})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].key != items[j].key {
+			return items[i].key < items[j].key
+		}
+		if items[i].hit.GetDocId() != items[j].hit.GetDocId() {
+			return items[i].hit.GetDocId() < items[j].hit.GetDocId()
+		}
+		return items[i].shard < items[j].shard
+	})
+	if uint64(len(items)) > request.Size {
+		items = items[:request.Size]
+	}
+	page := make([]searchHit, 0, len(items))
+	for _, it := range items {
+		page = append(page, it.searchHit)
+	}
+	if uint64(len(items)) < request.Size {
+		return page, nil
+	}
+	last := items[len(items)-1]
+	return page, &types.Cursor{Sort: last.key, DocId: last.hit.GetDocId(), Shard: last.shard}
+}
+
+// 内部方法，返回按得分排序的查询需要保留的结果数，普通查询不打分时返回 false
+func scoredSize(request *SearchRequest) (uint64, bool) {
+	var size uint64
+	switch {
+	case request.MoreLikeThis != nil:
+		size = request.MoreLikeThis.Size
+	case request.Knn != nil:
+		size = request.Knn.K
+	default:
+		return 0, false
+	}
+	if size == 0 {
+		size = 10
+	}
+	return size, true
 }
 
 func (sentinel *Sentinel) Get(request *DocIdRequest) (*doc.Document, error) {
@@ -206,3 +994,90 @@ func (sentinel *Sentinel) Get(request *DocIdRequest) (*doc.Document, error) {
 	}
 	return docs, nil
 }
+
+func (sentinel *Sentinel) Suggest(request *SuggestRequest) ([]*types.Suggestion, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	lists := make([][]*types.Suggestion, len(endpoints))
+	errs := make([]error, len(endpoints))
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint string) { //每个worker只写自己的下标，不需要加锁
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				errs[i] = fmt.Errorf("connect to worker %s failed", endpoint)
+				return
+			}
+			result, err := NewIndexServiceClient(conn).Suggest(context.Background(), request)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			lists[i] = result.Suggestions
+		}(i, endpoint)
+	}
+	wg.Wait()
+	// 只合并部分 worker 的候选词会漏掉权重更高的词，有 worker 失败时返回错误
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return types.MergeSuggestions(request.Size, lists...), nil
+}
+
+func (sentinel *Sentinel) SpellCheck(request *SpellCheckRequest) (*SpellCheckResult, error) {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	lists := make([][]*types.TermCorrection, len(endpoints))
+	errs := make([]error, len(endpoints))
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				errs[i] = fmt.Errorf("connect to worker %s failed", endpoint)
+				return
+			}
+			result, err := NewIndexServiceClient(conn).SpellCheck(context.Background(), request)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			lists[i] = result.Corrections
+		}(i, endpoint)
+	}
+	wg.Wait()
+	// 词频是各个 worker 之和，少了 worker 时候选词的排序不可信，有 worker 失败时返回错误
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	size := request.Size
+	if size == 0 {
+		size = 5
+	}
+	return newSpellCheckResult(request, types.MergeCorrections(size, lists...)), nil
+}
+
+// newSpellCheckResult 根据纠错结果生成纠错后的查询和文本
+func newSpellCheckResult(request *SpellCheckRequest, corrections []*types.TermCorrection) *SpellCheckResult {
+	result := &SpellCheckResult{Corrections: corrections, CorrectedText: types.CorrectedText(corrections)}
+	if request.Query != nil && !request.Query.Empty() {
+		words := make([]string, 0, len(corrections))
+		for _, c := range corrections {
+			words = append(words, c.Best())
+		}
+		result.Corrected = request.Query.Rewrite(words)
+	}
+	return result
+}
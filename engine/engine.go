@@ -5,7 +5,13 @@ import (
 	"fmt"
 	"github.com/cylScripter/NexusFind/config"
 	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
 	"github.com/cylScripter/NexusFind/utils"
+	"google.golang.org/protobuf/proto"
+	"math"
+	"slices"
+	"sort"
 	"time"
 )
 
@@ -41,13 +47,13 @@ func (isw *IndexServiceWorker) Init(etcdServers []string, heartbeatFrequency int
 
 func (isw *IndexServiceWorker) Register() error {
 	var heartBeat int64 = isw.hub.heartbeatFrequency
-	leaseId, err := isw.hub.Register(INDEX_SERVICE, fmt.Sprintf("%v:%v", isw.LocalIP, isw.LocalPort), 0)
+	leaseId, err := isw.hub.Register(INDEX_SERVICE, isw.endpoint(), 0)
 	if err != nil {
 		panic(err)
 	}
 	go func() {
 		for {
-			_, err = isw.hub.Register(INDEX_SERVICE, fmt.Sprintf("%v:%v", isw.LocalIP, isw.LocalPort), leaseId)
+			_, err = isw.hub.Register(INDEX_SERVICE, isw.endpoint(), leaseId)
 			if err != nil {
 				isw.Logger.NFLog.Error(err.Error())
 			}
@@ -57,6 +63,11 @@ func (isw *IndexServiceWorker) Register() error {
 	return nil
 }
 
+// 内部方法，worker 在注册中心的地址，分页时作为结果所在的分片
+func (isw *IndexServiceWorker) endpoint() string {
+	return fmt.Sprintf("%v:%v", isw.LocalIP, isw.LocalPort)
+}
+
 func (isw *IndexServiceWorker) Close() error {
 	endpoint := fmt.Sprintf("%v:%v", isw.LocalIP, isw.LocalPort)
 	err := isw.idxManager.Close()
@@ -76,7 +87,11 @@ func (isw *IndexServiceWorker) Close() error {
 }
 
 func (isw *IndexServiceWorker) Delete(ctx context.Context, request *DocIdRequest) (*Code, error) {
-	err := isw.idxManager.Delete(request.IndexName, request.DocId)
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return &Code{StatusCode: 0}, err
+	}
+	err = isw.idxManager.Delete(indexName, request.DocId)
 	if err != nil {
 		return &Code{StatusCode: 0}, err
 	}
@@ -85,26 +100,583 @@ func (isw *IndexServiceWorker) Delete(ctx context.Context, request *DocIdRequest
 func (isw *IndexServiceWorker) CreateIndex(ctx context.Context, request *CreateIndexRequest) (*Code, error) {
 	fields := make([]segment.SimpleFieldInfo, 0)
 	for _, iter := range request.FieldInfo {
-		field := segment.SimpleFieldInfo{FieldName: iter.FieldName, FieldType: iter.FieldType}
+		field := segment.SimpleFieldInfo{
+			FieldName:     iter.FieldName,
+			FieldType:     iter.FieldType,
+			Suggest:       iter.Suggest,
+			SuggestWeight: iter.SuggestWeight,
+			Dims:          iter.Dims,
+			Metric:        iter.Metric,
+			DateFormats:   iter.DateFormats,
+			TimeZone:      iter.TimeZone,
+		}
+		if field.FieldType == utils.IDX_TYPE_VECTOR && field.Dims == 0 {
+			return &Code{StatusCode: 0}, fmt.Errorf("vector field [%v] must set dims", field.FieldName)
+		}
+		if _, err := utils.LoadLocation(field.TimeZone); field.FieldType == utils.IDX_TYPE_DATETIME && err != nil {
+			return &Code{StatusCode: 0}, fmt.Errorf("date field [%v] has invalid time zone: %v", field.FieldName, err)
+		}
 		fields = append(fields, field)
 	}
 	err := isw.idxManager.CreateIndex(request.IndexName, fields)
+	if err == nil && (request.TTLField != "" || request.DefaultTTL > 0) {
+		err = isw.idxManager.SetTTL(request.IndexName, request.TTLField, request.DefaultTTL)
+	}
 	return &Code{StatusCode: 1}, err
 }
+
+// StartTTLReaper
+// @Description 启动后台清理过期文档的协程
+// @Param interval 清理间隔
+func (isw *IndexServiceWorker) StartTTLReaper(interval time.Duration) {
+	isw.idxManager.StartTTLReaper(interval)
+}
+
 func (isw *IndexServiceWorker) Add(ctx context.Context, request *AddRequest) (*Code, error) {
-	docid, err := isw.idxManager.Add(request.IndexName, request.Doc)
-	return &Code{StatusCode: docid}, err
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return &Code{StatusCode: 0}, err
+	}
+	docid, err := isw.idxManager.Add(indexName, request.Doc)
+	if err != nil {
+		return &Code{StatusCode: docid}, err
+	}
+	percolated, err := isw.idxManager.Percolate(indexName, request.Doc)
+	if err != nil {
+		// 文档已经写入，返回错误会让调用方误以为写入失败而重试，只记录并在结果中带上原因
+		isw.Logger.NFLog.Errorf("percolate document [%v] on index [%v] failed: %v", request.Doc.GetId(), indexName, err)
+		return &Code{StatusCode: docid, PercolateError: err.Error()}, nil
+	}
+	return &Code{StatusCode: docid, Percolated: percolated}, nil
 }
 func (isw *IndexServiceWorker) Search(ctx context.Context, request *SearchRequest) (*Result, error) {
-	result := isw.idxManager.Search(request.IndexName, request.Query, request.Filter)
-	fmt.Println(len(result))
-	return &Result{DocResult: result}, nil
+	start := time.Now()
+	result, err := isw.search(widenForCollapse(request))
+	if err != nil {
+		return nil, err
+	}
+	if request.Profile {
+		result.Profile.Shards[0].Shard = isw.endpoint()
+		result.Profile.Shards[0].TimeNanos = uint64(time.Since(start).Nanoseconds())
+	}
+	if request.Collapse != nil {
+		collapseResult(result, request.Collapse)
+		if limit, scored := scoredLimit(request); scored {
+			truncateResult(result, limit)
+		}
+	}
+	// 在序列化之前裁剪文档，Sentinel 合并结果时需要的字段先保留，由 Sentinel 最后裁剪
+	include, exclude := projectionFields(request.IncludeFields, request.ExcludeFields, mergeFields(request)...)
+	result.DocResult = projectDocs(result.DocResult, include, exclude, request.ExcludeKeywords)
+	return result, nil
 }
+
+// 内部方法，执行查询，返回未裁剪的文档。别名指向多个索引时依次查询各个索引并拼接结果，由 Sentinel 统一排序
+func (isw *IndexServiceWorker) search(request *SearchRequest) (*Result, error) {
+	indexNames := isw.idxManager.ResolveRead(request.IndexName)
+	if len(indexNames) == 1 {
+		indexRequest := proto.Clone(request).(*SearchRequest)
+		indexRequest.IndexName = indexNames[0]
+		return isw.searchIndex(indexRequest)
+	}
+	// 各个索引的 docId 会重复，游标无法区分
+	if request.Size > 0 {
+		return nil, fmt.Errorf("alias [%v] points to %v indexes, Size paging needs a single index", request.IndexName, len(indexNames))
+	}
+	var merged *Result
+	for _, indexName := range indexNames {
+		indexRequest := proto.Clone(request).(*SearchRequest)
+		indexRequest.IndexName = indexName
+		result, err := isw.searchIndex(indexRequest)
+		if err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = result
+			continue
+		}
+		merged.DocResult = append(merged.DocResult, result.DocResult...)
+		merged.Hits = append(merged.Hits, result.Hits...)
+		merged.TotalHits += result.TotalHits
+		if merged.DidYouMean == nil {
+			merged.DidYouMean = result.DidYouMean
+		}
+		if merged.Profile != nil && result.Profile != nil {
+			merged.Profile.Shards[0].Segments = append(merged.Profile.Shards[0].Segments, result.Profile.Shards[0].Segments...)
+		}
+	}
+	return merged, nil
+}
+
+// 内部方法，在一个索引上执行查询
+func (isw *IndexServiceWorker) searchIndex(request *SearchRequest) (*Result, error) {
+	if err := isw.idxManager.ValidateFilters(request.IndexName, request.Filter); err != nil {
+		return nil, err
+	}
+	if request.Collapse != nil {
+		if request.Size > 0 {
+			return nil, fmt.Errorf("collapse can not be used with Size paging")
+		}
+		if err := isw.idxManager.ValidateCollapse(request.IndexName, request.Collapse); err != nil {
+			return nil, err
+		}
+	}
+	// 记录耗时只是在原来的执行路径上计时，不改变返回的结果
+	var profile *types.ShardProfile
+	if request.Profile {
+		profile = &types.ShardProfile{}
+	}
+	if request.MoreLikeThis != nil {
+		docs, hits, total, err := isw.idxManager.MoreLikeThis(request.IndexName, request.MoreLikeThis, request.Filter, profile)
+		if err != nil {
+			return nil, err
+		}
+		return pageResult(request, withProfile(newScoredResult(request, docs, hits, total), profile), isw.endpoint()), nil
+	}
+	if isHybrid(request) {
+		docs, hits, total, err := isw.idxManager.Hybrid(request.IndexName, request.Query, request.Knn, request.Fusion, request.Filter, profile)
+		if err != nil {
+			return nil, err
+		}
+		return pageResult(request, withProfile(newScoredResult(request, docs, hits, total), profile), isw.endpoint()), nil
+	}
+	if request.Knn != nil {
+		docs, hits, err := isw.idxManager.Knn(request.IndexName, request.Knn, request.Filter, profile)
+		if err != nil {
+			return nil, err
+		}
+		// kNN 只会命中得分最高的 K 篇文档，命中总数即返回的条数
+		return pageResult(request, withProfile(newScoredResult(request, docs, hits, uint64(len(docs))), profile), isw.endpoint()), nil
+	}
+	var response *Result
+	if request.Size > 0 {
+		// 不按距离排序时按 docId 分页，只需要读取这一页的文档；按距离排序时需要全部命中的文档
+		after, size := uint64(0), uint64(0)
+		if request.GeoSort == nil {
+			after, size = searchAfterDocId(request.SearchAfter, isw.endpoint()), request.Size
+		}
+		var docs []*doc.Document
+		var docIds []uint64
+		var err error
+		if profile != nil {
+			docs, docIds, profile.Segments, err = isw.idxManager.SearchProfile(request.IndexName, request.Query, request.Filter, after, size)
+		} else {
+			docs, docIds, err = isw.idxManager.SearchAfter(request.IndexName, request.Query, request.Filter, after, size)
+		}
+		if err != nil {
+			return nil, err
+		}
+		hits := make([]*types.Hit, 0, len(docIds))
+		for _, docId := range docIds {
+			hits = append(hits, &types.Hit{DocId: docId})
+		}
+		response = &Result{DocResult: docs, Hits: hits}
+		if request.GeoSort != nil {
+			response.DocResult, response.Hits = types.SortByDistance(docs, hits, request.GeoSort)
+		}
+	} else {
+		result := isw.idxManager.Search(request.IndexName, request.Query, request.Filter, profile)
+		response = &Result{DocResult: result}
+		if request.GeoSort != nil {
+			response.DocResult, response.Hits = types.SortByDistance(result, nil, request.GeoSort)
+		}
+	}
+	if request.TrackTotalHits {
+		total, err := isw.idxManager.Count(request.IndexName, request.Query, request.Filter, 0)
+		if err != nil {
+			return nil, err
+		}
+		response.TotalHits = total
+	}
+	return pageResult(request, withProfile(response, profile), isw.endpoint()), nil
+}
+
+// 内部方法，记录了耗时时把段的耗时信息放到结果中，worker 的总耗时由 Search 补齐
+func withProfile(result *Result, profile *types.ShardProfile) *Result {
+	if profile != nil {
+		result.Profile = &types.SearchProfile{Shards: []*types.ShardProfile{profile}}
+	}
+	return result
+}
+
+// 内部方法，分页时去掉游标之前的结果并截断到 Size。混合检索需要在 Sentinel 上重新融合得分，只在 Sentinel 上分页
+func pageResult(request *SearchRequest, result *Result, shard string) *Result {
+	result.Shard = shard
+	if request.Size == 0 || isHybrid(request) {
+		return result
+	}
+	_, scored := scoredSize(request)
+	type item struct {
+		doc *doc.Document
+		hit *types.Hit
+		key float64
+	}
+	items := make([]item, 0, len(result.DocResult))
+	for i, d := range result.DocResult {
+		hit := result.Hits[i]
+		key := types.CursorSortKey(hit, scored, request.GeoSort)
+		if request.SearchAfter.After(key, hit.DocId, shard) {
+			items = append(items, item{doc: d, hit: hit, key: key})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].key != items[j].key {
+			return items[i].key < items[j].key
+		}
+		return items[i].hit.DocId < items[j].hit.DocId
+	})
+	if uint64(len(items)) > request.Size {
+		items = items[:request.Size]
+	}
+	page := &Result{DocResult: make([]*doc.Document, 0, len(items)), Hits: make([]*types.Hit, 0, len(items)), TotalHits: result.TotalHits, Shard: shard, Profile: result.Profile}
+	for _, it := range items {
+		page.DocResult = append(page.DocResult, it.doc)
+		page.Hits = append(page.Hits, it.hit)
+	}
+	return page
+}
+
+// 内部方法，Sentinel 合并各个 worker 的结果时需要读取的字段，例如按距离重新排序的坐标字段、折叠字段
+func mergeFields(request *SearchRequest) []string {
+	fields := make([]string, 0, 2)
+	if request.GeoSort != nil {
+		fields = append(fields, request.GeoSort.Field)
+	}
+	if request.Collapse != nil {
+		fields = append(fields, request.Collapse.Field)
+	}
+	return fields
+}
+
+// 内部方法，按字段值折叠结果，Hits 与 DocResult 一一对应时一起折叠，并返回每个值命中的文档数
+func collapseResult(result *Result, collapse *types.Collapse) {
+	kept, counts := types.CollapseDocs(result.DocResult, collapse)
+	aligned := len(result.Hits) == len(result.DocResult)
+	docs := make([]*doc.Document, 0, len(kept))
+	hits := make([]*types.Hit, 0, len(kept))
+	for _, i := range kept {
+		docs = append(docs, result.DocResult[i])
+		if aligned {
+			hits = append(hits, result.Hits[i])
+		}
+	}
+	result.DocResult = docs
+	if aligned {
+		result.Hits = hits
+	}
+	result.CollapseCounts = counts
+}
+
+// 内部方法，打分查询最终返回的条数，即 MoreLikeThis.Size、Knn.K 或者混合检索的 Fusion.Size
+func scoredLimit(request *SearchRequest) (uint64, bool) {
+	if isHybrid(request) {
+		return request.Fusion.Normalize().Size, true
+	}
+	return scoredSize(request)
+}
+
+// 内部方法，折叠打分查询的结果时先取回截断之前的结果，折叠之后再截断，否则一页中会因为折叠少于 Size 条。
+// kNN 的 K 决定的是候选文档集合，不放大
+func widenForCollapse(request *SearchRequest) *SearchRequest {
+	if request.Collapse == nil {
+		return request
+	}
+	if isHybrid(request) {
+		return shardFusionRequest(request)
+	}
+	if request.MoreLikeThis != nil {
+		widened := proto.Clone(request).(*SearchRequest)
+		widened.MoreLikeThis.Size = math.MaxUint32
+		return widened
+	}
+	return request
+}
+
+// 内部方法，把结果截断到 size 条，Hits 与 DocResult 一一对应时一起截断
+func truncateResult(result *Result, size uint64) {
+	if uint64(len(result.DocResult)) <= size {
+		return
+	}
+	if len(result.Hits) == len(result.DocResult) {
+		result.Hits = result.Hits[:size]
+	}
+	result.DocResult = result.DocResult[:size]
+}
+
+// 内部方法，在请求的字段列表基础上保留 keep 中的字段：IncludeFields 不为空时加入 keep，ExcludeFields 中去掉 keep
+func projectionFields(include, exclude []string, keep ...string) ([]string, []string) {
+	if len(keep) == 0 {
+		return include, exclude
+	}
+	if len(include) > 0 {
+		include = append(append(make([]string, 0, len(include)+len(keep)), include...), keep...)
+	}
+	kept := make([]string, 0, len(exclude))
+	for _, field := range exclude {
+		if !slices.Contains(keep, field) {
+			kept = append(kept, field)
+		}
+	}
+	return include, kept
+}
+
+// 内部方法，按字段列表裁剪一组文档
+func projectDocs(docs []*doc.Document, include, exclude []string, dropKeywords bool) []*doc.Document {
+	if len(include) == 0 && len(exclude) == 0 && !dropKeywords {
+		return docs
+	}
+	result := make([]*doc.Document, 0, len(docs))
+	for _, d := range docs {
+		result = append(result, d.Project(include, exclude, dropKeywords))
+	}
+	return result
+}
+
+// 内部方法，按 docId 分页时本 worker 上第一个需要返回的 docId。
+// 结果按 (docId, worker) 排序，docId 与游标相同的文档只有在本 worker 排在游标所在 worker 之后时才返回
+func searchAfterDocId(cursor *types.Cursor, shard string) uint64 {
+	if cursor == nil {
+		return 0
+	}
+	if cursor.Shard < shard {
+		return cursor.DocId
+	}
+	return cursor.DocId + 1
+}
+
+// 内部方法，打分查询的结果已经按 K 或者 Size 截断，total 为截断之前命中的文档总数
+func newScoredResult(request *SearchRequest, docs []*doc.Document, hits []*types.Hit, total uint64) *Result {
+	result := &Result{DocResult: docs, Hits: hits}
+	if request.TrackTotalHits {
+		result.TotalHits = total
+	}
+	return result
+}
+
+func (isw *IndexServiceWorker) Count(ctx context.Context, request *SearchRequest) (*CountResult, error) {
+	if request.MoreLikeThis != nil || request.Knn != nil {
+		return nil, fmt.Errorf("count only supports query and filters")
+	}
+	var count uint64
+	for _, indexName := range isw.idxManager.ResolveRead(request.IndexName) {
+		if err := isw.idxManager.ValidateFilters(indexName, request.Filter); err != nil {
+			return nil, err
+		}
+		n, err := isw.idxManager.Count(indexName, request.Query, request.Filter, request.TerminateAfter)
+		if err != nil {
+			return nil, err
+		}
+		count += n
+	}
+	if request.TerminateAfter > 0 && count > request.TerminateAfter {
+		count = request.TerminateAfter
+	}
+	return &CountResult{Count: count, Exists: count > 0}, nil
+}
+
+func (isw *IndexServiceWorker) WatchChanges(request *WatchRequest, stream IndexService_WatchChangesServer) error {
+	from := request.FromSeq
+	if seq, ok := request.FromSeqs[isw.endpoint()]; ok {
+		from = seq
+	}
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return err
+	}
+	return isw.idxManager.Watch(stream.Context(), indexName, from, request.IncludeDocument, func(event *types.ChangeEvent) error {
+		event.Shard = isw.endpoint()
+		return stream.Send(event)
+	})
+}
+
+func (isw *IndexServiceWorker) RegisterPercolator(ctx context.Context, request *PercolatorRequest) (*Code, error) {
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	if err := isw.idxManager.RegisterPercolator(indexName, request.Query); err != nil {
+		return nil, err
+	}
+	return &Code{StatusCode: 1}, nil
+}
+
+func (isw *IndexServiceWorker) DeletePercolator(ctx context.Context, request *PercolatorRequest) (*Code, error) {
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	if err := isw.idxManager.DeletePercolator(indexName, request.Query.GetId()); err != nil {
+		return nil, err
+	}
+	return &Code{StatusCode: 1}, nil
+}
+
+func (isw *IndexServiceWorker) DropIndex(ctx context.Context, request *DropIndexRequest) (*Code, error) {
+	if err := isw.idxManager.DropIndex(request.IndexName); err != nil {
+		return nil, err
+	}
+	return &Code{StatusCode: 1}, nil
+}
+
+func (isw *IndexServiceWorker) DeleteByQuery(ctx context.Context, request *DeleteByQueryRequest) (*DeleteByQueryResult, error) {
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	if err := isw.idxManager.ValidateFilters(indexName, request.Filter); err != nil {
+		return nil, err
+	}
+	deleted, err := isw.idxManager.DeleteByQuery(indexName, request.Query, request.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteByQueryResult{Deleted: deleted}, nil
+}
+
+func (isw *IndexServiceWorker) SwitchAlias(ctx context.Context, request *SwitchAliasRequest) (*Code, error) {
+	previous, err := isw.idxManager.SwitchAlias(request.Alias, request.Indexes, request.WriteIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &Code{StatusCode: 1, PreviousIndexes: previous.Indexes, PreviousWriteIndex: previous.WriteIndex}, nil
+}
+
+// Reindex
+// @Description 在后台把本 worker 上源索引的文档复制到目标索引
+// @Param request 任务 Id、源索引、目标索引以及可选的查询条件
+// @Return 任务开始时的进度
+func (isw *IndexServiceWorker) Reindex(ctx context.Context, request *ReindexRequest) (*ReindexStatus, error) {
+	jobId := request.JobId
+	if jobId == "" {
+		jobId = fmt.Sprintf("reindex-%v", time.Now().UnixNano())
+	}
+	source, err := isw.idxManager.ResolveWrite(request.Source)
+	if err != nil {
+		return nil, err
+	}
+	if err := isw.idxManager.ValidateFilters(source, request.Filter); err != nil {
+		return nil, err
+	}
+	return isw.idxManager.Reindex(jobId, source, request.Dest, request.Query, request.Filter)
+}
+
+func (isw *IndexServiceWorker) GetReindexStatus(ctx context.Context, request *ReindexStatusRequest) (*ReindexStatus, error) {
+	return isw.idxManager.ReindexStatus(request.JobId)
+}
+
+func (isw *IndexServiceWorker) Stats(ctx context.Context, request *StatsRequest) (*StatsResult, error) {
+	filterCache := isw.idxManager.FilterCacheStats()
+	return &StatsResult{Workers: []*WorkerStats{{
+		Shard: isw.endpoint(),
+		FilterCache: &FilterCacheStats{
+			Hits:      filterCache.Hits,
+			Misses:    filterCache.Misses,
+			Evictions: filterCache.Evictions,
+			Entries:   filterCache.Entries,
+			Bytes:     filterCache.Bytes,
+			Capacity:  filterCache.Capacity,
+		},
+		TTL: isw.idxManager.TTLStats(),
+	}}}, nil
+}
+
+func (isw *IndexServiceWorker) Percolate(ctx context.Context, request *PercolateRequest) (*PercolateResult, error) {
+	indexName, err := isw.idxManager.ResolveWrite(request.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	queryIds, err := isw.idxManager.Percolate(indexName, request.Doc)
+	if err != nil {
+		return nil, err
+	}
+	return &PercolateResult{QueryIds: queryIds}, nil
+}
+
+// Explain
+// @Description 解释本 worker 上某个文档为什么命中或者没有命中查询
+// @Param request 索引名、主键、查询及过滤条件
+// @Return 解释结果，主键不在本 worker 上时 Found 为 false。别名指向多个索引时返回第一个找到该主键的索引的结果
+func (isw *IndexServiceWorker) Explain(ctx context.Context, request *ExplainRequest) (*ExplainResult, error) {
+	var explanation *types.Explanation
+	for _, indexName := range isw.idxManager.ResolveRead(request.IndexName) {
+		if err := isw.idxManager.ValidateFilters(indexName, request.Filter); err != nil {
+			return nil, err
+		}
+		var err error
+		explanation, err = isw.idxManager.Explain(indexName, request.DocId, request.Query, request.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if explanation.GetFound() {
+			break
+		}
+	}
+	return &ExplainResult{Explanation: explanation, Shard: isw.endpoint()}, nil
+}
+
 func (isw *IndexServiceWorker) Get(ctx context.Context, request *DocIdRequest) (*GetResult, error) {
-	doc, exist := isw.idxManager.Get(request.IndexName, request.DocId)
-	if exist {
-		return &GetResult{Doc: doc, Exist: exist}, nil
+	for _, indexName := range isw.idxManager.ResolveRead(request.IndexName) {
+		doc, exist := isw.idxManager.Get(indexName, request.DocId)
+		if exist {
+			return &GetResult{Doc: doc.Project(request.IncludeFields, request.ExcludeFields, request.ExcludeKeywords), Exist: exist}, nil
+		}
 	}
 	isw.Logger.NFLog.Errorf("document [%v] no has exists", request.DocId)
 	return nil, fmt.Errorf("document [%v] no has exists", request.DocId)
 }
+
+// Suggest
+// @Description 返回前缀补全的候选词，别名指向多个索引时合并各个索引的候选词
+// @Param request 索引名或者别名、字段、前缀及返回个数
+// @Return 候选词
+func (isw *IndexServiceWorker) Suggest(ctx context.Context, request *SuggestRequest) (*SuggestResult, error) {
+	indexNames := isw.idxManager.ResolveRead(request.IndexName)
+	lists := make([][]*types.Suggestion, 0, len(indexNames))
+	for _, indexName := range indexNames {
+		suggestions, err := isw.idxManager.Suggest(indexName, request.Field, request.Prefix, request.Size)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, suggestions)
+	}
+	if len(lists) == 1 {
+		return &SuggestResult{Suggestions: lists[0]}, nil
+	}
+	return &SuggestResult{Suggestions: types.MergeSuggestions(request.Size, lists...)}, nil
+}
+
+func (isw *IndexServiceWorker) Generation(ctx context.Context, request *GenerationRequest) (*GenerationResult, error) {
+	// 别名的版本号为各个索引的版本号之和，切换别名后会变化
+	var generation uint64
+	for _, indexName := range isw.idxManager.ResolveRead(request.IndexName) {
+		if isw.idxManager.GetIndex(indexName) == nil {
+			return nil, fmt.Errorf("index[%v] not found", indexName)
+		}
+		generation += isw.idxManager.Generation(indexName)
+	}
+	return &GenerationResult{Generation: generation}, nil
+}
+
+// SpellCheck
+// @Description 对文本中的词给出纠错候选，别名指向多个索引时合并各个索引的候选词
+// @Param request 索引名或者别名、字段、文本或者查询
+// @Return 纠错结果
+func (isw *IndexServiceWorker) SpellCheck(ctx context.Context, request *SpellCheckRequest) (*SpellCheckResult, error) {
+	indexNames := isw.idxManager.ResolveRead(request.IndexName)
+	lists := make([][]*types.TermCorrection, 0, len(indexNames))
+	for _, indexName := range indexNames {
+		corrections, err := isw.idxManager.SpellCheck(indexName, request)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, corrections)
+	}
+	if len(lists) == 1 {
+		return newSpellCheckResult(request, lists[0]), nil
+	}
+	size := request.Size
+	if size == 0 {
+		size = 5
+	}
+	return newSpellCheckResult(request, types.MergeCorrections(size, lists...)), nil
+}
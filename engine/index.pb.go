@@ -8,7 +8,7 @@ package engine
 
 import (
 	types "github.com/cylScripter/NexusFind/types"
-	"github.com/cylScripter/NexusFind/types/doc"
+	doc "github.com/cylScripter/NexusFind/types/doc"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -27,8 +27,14 @@ type SimpleFieldInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FieldName string `protobuf:"bytes,1,opt,name=FieldName,proto3" json:"FieldName,omitempty"`
-	FieldType uint64 `protobuf:"varint,2,opt,name=FieldType,proto3" json:"FieldType,omitempty"`
+	FieldName     string   `protobuf:"bytes,1,opt,name=FieldName,proto3" json:"FieldName,omitempty"`
+	FieldType     uint64   `protobuf:"varint,2,opt,name=FieldType,proto3" json:"FieldType,omitempty"`
+	Suggest       bool     `protobuf:"varint,3,opt,name=Suggest,proto3" json:"Suggest,omitempty"`            // 是否为该字段建立补全词典
+	SuggestWeight string   `protobuf:"bytes,4,opt,name=SuggestWeight,proto3" json:"SuggestWeight,omitempty"` // 补全权重取自哪个数值字段，例如 likeCount
+	Dims          uint64   `protobuf:"varint,5,opt,name=Dims,proto3" json:"Dims,omitempty"`                  // 向量类型字段的维度
+	Metric        uint64   `protobuf:"varint,6,opt,name=Metric,proto3" json:"Metric,omitempty"`              // 向量类型字段的相似度，默认余弦相似度
+	DateFormats   []string `protobuf:"bytes,7,rep,name=DateFormats,proto3" json:"DateFormats,omitempty"`     // 毫秒日期字段接受的格式，rfc3339、epoch_millis、epoch_second 或者 Go 的时间布局
+	TimeZone      string   `protobuf:"bytes,8,opt,name=TimeZone,proto3" json:"TimeZone,omitempty"`           // 毫秒日期字段的时区，例如 Asia/Shanghai，默认本地时区
 }
 
 func (x *SimpleFieldInfo) Reset() {
@@ -77,13 +83,57 @@ func (x *SimpleFieldInfo) GetFieldType() uint64 {
 	return 0
 }
 
+func (x *SimpleFieldInfo) GetSuggest() bool {
+	if x != nil {
+		return x.Suggest
+	}
+	return false
+}
+
+func (x *SimpleFieldInfo) GetSuggestWeight() string {
+	if x != nil {
+		return x.SuggestWeight
+	}
+	return ""
+}
+
+func (x *SimpleFieldInfo) GetDims() uint64 {
+	if x != nil {
+		return x.Dims
+	}
+	return 0
+}
+
+func (x *SimpleFieldInfo) GetMetric() uint64 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+func (x *SimpleFieldInfo) GetDateFormats() []string {
+	if x != nil {
+		return x.DateFormats
+	}
+	return nil
+}
+
+func (x *SimpleFieldInfo) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
 type CreateIndexRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexName string             `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
-	FieldInfo []*SimpleFieldInfo `protobuf:"bytes,2,rep,name=FieldInfo,proto3" json:"FieldInfo,omitempty"`
+	IndexName  string             `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	FieldInfo  []*SimpleFieldInfo `protobuf:"bytes,2,rep,name=FieldInfo,proto3" json:"FieldInfo,omitempty"`
+	TTLField   string             `protobuf:"bytes,3,opt,name=TTLField,proto3" json:"TTLField,omitempty"`      // 判断文档过期的日期字段，DefaultTTL 为 0 时字段值即过期时间
+	DefaultTTL int64              `protobuf:"varint,4,opt,name=DefaultTTL,proto3" json:"DefaultTTL,omitempty"` // 文档的有效期，单位秒，从 TTLField 开始计算，没有 TTLField 时从写入时间开始计算
 }
 
 func (x *CreateIndexRequest) Reset() {
@@ -132,6 +182,20 @@ func (x *CreateIndexRequest) GetFieldInfo() []*SimpleFieldInfo {
 	return nil
 }
 
+func (x *CreateIndexRequest) GetTTLField() string {
+	if x != nil {
+		return x.TTLField
+	}
+	return ""
+}
+
+func (x *CreateIndexRequest) GetDefaultTTL() int64 {
+	if x != nil {
+		return x.DefaultTTL
+	}
+	return 0
+}
+
 type AddRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -192,8 +256,11 @@ type DocIdRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexName string `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
-	DocId     string `protobuf:"bytes,2,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	IndexName       string   `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	DocId           string   `protobuf:"bytes,2,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	IncludeFields   []string `protobuf:"bytes,3,rep,name=IncludeFields,proto3" json:"IncludeFields,omitempty"`      // 只返回这些字段，为空时返回全部字段
+	ExcludeFields   []string `protobuf:"bytes,4,rep,name=ExcludeFields,proto3" json:"ExcludeFields,omitempty"`      // 不返回这些字段，优先于 IncludeFields
+	ExcludeKeywords bool     `protobuf:"varint,5,opt,name=ExcludeKeywords,proto3" json:"ExcludeKeywords,omitempty"` // 为 true 时不返回 Keywords 词频列表
 }
 
 func (x *DocIdRequest) Reset() {
@@ -242,14 +309,48 @@ func (x *DocIdRequest) GetDocId() string {
 	return ""
 }
 
+func (x *DocIdRequest) GetIncludeFields() []string {
+	if x != nil {
+		return x.IncludeFields
+	}
+	return nil
+}
+
+func (x *DocIdRequest) GetExcludeFields() []string {
+	if x != nil {
+		return x.ExcludeFields
+	}
+	return nil
+}
+
+func (x *DocIdRequest) GetExcludeKeywords() bool {
+	if x != nil {
+		return x.ExcludeKeywords
+	}
+	return false
+}
+
 type SearchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexName string                 `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
-	Query     *types.TermQuery       `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
-	Filter    []*types.SearchFilters `protobuf:"bytes,3,rep,name=Filter,proto3" json:"Filter,omitempty"`
+	IndexName       string                 `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Query           *types.TermQuery       `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
+	Filter          []*types.SearchFilters `protobuf:"bytes,3,rep,name=Filter,proto3" json:"Filter,omitempty"`                     // 所有条件都要满足，条件可以是 FILT_AND、FILT_OR、FILT_NOT 组成的树
+	MoreLikeThis    *types.MoreLikeThis    `protobuf:"bytes,4,opt,name=MoreLikeThis,proto3" json:"MoreLikeThis,omitempty"`         // 不为空时忽略 Query，查找与给定文档相似的文档
+	Knn             *types.KnnQuery        `protobuf:"bytes,5,opt,name=Knn,proto3" json:"Knn,omitempty"`                           // 不为空且 Query 为空时，查找向量最相近的文档
+	Fusion          *types.Fusion          `protobuf:"bytes,6,opt,name=Fusion,proto3" json:"Fusion,omitempty"`                     // Query 和 Knn 同时存在时的融合方式
+	GeoSort         *types.GeoSort         `protobuf:"bytes,7,opt,name=GeoSort,proto3" json:"GeoSort,omitempty"`                   // 不为空时按到某个点的距离排序
+	TrackTotalHits  bool                   `protobuf:"varint,8,opt,name=TrackTotalHits,proto3" json:"TrackTotalHits,omitempty"`    // 为 true 时在 Result.TotalHits 中返回命中的总数
+	TerminateAfter  uint64                 `protobuf:"varint,9,opt,name=TerminateAfter,proto3" json:"TerminateAfter,omitempty"`    // Count 统计到这么多条后提前结束，为 1 时只判断是否存在
+	Size            uint64                 `protobuf:"varint,10,opt,name=Size,proto3" json:"Size,omitempty"`                       // 大于 0 时分页返回，每页最多 Size 条，顺序稳定
+	SearchAfter     *types.Cursor          `protobuf:"bytes,11,opt,name=SearchAfter,proto3" json:"SearchAfter,omitempty"`          // 上一页返回的 NextCursor，为空时从第一条开始
+	IncludeFields   []string               `protobuf:"bytes,12,rep,name=IncludeFields,proto3" json:"IncludeFields,omitempty"`      // 只返回这些字段，为空时返回全部字段
+	ExcludeFields   []string               `protobuf:"bytes,13,rep,name=ExcludeFields,proto3" json:"ExcludeFields,omitempty"`      // 不返回这些字段，优先于 IncludeFields
+	ExcludeKeywords bool                   `protobuf:"varint,14,opt,name=ExcludeKeywords,proto3" json:"ExcludeKeywords,omitempty"` // 为 true 时不返回 Keywords 词频列表
+	Collapse        *types.Collapse        `protobuf:"bytes,15,opt,name=Collapse,proto3" json:"Collapse,omitempty"`                // 不为空时按字段值折叠结果，不能与 Size 分页同时使用
+	Profile         bool                   `protobuf:"varint,16,opt,name=Profile,proto3" json:"Profile,omitempty"`                 // 为 true 时在 Result.Profile 中返回各个 worker、各个段的耗时
 }
 
 func (x *SearchRequest) Reset() {
@@ -305,12 +406,110 @@ func (x *SearchRequest) GetFilter() []*types.SearchFilters {
 	return nil
 }
 
+func (x *SearchRequest) GetMoreLikeThis() *types.MoreLikeThis {
+	if x != nil {
+		return x.MoreLikeThis
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetKnn() *types.KnnQuery {
+	if x != nil {
+		return x.Knn
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetFusion() *types.Fusion {
+	if x != nil {
+		return x.Fusion
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetGeoSort() *types.GeoSort {
+	if x != nil {
+		return x.GeoSort
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTrackTotalHits() bool {
+	if x != nil {
+		return x.TrackTotalHits
+	}
+	return false
+}
+
+func (x *SearchRequest) GetTerminateAfter() uint64 {
+	if x != nil {
+		return x.TerminateAfter
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetSearchAfter() *types.Cursor {
+	if x != nil {
+		return x.SearchAfter
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetIncludeFields() []string {
+	if x != nil {
+		return x.IncludeFields
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludeFields() []string {
+	if x != nil {
+		return x.ExcludeFields
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExcludeKeywords() bool {
+	if x != nil {
+		return x.ExcludeKeywords
+	}
+	return false
+}
+
+func (x *SearchRequest) GetCollapse() *types.Collapse {
+	if x != nil {
+		return x.Collapse
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetProfile() bool {
+	if x != nil {
+		return x.Profile
+	}
+	return false
+}
+
 type Result struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DocResult []*doc.Document `protobuf:"bytes,1,rep,name=DocResult,proto3" json:"DocResult,omitempty"`
+	DocResult      []*doc.Document      `protobuf:"bytes,1,rep,name=DocResult,proto3" json:"DocResult,omitempty"`
+	DidYouMean     *SpellCheckResult    `protobuf:"bytes,2,opt,name=DidYouMean,proto3" json:"DidYouMean,omitempty"`                                                                                                  // 没有命中任何文档时给出的纠错建议
+	Hits           []*types.Hit         `protobuf:"bytes,3,rep,name=Hits,proto3" json:"Hits,omitempty"`                                                                                                              // 与 DocResult 一一对应的打分信息，只有需要打分的查询才会返回
+	TotalHits      uint64               `protobuf:"varint,4,opt,name=TotalHits,proto3" json:"TotalHits,omitempty"`                                                                                                   // TrackTotalHits 为 true 时返回命中的总数，kNN 查询时不超过 K
+	NextCursor     *types.Cursor        `protobuf:"bytes,5,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`                                                                                                  // 分页时下一页的游标，没有更多结果时为空
+	Shard          string               `protobuf:"bytes,6,opt,name=Shard,proto3" json:"Shard,omitempty"`                                                                                                            // 返回结果的 worker
+	CollapseCounts map[string]uint64    `protobuf:"bytes,7,rep,name=CollapseCounts,proto3" json:"CollapseCounts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // 折叠时每个值命中的文档数，包括被折叠掉的文档
+	Profile        *types.SearchProfile `protobuf:"bytes,8,opt,name=Profile,proto3" json:"Profile,omitempty"`                                                                                                        // Profile 为 true 时返回的耗时信息
 }
 
 func (x *Result) Reset() {
@@ -352,12 +551,65 @@ func (x *Result) GetDocResult() []*doc.Document {
 	return nil
 }
 
+func (x *Result) GetDidYouMean() *SpellCheckResult {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return nil
+}
+
+func (x *Result) GetHits() []*types.Hit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *Result) GetTotalHits() uint64 {
+	if x != nil {
+		return x.TotalHits
+	}
+	return 0
+}
+
+func (x *Result) GetNextCursor() *types.Cursor {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
+func (x *Result) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+func (x *Result) GetCollapseCounts() map[string]uint64 {
+	if x != nil {
+		return x.CollapseCounts
+	}
+	return nil
+}
+
+func (x *Result) GetProfile() *types.SearchProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
 type Code struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StatusCode uint64 `protobuf:"varint,1,opt,name=StatusCode,proto3" json:"StatusCode,omitempty"`
+	StatusCode         uint64   `protobuf:"varint,1,opt,name=StatusCode,proto3" json:"StatusCode,omitempty"`
+	Percolated         []string `protobuf:"bytes,2,rep,name=Percolated,proto3" json:"Percolated,omitempty"`                 // Add 时新文档命中的已注册查询的 Id
+	PercolateError     string   `protobuf:"bytes,3,opt,name=PercolateError,proto3" json:"PercolateError,omitempty"`         // Add 时文档已经写入，但判断命中哪些查询失败的原因
+	PreviousIndexes    []string `protobuf:"bytes,4,rep,name=PreviousIndexes,proto3" json:"PreviousIndexes,omitempty"`       // SwitchAlias 时切换前别名指向的索引，别名不存在时为空
+	PreviousWriteIndex string   `protobuf:"bytes,5,opt,name=PreviousWriteIndex,proto3" json:"PreviousWriteIndex,omitempty"` // SwitchAlias 时切换前的写索引
 }
 
 func (x *Code) Reset() {
@@ -399,17 +651,47 @@ func (x *Code) GetStatusCode() uint64 {
 	return 0
 }
 
-type GetResult struct {
+func (x *Code) GetPercolated() []string {
+	if x != nil {
+		return x.Percolated
+	}
+	return nil
+}
+
+func (x *Code) GetPercolateError() string {
+	if x != nil {
+		return x.PercolateError
+	}
+	return ""
+}
+
+func (x *Code) GetPreviousIndexes() []string {
+	if x != nil {
+		return x.PreviousIndexes
+	}
+	return nil
+}
+
+func (x *Code) GetPreviousWriteIndex() string {
+	if x != nil {
+		return x.PreviousWriteIndex
+	}
+	return ""
+}
+
+type SuggestRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Doc   *doc.Document `protobuf:"bytes,1,opt,name=Doc,proto3" json:"Doc,omitempty"`
-	Exist bool          `protobuf:"varint,2,opt,name=Exist,proto3" json:"Exist,omitempty"`
+	IndexName string `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Field     string `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Prefix    string `protobuf:"bytes,3,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Size      uint64 `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
 }
 
-func (x *GetResult) Reset() {
-	*x = GetResult{}
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_engine_index_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -417,13 +699,13 @@ func (x *GetResult) Reset() {
 	}
 }
 
-func (x *GetResult) String() string {
+func (x *SuggestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResult) ProtoMessage() {}
+func (*SuggestRequest) ProtoMessage() {}
 
-func (x *GetResult) ProtoReflect() protoreflect.Message {
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_engine_index_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -435,162 +717,2272 @@ func (x *GetResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResult.ProtoReflect.Descriptor instead.
-func (*GetResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
 	return file_engine_index_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetResult) GetDoc() *doc.Document {
+func (x *SuggestRequest) GetIndexName() string {
 	if x != nil {
-		return x.Doc
+		return x.IndexName
 	}
-	return nil
+	return ""
 }
 
-func (x *GetResult) GetExist() bool {
+func (x *SuggestRequest) GetField() string {
 	if x != nil {
-		return x.Exist
+		return x.Field
 	}
-	return false
+	return ""
 }
 
-var File_engine_index_proto protoreflect.FileDescriptor
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
 
-var file_engine_index_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x1a, 0x16, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x64, 0x6f, 0x63, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x0f, 0x53, 0x69, 0x6d, 0x70, 0x6c,
-	0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0x69, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66,
-	0x6f, 0x22, 0x4b, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
-	0x03, 0x44, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63,
-	0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x44, 0x6f, 0x63, 0x22, 0x42,
-	0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
-	0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x44, 0x6f, 0x63,
-	0x49, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x09, 0x44, 0x6f, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x44, 0x6f, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
-	0x26, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x03, 0x44, 0x6f, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x03, 0x44, 0x6f, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x78, 0x69, 0x73, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x45, 0x78, 0x69, 0x73, 0x74, 0x32, 0xff, 0x01, 0x0a, 0x0c,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x06,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
-	0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65,
-	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x03, 0x41, 0x64,
-	0x64, 0x12, 0x12, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43,
-	0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x15, 0x2e,
-	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x2e, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x14, 0x2e, 0x65, 0x6e,
-	0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x11, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x12, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x0b, 0x5a,
-	0x09, 0x2e, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *SuggestRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
 }
 
-var (
-	file_engine_index_proto_rawDescOnce sync.Once
-	file_engine_index_proto_rawDescData = file_engine_index_proto_rawDesc
-)
+type SuggestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_engine_index_proto_rawDescGZIP() []byte {
-	file_engine_index_proto_rawDescOnce.Do(func() {
-		file_engine_index_proto_rawDescData = protoimpl.X.CompressGZIP(file_engine_index_proto_rawDescData)
-	})
-	return file_engine_index_proto_rawDescData
+	Suggestions []*types.Suggestion `protobuf:"bytes,1,rep,name=Suggestions,proto3" json:"Suggestions,omitempty"`
 }
 
-var file_engine_index_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_engine_index_proto_goTypes = []interface{}{
-	(*SimpleFieldInfo)(nil),     // 0: engine.SimpleFieldInfo
-	(*CreateIndexRequest)(nil),  // 1: engine.CreateIndexRequest
-	(*AddRequest)(nil),          // 2: engine.AddRequest
-	(*DocIdRequest)(nil),        // 3: engine.DocIdRequest
-	(*SearchRequest)(nil),       // 4: engine.SearchRequest
-	(*Result)(nil),              // 5: engine.Result
-	(*Code)(nil),                // 6: engine.Code
-	(*GetResult)(nil),           // 7: engine.GetResult
-	(*doc.Document)(nil),        // 8: doc.Document
-	(*types.TermQuery)(nil),     // 9: types.TermQuery
-	(*types.SearchFilters)(nil), // 10: types.SearchFilters
+func (x *SuggestResult) Reset() {
+	*x = SuggestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_engine_index_proto_depIdxs = []int32{
-	0,  // 0: engine.CreateIndexRequest.FieldInfo:type_name -> engine.SimpleFieldInfo
-	8,  // 1: engine.AddRequest.Doc:type_name -> doc.Document
-	9,  // 2: engine.SearchRequest.Query:type_name -> types.TermQuery
-	10, // 3: engine.SearchRequest.Filter:type_name -> types.SearchFilters
-	8,  // 4: engine.Result.DocResult:type_name -> doc.Document
-	8,  // 5: engine.GetResult.Doc:type_name -> doc.Document
-	3,  // 6: engine.IndexService.Delete:input_type -> engine.DocIdRequest
-	2,  // 7: engine.IndexService.Add:input_type -> engine.AddRequest
-	4,  // 8: engine.IndexService.Search:input_type -> engine.SearchRequest
-	3,  // 9: engine.IndexService.Get:input_type -> engine.DocIdRequest
-	1,  // 10: engine.IndexService.CreateIndex:input_type -> engine.CreateIndexRequest
-	6,  // 11: engine.IndexService.Delete:output_type -> engine.Code
-	6,  // 12: engine.IndexService.Add:output_type -> engine.Code
-	5,  // 13: engine.IndexService.Search:output_type -> engine.Result
-	7,  // 14: engine.IndexService.Get:output_type -> engine.GetResult
-	6,  // 15: engine.IndexService.CreateIndex:output_type -> engine.Code
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+
+func (x *SuggestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_engine_index_proto_init() }
-func file_engine_index_proto_init() {
-	if File_engine_index_proto != nil {
-		return
+func (*SuggestResult) ProtoMessage() {}
+
+func (x *SuggestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_engine_index_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SimpleFieldInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResult.ProtoReflect.Descriptor instead.
+func (*SuggestResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SuggestResult) GetSuggestions() []*types.Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type SpellCheckRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string           `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Field     string           `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`        // Text 所属的字段
+	Text      string           `protobuf:"bytes,3,opt,name=Text,proto3" json:"Text,omitempty"`          // 待纠错的文本，与 Query 二选一
+	Query     *types.TermQuery `protobuf:"bytes,4,opt,name=Query,proto3" json:"Query,omitempty"`        // 待纠错的查询，按叶子节点的 Keyword 逐个纠错
+	MaxEdits  uint64           `protobuf:"varint,5,opt,name=MaxEdits,proto3" json:"MaxEdits,omitempty"` // 最大编辑距离，默认 2
+	Size      uint64           `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`         // 每个词最多返回多少个候选词
+}
+
+func (x *SpellCheckRequest) Reset() {
+	*x = SpellCheckRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpellCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpellCheckRequest) ProtoMessage() {}
+
+func (x *SpellCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpellCheckRequest.ProtoReflect.Descriptor instead.
+func (*SpellCheckRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SpellCheckRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *SpellCheckRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SpellCheckRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SpellCheckRequest) GetQuery() *types.TermQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *SpellCheckRequest) GetMaxEdits() uint64 {
+	if x != nil {
+		return x.MaxEdits
+	}
+	return 0
+}
+
+func (x *SpellCheckRequest) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type SpellCheckResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Corrections   []*types.TermCorrection `protobuf:"bytes,1,rep,name=Corrections,proto3" json:"Corrections,omitempty"`
+	Corrected     *types.TermQuery        `protobuf:"bytes,2,opt,name=Corrected,proto3" json:"Corrected,omitempty"`
+	CorrectedText string                  `protobuf:"bytes,3,opt,name=CorrectedText,proto3" json:"CorrectedText,omitempty"`
+}
+
+func (x *SpellCheckResult) Reset() {
+	*x = SpellCheckResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpellCheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpellCheckResult) ProtoMessage() {}
+
+func (x *SpellCheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpellCheckResult.ProtoReflect.Descriptor instead.
+func (*SpellCheckResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SpellCheckResult) GetCorrections() []*types.TermCorrection {
+	if x != nil {
+		return x.Corrections
+	}
+	return nil
+}
+
+func (x *SpellCheckResult) GetCorrected() *types.TermQuery {
+	if x != nil {
+		return x.Corrected
+	}
+	return nil
+}
+
+func (x *SpellCheckResult) GetCorrectedText() string {
+	if x != nil {
+		return x.CorrectedText
+	}
+	return ""
+}
+
+type GetResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Doc   *doc.Document `protobuf:"bytes,1,opt,name=Doc,proto3" json:"Doc,omitempty"`
+	Exist bool          `protobuf:"varint,2,opt,name=Exist,proto3" json:"Exist,omitempty"`
+}
+
+func (x *GetResult) Reset() {
+	*x = GetResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResult) ProtoMessage() {}
+
+func (x *GetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResult.ProtoReflect.Descriptor instead.
+func (*GetResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetResult) GetDoc() *doc.Document {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+func (x *GetResult) GetExist() bool {
+	if x != nil {
+		return x.Exist
+	}
+	return false
+}
+
+type CountResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count  uint64 `protobuf:"varint,1,opt,name=Count,proto3" json:"Count,omitempty"`   // 命中的文档数，设置了 TerminateAfter 时不超过 TerminateAfter
+	Exists bool   `protobuf:"varint,2,opt,name=Exists,proto3" json:"Exists,omitempty"` // 是否至少命中一个文档
+}
+
+func (x *CountResult) Reset() {
+	*x = CountResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResult) ProtoMessage() {}
+
+func (x *CountResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResult.ProtoReflect.Descriptor instead.
+func (*CountResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CountResult) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CountResult) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+type GenerationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+}
+
+func (x *GenerationRequest) Reset() {
+	*x = GenerationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationRequest) ProtoMessage() {}
+
+func (x *GenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationRequest.ProtoReflect.Descriptor instead.
+func (*GenerationRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GenerationRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+type GenerationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Generation uint64 `protobuf:"varint,1,opt,name=Generation,proto3" json:"Generation,omitempty"` // 索引内容的版本号，新增、删除、落盘时变化
+}
+
+func (x *GenerationResult) Reset() {
+	*x = GenerationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationResult) ProtoMessage() {}
+
+func (x *GenerationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationResult.ProtoReflect.Descriptor instead.
+func (*GenerationResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GenerationResult) GetGeneration() uint64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type ExplainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string                 `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	DocId     string                 `protobuf:"bytes,2,opt,name=DocId,proto3" json:"DocId,omitempty"` // 文档主键
+	Query     *types.TermQuery       `protobuf:"bytes,3,opt,name=Query,proto3" json:"Query,omitempty"`
+	Filter    []*types.SearchFilters `protobuf:"bytes,4,rep,name=Filter,proto3" json:"Filter,omitempty"`
+}
+
+func (x *ExplainRequest) Reset() {
+	*x = ExplainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainRequest) ProtoMessage() {}
+
+func (x *ExplainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainRequest.ProtoReflect.Descriptor instead.
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExplainRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *ExplainRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *ExplainRequest) GetQuery() *types.TermQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ExplainRequest) GetFilter() []*types.SearchFilters {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ExplainResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Explanation *types.Explanation `protobuf:"bytes,1,opt,name=Explanation,proto3" json:"Explanation,omitempty"`
+	Shard       string             `protobuf:"bytes,2,opt,name=Shard,proto3" json:"Shard,omitempty"` // 文档所在的 worker
+}
+
+func (x *ExplainResult) Reset() {
+	*x = ExplainResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResult) ProtoMessage() {}
+
+func (x *ExplainResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResult.ProtoReflect.Descriptor instead.
+func (*ExplainResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExplainResult) GetExplanation() *types.Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+func (x *ExplainResult) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+type PercolatorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string                `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Query     *types.PercolateQuery `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"` // 删除时只使用 Query.Id
+}
+
+func (x *PercolatorRequest) Reset() {
+	*x = PercolatorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PercolatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PercolatorRequest) ProtoMessage() {}
+
+func (x *PercolatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PercolatorRequest.ProtoReflect.Descriptor instead.
+func (*PercolatorRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PercolatorRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *PercolatorRequest) GetQuery() *types.PercolateQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type PercolateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string        `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Doc       *doc.Document `protobuf:"bytes,2,opt,name=Doc,proto3" json:"Doc,omitempty"`
+}
+
+func (x *PercolateRequest) Reset() {
+	*x = PercolateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PercolateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PercolateRequest) ProtoMessage() {}
+
+func (x *PercolateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PercolateRequest.ProtoReflect.Descriptor instead.
+func (*PercolateRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PercolateRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *PercolateRequest) GetDoc() *doc.Document {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type PercolateResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryIds []string `protobuf:"bytes,1,rep,name=QueryIds,proto3" json:"QueryIds,omitempty"` // 文档命中的已注册查询的 Id，按 Id 排序
+}
+
+func (x *PercolateResult) Reset() {
+	*x = PercolateResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PercolateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PercolateResult) ProtoMessage() {}
+
+func (x *PercolateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PercolateResult.ProtoReflect.Descriptor instead.
+func (*PercolateResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PercolateResult) GetQueryIds() []string {
+	if x != nil {
+		return x.QueryIds
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName       string            `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	FromSeq         uint64            `protobuf:"varint,2,opt,name=FromSeq,proto3" json:"FromSeq,omitempty"`                                                                                           // 从该序号开始推送，为 0 时从头开始
+	IncludeDocument bool              `protobuf:"varint,3,opt,name=IncludeDocument,proto3" json:"IncludeDocument,omitempty"`                                                                           // 新增事件是否带上文档内容
+	FromSeqs        map[string]uint64 `protobuf:"bytes,4,rep,name=FromSeqs,proto3" json:"FromSeqs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // 经 Sentinel 恢复时每个 worker 的起始序号，没有的 worker 使用 FromSeq
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetFromSeq() uint64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetIncludeDocument() bool {
+	if x != nil {
+		return x.IncludeDocument
+	}
+	return false
+}
+
+func (x *WatchRequest) GetFromSeqs() map[string]uint64 {
+	if x != nil {
+		return x.FromSeqs
+	}
+	return nil
+}
+
+type DropIndexRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+}
+
+func (x *DropIndexRequest) Reset() {
+	*x = DropIndexRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DropIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DropIndexRequest) ProtoMessage() {}
+
+func (x *DropIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DropIndexRequest.ProtoReflect.Descriptor instead.
+func (*DropIndexRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DropIndexRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+type DeleteByQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string                 `protobuf:"bytes,1,opt,name=IndexName,proto3" json:"IndexName,omitempty"`
+	Query     *types.TermQuery       `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
+	Filter    []*types.SearchFilters `protobuf:"bytes,3,rep,name=Filter,proto3" json:"Filter,omitempty"` // 与 Query 不能同时为空
+}
+
+func (x *DeleteByQueryRequest) Reset() {
+	*x = DeleteByQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteByQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByQueryRequest) ProtoMessage() {}
+
+func (x *DeleteByQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByQueryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteByQueryRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteByQueryRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *DeleteByQueryRequest) GetQuery() *types.TermQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *DeleteByQueryRequest) GetFilter() []*types.SearchFilters {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type DeleteByQueryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted uint64 `protobuf:"varint,1,opt,name=Deleted,proto3" json:"Deleted,omitempty"` // 删除的文档数，经 Sentinel 时为所有 worker 之和
+}
+
+func (x *DeleteByQueryResult) Reset() {
+	*x = DeleteByQueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteByQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByQueryResult) ProtoMessage() {}
+
+func (x *DeleteByQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByQueryResult.ProtoReflect.Descriptor instead.
+func (*DeleteByQueryResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteByQueryResult) GetDeleted() uint64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type SwitchAliasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Alias      string   `protobuf:"bytes,1,opt,name=Alias,proto3" json:"Alias,omitempty"`
+	Indexes    []string `protobuf:"bytes,2,rep,name=Indexes,proto3" json:"Indexes,omitempty"`       // 别名指向的全部索引，为空时删除别名
+	WriteIndex string   `protobuf:"bytes,3,opt,name=WriteIndex,proto3" json:"WriteIndex,omitempty"` // 写入使用的索引，只有一个索引时默认为该索引
+}
+
+func (x *SwitchAliasRequest) Reset() {
+	*x = SwitchAliasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwitchAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchAliasRequest) ProtoMessage() {}
+
+func (x *SwitchAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchAliasRequest.ProtoReflect.Descriptor instead.
+func (*SwitchAliasRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SwitchAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *SwitchAliasRequest) GetIndexes() []string {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *SwitchAliasRequest) GetWriteIndex() string {
+	if x != nil {
+		return x.WriteIndex
+	}
+	return ""
+}
+
+type ReindexRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId  string                 `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`   // 为空时自动生成
+	Source string                 `protobuf:"bytes,2,opt,name=Source,proto3" json:"Source,omitempty"` // 源索引或者只指向一个索引的别名
+	Dest   string                 `protobuf:"bytes,3,opt,name=Dest,proto3" json:"Dest,omitempty"`     // 目标索引，需要先用 CreateIndex 按新的 schema 创建
+	Query  *types.TermQuery       `protobuf:"bytes,4,opt,name=Query,proto3" json:"Query,omitempty"`   // 与 Filter 都为空时复制全部文档
+	Filter []*types.SearchFilters `protobuf:"bytes,5,rep,name=Filter,proto3" json:"Filter,omitempty"`
+}
+
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexRequest) ProtoMessage() {}
+
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReindexRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ReindexRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ReindexRequest) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *ReindexRequest) GetQuery() *types.TermQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ReindexRequest) GetFilter() []*types.SearchFilters {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ReindexStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId string `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+}
+
+func (x *ReindexStatusRequest) Reset() {
+	*x = ReindexStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexStatusRequest) ProtoMessage() {}
+
+func (x *ReindexStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReindexStatusRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReindexStatusRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ReindexStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId     string `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	Source    string `protobuf:"bytes,2,opt,name=Source,proto3" json:"Source,omitempty"`
+	Dest      string `protobuf:"bytes,3,opt,name=Dest,proto3" json:"Dest,omitempty"`
+	Total     uint64 `protobuf:"varint,4,opt,name=Total,proto3" json:"Total,omitempty"`         // 开始时源索引中待复制的文档数
+	Copied    uint64 `protobuf:"varint,5,opt,name=Copied,proto3" json:"Copied,omitempty"`       // 已经写入目标索引的文档数
+	Failed    uint64 `protobuf:"varint,6,opt,name=Failed,proto3" json:"Failed,omitempty"`       // 写入失败的文档数
+	Done      bool   `protobuf:"varint,7,opt,name=Done,proto3" json:"Done,omitempty"`           // 经 Sentinel 时所有 worker 都结束才为 true
+	Error     string `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`          // 第一个错误
+	StartTime int64  `protobuf:"varint,9,opt,name=StartTime,proto3" json:"StartTime,omitempty"` // unix 毫秒
+	EndTime   int64  `protobuf:"varint,10,opt,name=EndTime,proto3" json:"EndTime,omitempty"`    // unix 毫秒，未结束时为 0
+}
+
+func (x *ReindexStatus) Reset() {
+	*x = ReindexStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReindexStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexStatus) ProtoMessage() {}
+
+func (x *ReindexStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexStatus.ProtoReflect.Descriptor instead.
+func (*ReindexStatus) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReindexStatus) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ReindexStatus) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ReindexStatus) GetDest() string {
+	if x != nil {
+		return x.Dest
+	}
+	return ""
+}
+
+func (x *ReindexStatus) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ReindexStatus) GetCopied() uint64 {
+	if x != nil {
+		return x.Copied
+	}
+	return 0
+}
+
+func (x *ReindexStatus) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ReindexStatus) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ReindexStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReindexStatus) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ReindexStatus) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{28}
+}
+
+type FilterCacheStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hits      uint64 `protobuf:"varint,1,opt,name=Hits,proto3" json:"Hits,omitempty"`
+	Misses    uint64 `protobuf:"varint,2,opt,name=Misses,proto3" json:"Misses,omitempty"`
+	Evictions uint64 `protobuf:"varint,3,opt,name=Evictions,proto3" json:"Evictions,omitempty"`
+	Entries   uint64 `protobuf:"varint,4,opt,name=Entries,proto3" json:"Entries,omitempty"`
+	Bytes     uint64 `protobuf:"varint,5,opt,name=Bytes,proto3" json:"Bytes,omitempty"`       // 缓存的位图占用的内存
+	Capacity  uint64 `protobuf:"varint,6,opt,name=Capacity,proto3" json:"Capacity,omitempty"` // 内存上限
+}
+
+func (x *FilterCacheStats) Reset() {
+	*x = FilterCacheStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCacheStats) ProtoMessage() {}
+
+func (x *FilterCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCacheStats.ProtoReflect.Descriptor instead.
+func (*FilterCacheStats) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FilterCacheStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *FilterCacheStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *FilterCacheStats) GetEvictions() uint64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+func (x *FilterCacheStats) GetEntries() uint64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *FilterCacheStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *FilterCacheStats) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type TTLStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expired     uint64 `protobuf:"varint,1,opt,name=Expired,proto3" json:"Expired,omitempty"`         // 累计过期的文档数
+	LastExpired uint64 `protobuf:"varint,2,opt,name=LastExpired,proto3" json:"LastExpired,omitempty"` // 最近一次清理过期的文档数
+	LastRun     int64  `protobuf:"varint,3,opt,name=LastRun,proto3" json:"LastRun,omitempty"`         // 最近一次清理的时间，unix 毫秒
+	LastError   string `protobuf:"bytes,4,opt,name=LastError,proto3" json:"LastError,omitempty"`      // 最近一次清理的错误，成功时为空
+}
+
+func (x *TTLStats) Reset() {
+	*x = TTLStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TTLStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TTLStats) ProtoMessage() {}
+
+func (x *TTLStats) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TTLStats.ProtoReflect.Descriptor instead.
+func (*TTLStats) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TTLStats) GetExpired() uint64 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *TTLStats) GetLastExpired() uint64 {
+	if x != nil {
+		return x.LastExpired
+	}
+	return 0
+}
+
+func (x *TTLStats) GetLastRun() int64 {
+	if x != nil {
+		return x.LastRun
+	}
+	return 0
+}
+
+func (x *TTLStats) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type WorkerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shard       string               `protobuf:"bytes,1,opt,name=Shard,proto3" json:"Shard,omitempty"`                                                                                     // 返回统计信息的 worker
+	FilterCache *FilterCacheStats    `protobuf:"bytes,2,opt,name=FilterCache,proto3" json:"FilterCache,omitempty"`                                                                         // worker 上所有索引共用的过滤结果缓存
+	TTL         map[string]*TTLStats `protobuf:"bytes,3,rep,name=TTL,proto3" json:"TTL,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 索引名 -> 过期清理统计信息，没有清理过的索引不在其中
+}
+
+func (x *WorkerStats) Reset() {
+	*x = WorkerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStats) ProtoMessage() {}
+
+func (x *WorkerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStats.ProtoReflect.Descriptor instead.
+func (*WorkerStats) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WorkerStats) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+func (x *WorkerStats) GetFilterCache() *FilterCacheStats {
+	if x != nil {
+		return x.FilterCache
+	}
+	return nil
+}
+
+func (x *WorkerStats) GetTTL() map[string]*TTLStats {
+	if x != nil {
+		return x.TTL
+	}
+	return nil
+}
+
+type ResultCacheStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hits             uint64 `protobuf:"varint,1,opt,name=Hits,proto3" json:"Hits,omitempty"`
+	Misses           uint64 `protobuf:"varint,2,opt,name=Misses,proto3" json:"Misses,omitempty"`
+	Entries          uint64 `protobuf:"varint,3,opt,name=Entries,proto3" json:"Entries,omitempty"`
+	Capacity         uint64 `protobuf:"varint,4,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
+	GenerationHits   uint64 `protobuf:"varint,5,opt,name=GenerationHits,proto3" json:"GenerationHits,omitempty"`     // 直接使用缓存的版本号、没有访问 worker 的次数
+	GenerationMisses uint64 `protobuf:"varint,6,opt,name=GenerationMisses,proto3" json:"GenerationMisses,omitempty"` // 访问 worker 取得版本号的次数
+}
+
+func (x *ResultCacheStats) Reset() {
+	*x = ResultCacheStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultCacheStats) ProtoMessage() {}
+
+func (x *ResultCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultCacheStats.ProtoReflect.Descriptor instead.
+func (*ResultCacheStats) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResultCacheStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *ResultCacheStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *ResultCacheStats) GetEntries() uint64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *ResultCacheStats) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ResultCacheStats) GetGenerationHits() uint64 {
+	if x != nil {
+		return x.GenerationHits
+	}
+	return 0
+}
+
+func (x *ResultCacheStats) GetGenerationMisses() uint64 {
+	if x != nil {
+		return x.GenerationMisses
+	}
+	return 0
+}
+
+type StatsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workers     []*WorkerStats    `protobuf:"bytes,1,rep,name=Workers,proto3" json:"Workers,omitempty"`         // 经 Sentinel 时为所有 worker 的统计信息，按 Shard 排序
+	ResultCache *ResultCacheStats `protobuf:"bytes,2,opt,name=ResultCache,proto3" json:"ResultCache,omitempty"` // Sentinel 上的查询结果缓存，没有开启或者直接访问 worker 时为空
+}
+
+func (x *StatsResult) Reset() {
+	*x = StatsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_engine_index_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResult) ProtoMessage() {}
+
+func (x *StatsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_engine_index_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResult.ProtoReflect.Descriptor instead.
+func (*StatsResult) Descriptor() ([]byte, []int) {
+	return file_engine_index_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StatsResult) GetWorkers() []*WorkerStats {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+func (x *StatsResult) GetResultCache() *ResultCacheStats {
+	if x != nil {
+		return x.ResultCache
+	}
+	return nil
+}
+
+var File_engine_index_proto protoreflect.FileDescriptor
+
+var file_engine_index_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x1a, 0x13, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x64, 0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x11, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf7, 0x01, 0x0a, 0x0f, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x57, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x69, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x44, 0x69, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x12, 0x20, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0xa5,
+	0x01, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x54,
+	0x4c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x54,
+	0x4c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x54, 0x54, 0x4c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x54, 0x54, 0x4c, 0x22, 0x4b, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x44, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03,
+	0x44, 0x6f, 0x63, 0x22, 0xb8, 0x01, 0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4b,
+	0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x45,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x82,
+	0x05, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26,
+	0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x06, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x0c, 0x4d, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x6b, 0x65,
+	0x54, 0x68, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x4d, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x68, 0x69, 0x73, 0x52,
+	0x0c, 0x4d, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x68, 0x69, 0x73, 0x12, 0x21, 0x0a,
+	0x03, 0x4b, 0x6e, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x4b, 0x6e, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x03, 0x4b, 0x6e, 0x6e,
+	0x12, 0x25, 0x0a, 0x06, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x07, 0x47, 0x65, 0x6f, 0x53, 0x6f,
+	0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x47, 0x65, 0x6f, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x47, 0x65, 0x6f, 0x53, 0x6f, 0x72,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x48,
+	0x69, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x48, 0x69, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2f, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x49,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4b, 0x65, 0x79,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x08,
+	0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x52,
+	0x08, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x22, 0xb1, 0x03, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b,
+	0x0a, 0x09, 0x44, 0x6f, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x09, 0x44, 0x6f, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x44,
+	0x69, 0x64, 0x59, 0x6f, 0x75, 0x4d, 0x65, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0a, 0x44, 0x69, 0x64, 0x59, 0x6f,
+	0x75, 0x4d, 0x65, 0x61, 0x6e, 0x12, 0x1e, 0x0a, 0x04, 0x48, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x69, 0x74, 0x52,
+	0x04, 0x48, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x48, 0x69,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x48,
+	0x69, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x0a, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x0a, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x12, 0x4a, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c,
+	0x61, 0x70, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x1a, 0x41, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x01, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x26, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c,
+	0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0x70, 0x0a, 0x0e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x44, 0x0a, 0x0d, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x11, 0x53,
+	0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x61, 0x78, 0x45, 0x64, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x4d, 0x61, 0x78, 0x45, 0x64, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65,
+	0x22, 0xa1, 0x01, 0x0a, 0x10, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e,
+	0x0a, 0x09, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x09, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x24,
+	0x0a, 0x0d, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x65, 0x78, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x54, 0x65, 0x78, 0x74, 0x22, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1f, 0x0a, 0x03, 0x44, 0x6f, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x44,
+	0x6f, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x78, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x45, 0x78, 0x69, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x31, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9a, 0x01, 0x0a,
+	0x0e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x44, 0x6f,
+	0x63, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x5b, 0x0a, 0x0d, 0x45, 0x78, 0x70,
+	0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x34, 0x0a, 0x0b, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x22, 0x5e, 0x0a, 0x11, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x22, 0x51, 0x0a, 0x10, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x44, 0x6f, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x44, 0x6f, 0x63, 0x22, 0x2d, 0x0a, 0x0f, 0x50, 0x65, 0x72,
+	0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x73, 0x22, 0xed, 0x01, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x46, 0x72, 0x6f, 0x6d, 0x53,
+	0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65,
+	0x71, 0x12, 0x28, 0x0a, 0x0f, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x49, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x08, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x30, 0x0a, 0x10, 0x44, 0x72, 0x6f, 0x70,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x64, 0x0a, 0x12, 0x53, 0x77, 0x69, 0x74,
+	0x63, 0x68, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x41,
+	0x6c, 0x69, 0x61, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xa8,
+	0x01, 0x0a, 0x0e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x44, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2c, 0x0a, 0x14, 0x52, 0x65, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0xf9, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4a, 0x6f, 0x62,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x45, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x69, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4d, 0x69,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x7e,
+	0x0a, 0x08, 0x54, 0x54, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x75,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xd9,
+	0x01, 0x0a, 0x0b, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53,
+	0x68, 0x61, 0x72, 0x64, 0x12, 0x3a, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x12, 0x2e, 0x0a, 0x03, 0x54, 0x54, 0x4c, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x2e, 0x54, 0x54, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x54, 0x54, 0x4c,
+	0x1a, 0x48, 0x0a, 0x08, 0x54, 0x54, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x54, 0x54, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x48, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48,
+	0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x12, 0x26, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x69, 0x73, 0x73, 0x65, 0x73, 0x22, 0x78, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x57,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x32,
+	0x97, 0x09, 0x0a, 0x0c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x2c, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x27,
+	0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x12, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x41,
+	0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x12, 0x15, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2e, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12,
+	0x14, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x38, 0x0a, 0x07, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x41, 0x0a, 0x0a, 0x53,
+	0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x19, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x70,
+	0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x41,
+	0x0a, 0x0a, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x33, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x15, 0x2e, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x12, 0x16, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x3d, 0x0a, 0x12, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x65, 0x72, 0x63,
+	0x6f, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x19, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x3b, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x19, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x65, 0x72,
+	0x63, 0x6f, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x09,
+	0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x65, 0x72,
+	0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3a, 0x0a, 0x0c,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x33, 0x0a, 0x09, 0x44, 0x72, 0x6f, 0x70,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44,
+	0x72, 0x6f, 0x70, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x4a, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1c,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x53, 0x77, 0x69,
+	0x74, 0x63, 0x68, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x2e,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52,
+	0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x47, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1c, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14,
+	0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x2f, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_engine_index_proto_rawDescOnce sync.Once
+	file_engine_index_proto_rawDescData = file_engine_index_proto_rawDesc
+)
+
+func file_engine_index_proto_rawDescGZIP() []byte {
+	file_engine_index_proto_rawDescOnce.Do(func() {
+		file_engine_index_proto_rawDescData = protoimpl.X.CompressGZIP(file_engine_index_proto_rawDescData)
+	})
+	return file_engine_index_proto_rawDescData
+}
+
+var file_engine_index_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_engine_index_proto_goTypes = []interface{}{
+	(*SimpleFieldInfo)(nil),      // 0: engine.SimpleFieldInfo
+	(*CreateIndexRequest)(nil),   // 1: engine.CreateIndexRequest
+	(*AddRequest)(nil),           // 2: engine.AddRequest
+	(*DocIdRequest)(nil),         // 3: engine.DocIdRequest
+	(*SearchRequest)(nil),        // 4: engine.SearchRequest
+	(*Result)(nil),               // 5: engine.Result
+	(*Code)(nil),                 // 6: engine.Code
+	(*SuggestRequest)(nil),       // 7: engine.SuggestRequest
+	(*SuggestResult)(nil),        // 8: engine.SuggestResult
+	(*SpellCheckRequest)(nil),    // 9: engine.SpellCheckRequest
+	(*SpellCheckResult)(nil),     // 10: engine.SpellCheckResult
+	(*GetResult)(nil),            // 11: engine.GetResult
+	(*CountResult)(nil),          // 12: engine.CountResult
+	(*GenerationRequest)(nil),    // 13: engine.GenerationRequest
+	(*GenerationResult)(nil),     // 14: engine.GenerationResult
+	(*ExplainRequest)(nil),       // 15: engine.ExplainRequest
+	(*ExplainResult)(nil),        // 16: engine.ExplainResult
+	(*PercolatorRequest)(nil),    // 17: engine.PercolatorRequest
+	(*PercolateRequest)(nil),     // 18: engine.PercolateRequest
+	(*PercolateResult)(nil),      // 19: engine.PercolateResult
+	(*WatchRequest)(nil),         // 20: engine.WatchRequest
+	(*DropIndexRequest)(nil),     // 21: engine.DropIndexRequest
+	(*DeleteByQueryRequest)(nil), // 22: engine.DeleteByQueryRequest
+	(*DeleteByQueryResult)(nil),  // 23: engine.DeleteByQueryResult
+	(*SwitchAliasRequest)(nil),   // 24: engine.SwitchAliasRequest
+	(*ReindexRequest)(nil),       // 25: engine.ReindexRequest
+	(*ReindexStatusRequest)(nil), // 26: engine.ReindexStatusRequest
+	(*ReindexStatus)(nil),        // 27: engine.ReindexStatus
+	(*StatsRequest)(nil),         // 28: engine.StatsRequest
+	(*FilterCacheStats)(nil),     // 29: engine.FilterCacheStats
+	(*TTLStats)(nil),             // 30: engine.TTLStats
+	(*WorkerStats)(nil),          // 31: engine.WorkerStats
+	(*ResultCacheStats)(nil),     // 32: engine.ResultCacheStats
+	(*StatsResult)(nil),          // 33: engine.StatsResult
+	nil,                          // 34: engine.Result.CollapseCountsEntry
+	nil,                          // 35: engine.WatchRequest.FromSeqsEntry
+	nil,                          // 36: engine.WorkerStats.TTLEntry
+	(*doc.Document)(nil),         // 37: doc.Document
+	(*types.TermQuery)(nil),      // 38: types.TermQuery
+	(*types.SearchFilters)(nil),  // 39: types.SearchFilters
+	(*types.MoreLikeThis)(nil),   // 40: types.MoreLikeThis
+	(*types.KnnQuery)(nil),       // 41: types.KnnQuery
+	(*types.Fusion)(nil),         // 42: types.Fusion
+	(*types.GeoSort)(nil),        // 43: types.GeoSort
+	(*types.Cursor)(nil),         // 44: types.Cursor
+	(*types.Collapse)(nil),       // 45: types.Collapse
+	(*types.Hit)(nil),            // 46: types.Hit
+	(*types.SearchProfile)(nil),  // 47: types.SearchProfile
+	(*types.Suggestion)(nil),     // 48: types.Suggestion
+	(*types.TermCorrection)(nil), // 49: types.TermCorrection
+	(*types.Explanation)(nil),    // 50: types.Explanation
+	(*types.PercolateQuery)(nil), // 51: types.PercolateQuery
+	(*types.ChangeEvent)(nil),    // 52: types.ChangeEvent
+}
+var file_engine_index_proto_depIdxs = []int32{
+	0,  // 0: engine.CreateIndexRequest.FieldInfo:type_name -> engine.SimpleFieldInfo
+	37, // 1: engine.AddRequest.Doc:type_name -> doc.Document
+	38, // 2: engine.SearchRequest.Query:type_name -> types.TermQuery
+	39, // 3: engine.SearchRequest.Filter:type_name -> types.SearchFilters
+	40, // 4: engine.SearchRequest.MoreLikeThis:type_name -> types.MoreLikeThis
+	41, // 5: engine.SearchRequest.Knn:type_name -> types.KnnQuery
+	42, // 6: engine.SearchRequest.Fusion:type_name -> types.Fusion
+	43, // 7: engine.SearchRequest.GeoSort:type_name -> types.GeoSort
+	44, // 8: engine.SearchRequest.SearchAfter:type_name -> types.Cursor
+	45, // 9: engine.SearchRequest.Collapse:type_name -> types.Collapse
+	37, // 10: engine.Result.DocResult:type_name -> doc.Document
+	10, // 11: engine.Result.DidYouMean:type_name -> engine.SpellCheckResult
+	46, // 12: engine.Result.Hits:type_name -> types.Hit
+	44, // 13: engine.Result.NextCursor:type_name -> types.Cursor
+	34, // 14: engine.Result.CollapseCounts:type_name -> engine.Result.CollapseCountsEntry
+	47, // 15: engine.Result.Profile:type_name -> types.SearchProfile
+	48, // 16: engine.SuggestResult.Suggestions:type_name -> types.Suggestion
+	38, // 17: engine.SpellCheckRequest.Query:type_name -> types.TermQuery
+	49, // 18: engine.SpellCheckResult.Corrections:type_name -> types.TermCorrection
+	38, // 19: engine.SpellCheckResult.Corrected:type_name -> types.TermQuery
+	37, // 20: engine.GetResult.Doc:type_name -> doc.Document
+	38, // 21: engine.ExplainRequest.Query:type_name -> types.TermQuery
+	39, // 22: engine.ExplainRequest.Filter:type_name -> types.SearchFilters
+	50, // 23: engine.ExplainResult.Explanation:type_name -> types.Explanation
+	51, // 24: engine.PercolatorRequest.Query:type_name -> types.PercolateQuery
+	37, // 25: engine.PercolateRequest.Doc:type_name -> doc.Document
+	35, // 26: engine.WatchRequest.FromSeqs:type_name -> engine.WatchRequest.FromSeqsEntry
+	38, // 27: engine.DeleteByQueryRequest.Query:type_name -> types.TermQuery
+	39, // 28: engine.DeleteByQueryRequest.Filter:type_name -> types.SearchFilters
+	38, // 29: engine.ReindexRequest.Query:type_name -> types.TermQuery
+	39, // 30: engine.ReindexRequest.Filter:type_name -> types.SearchFilters
+	29, // 31: engine.WorkerStats.FilterCache:type_name -> engine.FilterCacheStats
+	36, // 32: engine.WorkerStats.TTL:type_name -> engine.WorkerStats.TTLEntry
+	31, // 33: engine.StatsResult.Workers:type_name -> engine.WorkerStats
+	32, // 34: engine.StatsResult.ResultCache:type_name -> engine.ResultCacheStats
+	30, // 35: engine.WorkerStats.TTLEntry.value:type_name -> engine.TTLStats
+	3,  // 36: engine.IndexService.Delete:input_type -> engine.DocIdRequest
+	2,  // 37: engine.IndexService.Add:input_type -> engine.AddRequest
+	4,  // 38: engine.IndexService.Search:input_type -> engine.SearchRequest
+	3,  // 39: engine.IndexService.Get:input_type -> engine.DocIdRequest
+	1,  // 40: engine.IndexService.CreateIndex:input_type -> engine.CreateIndexRequest
+	7,  // 41: engine.IndexService.Suggest:input_type -> engine.SuggestRequest
+	9,  // 42: engine.IndexService.SpellCheck:input_type -> engine.SpellCheckRequest
+	13, // 43: engine.IndexService.Generation:input_type -> engine.GenerationRequest
+	4,  // 44: engine.IndexService.Count:input_type -> engine.SearchRequest
+	15, // 45: engine.IndexService.Explain:input_type -> engine.ExplainRequest
+	17, // 46: engine.IndexService.RegisterPercolator:input_type -> engine.PercolatorRequest
+	17, // 47: engine.IndexService.DeletePercolator:input_type -> engine.PercolatorRequest
+	18, // 48: engine.IndexService.Percolate:input_type -> engine.PercolateRequest
+	20, // 49: engine.IndexService.WatchChanges:input_type -> engine.WatchRequest
+	21, // 50: engine.IndexService.DropIndex:input_type -> engine.DropIndexRequest
+	22, // 51: engine.IndexService.DeleteByQuery:input_type -> engine.DeleteByQueryRequest
+	24, // 52: engine.IndexService.SwitchAlias:input_type -> engine.SwitchAliasRequest
+	25, // 53: engine.IndexService.Reindex:input_type -> engine.ReindexRequest
+	26, // 54: engine.IndexService.GetReindexStatus:input_type -> engine.ReindexStatusRequest
+	28, // 55: engine.IndexService.Stats:input_type -> engine.StatsRequest
+	6,  // 56: engine.IndexService.Delete:output_type -> engine.Code
+	6,  // 57: engine.IndexService.Add:output_type -> engine.Code
+	5,  // 58: engine.IndexService.Search:output_type -> engine.Result
+	11, // 59: engine.IndexService.Get:output_type -> engine.GetResult
+	6,  // 60: engine.IndexService.CreateIndex:output_type -> engine.Code
+	8,  // 61: engine.IndexService.Suggest:output_type -> engine.SuggestResult
+	10, // 62: engine.IndexService.SpellCheck:output_type -> engine.SpellCheckResult
+	14, // 63: engine.IndexService.Generation:output_type -> engine.GenerationResult
+	12, // 64: engine.IndexService.Count:output_type -> engine.CountResult
+	16, // 65: engine.IndexService.Explain:output_type -> engine.ExplainResult
+	6,  // 66: engine.IndexService.RegisterPercolator:output_type -> engine.Code
+	6,  // 67: engine.IndexService.DeletePercolator:output_type -> engine.Code
+	19, // 68: engine.IndexService.Percolate:output_type -> engine.PercolateResult
+	52, // 69: engine.IndexService.WatchChanges:output_type -> types.ChangeEvent
+	6,  // 70: engine.IndexService.DropIndex:output_type -> engine.Code
+	23, // 71: engine.IndexService.DeleteByQuery:output_type -> engine.DeleteByQueryResult
+	6,  // 72: engine.IndexService.SwitchAlias:output_type -> engine.Code
+	27, // 73: engine.IndexService.Reindex:output_type -> engine.ReindexStatus
+	27, // 74: engine.IndexService.GetReindexStatus:output_type -> engine.ReindexStatus
+	33, // 75: engine.IndexService.Stats:output_type -> engine.StatsResult
+	56, // [56:76] is the sub-list for method output_type
+	36, // [36:56] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_engine_index_proto_init() }
+func file_engine_index_proto_init() {
+	if File_engine_index_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_engine_index_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimpleFieldInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateIndexRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocIdRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateIndexRequest); i {
+		file_engine_index_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -601,8 +2993,8 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRequest); i {
+		file_engine_index_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Result); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -613,8 +3005,8 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DocIdRequest); i {
+		file_engine_index_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Code); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -625,8 +3017,8 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchRequest); i {
+		file_engine_index_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -637,8 +3029,8 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Result); i {
+		file_engine_index_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -649,8 +3041,8 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Code); i {
+		file_engine_index_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpellCheckRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -661,7 +3053,19 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
-		file_engine_index_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_engine_index_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpellCheckResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetResult); i {
 			case 0:
 				return &v.state
@@ -673,6 +3077,270 @@ func file_engine_index_proto_init() {
 				return nil
 			}
 		}
+		file_engine_index_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PercolatorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PercolateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PercolateResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DropIndexRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteByQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteByQueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwitchAliasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReindexStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterCacheStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TTLStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultCacheStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_engine_index_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -680,7 +3348,7 @@ func file_engine_index_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_engine_index_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
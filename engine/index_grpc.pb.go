@@ -8,6 +8,7 @@ package engine
 
 import (
 	context "context"
+	types "github.com/cylScripter/NexusFind/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,11 +20,26 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	IndexService_Delete_FullMethodName      = "/engine.IndexService/Delete"
-	IndexService_Add_FullMethodName         = "/engine.IndexService/Add"
-	IndexService_Search_FullMethodName      = "/engine.IndexService/Search"
-	IndexService_Get_FullMethodName         = "/engine.IndexService/Get"
-	IndexService_CreateIndex_FullMethodName = "/engine.IndexService/CreateIndex"
+	IndexService_Delete_FullMethodName             = "/engine.IndexService/Delete"
+	IndexService_Add_FullMethodName                = "/engine.IndexService/Add"
+	IndexService_Search_FullMethodName             = "/engine.IndexService/Search"
+	IndexService_Get_FullMethodName                = "/engine.IndexService/Get"
+	IndexService_CreateIndex_FullMethodName        = "/engine.IndexService/CreateIndex"
+	IndexService_Suggest_FullMethodName            = "/engine.IndexService/Suggest"
+	IndexService_SpellCheck_FullMethodName         = "/engine.IndexService/SpellCheck"
+	IndexService_Generation_FullMethodName         = "/engine.IndexService/Generation"
+	IndexService_Count_FullMethodName              = "/engine.IndexService/Count"
+	IndexService_Explain_FullMethodName            = "/engine.IndexService/Explain"
+	IndexService_RegisterPercolator_FullMethodName = "/engine.IndexService/RegisterPercolator"
+	IndexService_DeletePercolator_FullMethodName   = "/engine.IndexService/DeletePercolator"
+	IndexService_Percolate_FullMethodName          = "/engine.IndexService/Percolate"
+	IndexService_WatchChanges_FullMethodName       = "/engine.IndexService/WatchChanges"
+	IndexService_DropIndex_FullMethodName          = "/engine.IndexService/DropIndex"
+	IndexService_DeleteByQuery_FullMethodName      = "/engine.IndexService/DeleteByQuery"
+	IndexService_SwitchAlias_FullMethodName        = "/engine.IndexService/SwitchAlias"
+	IndexService_Reindex_FullMethodName            = "/engine.IndexService/Reindex"
+	IndexService_GetReindexStatus_FullMethodName   = "/engine.IndexService/GetReindexStatus"
+	IndexService_Stats_FullMethodName              = "/engine.IndexService/Stats"
 )
 
 // IndexServiceClient is the client API for IndexService service.
@@ -35,6 +51,21 @@ type IndexServiceClient interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*Result, error)
 	Get(ctx context.Context, in *DocIdRequest, opts ...grpc.CallOption) (*GetResult, error)
 	CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*Code, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error)
+	SpellCheck(ctx context.Context, in *SpellCheckRequest, opts ...grpc.CallOption) (*SpellCheckResult, error)
+	Generation(ctx context.Context, in *GenerationRequest, opts ...grpc.CallOption) (*GenerationResult, error)
+	Count(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*CountResult, error)
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResult, error)
+	RegisterPercolator(ctx context.Context, in *PercolatorRequest, opts ...grpc.CallOption) (*Code, error)
+	DeletePercolator(ctx context.Context, in *PercolatorRequest, opts ...grpc.CallOption) (*Code, error)
+	Percolate(ctx context.Context, in *PercolateRequest, opts ...grpc.CallOption) (*PercolateResult, error)
+	WatchChanges(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (IndexService_WatchChangesClient, error)
+	DropIndex(ctx context.Context, in *DropIndexRequest, opts ...grpc.CallOption) (*Code, error)
+	DeleteByQuery(ctx context.Context, in *DeleteByQueryRequest, opts ...grpc.CallOption) (*DeleteByQueryResult, error)
+	SwitchAlias(ctx context.Context, in *SwitchAliasRequest, opts ...grpc.CallOption) (*Code, error)
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexStatus, error)
+	GetReindexStatus(ctx context.Context, in *ReindexStatusRequest, opts ...grpc.CallOption) (*ReindexStatus, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResult, error)
 }
 
 type indexServiceClient struct {
@@ -90,6 +121,164 @@ func (c *indexServiceClient) CreateIndex(ctx context.Context, in *CreateIndexReq
 	return out, nil
 }
 
+func (c *indexServiceClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error) {
+	out := new(SuggestResult)
+	err := c.cc.Invoke(ctx, IndexService_Suggest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) SpellCheck(ctx context.Context, in *SpellCheckRequest, opts ...grpc.CallOption) (*SpellCheckResult, error) {
+	out := new(SpellCheckResult)
+	err := c.cc.Invoke(ctx, IndexService_SpellCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Generation(ctx context.Context, in *GenerationRequest, opts ...grpc.CallOption) (*GenerationResult, error) {
+	out := new(GenerationResult)
+	err := c.cc.Invoke(ctx, IndexService_Generation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Count(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*CountResult, error) {
+	out := new(CountResult)
+	err := c.cc.Invoke(ctx, IndexService_Count_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResult, error) {
+	out := new(ExplainResult)
+	err := c.cc.Invoke(ctx, IndexService_Explain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) RegisterPercolator(ctx context.Context, in *PercolatorRequest, opts ...grpc.CallOption) (*Code, error) {
+	out := new(Code)
+	err := c.cc.Invoke(ctx, IndexService_RegisterPercolator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) DeletePercolator(ctx context.Context, in *PercolatorRequest, opts ...grpc.CallOption) (*Code, error) {
+	out := new(Code)
+	err := c.cc.Invoke(ctx, IndexService_DeletePercolator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Percolate(ctx context.Context, in *PercolateRequest, opts ...grpc.CallOption) (*PercolateResult, error) {
+	out := new(PercolateResult)
+	err := c.cc.Invoke(ctx, IndexService_Percolate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) WatchChanges(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (IndexService_WatchChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IndexService_ServiceDesc.Streams[0], IndexService_WatchChanges_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &indexServiceWatchChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IndexService_WatchChangesClient interface {
+	Recv() (*types.ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type indexServiceWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *indexServiceWatchChangesClient) Recv() (*types.ChangeEvent, error) {
+	m := new(types.ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *indexServiceClient) DropIndex(ctx context.Context, in *DropIndexRequest, opts ...grpc.CallOption) (*Code, error) {
+	out := new(Code)
+	err := c.cc.Invoke(ctx, IndexService_DropIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) DeleteByQuery(ctx context.Context, in *DeleteByQueryRequest, opts ...grpc.CallOption) (*DeleteByQueryResult, error) {
+	out := new(DeleteByQueryResult)
+	err := c.cc.Invoke(ctx, IndexService_DeleteByQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) SwitchAlias(ctx context.Context, in *SwitchAliasRequest, opts ...grpc.CallOption) (*Code, error) {
+	out := new(Code)
+	err := c.cc.Invoke(ctx, IndexService_SwitchAlias_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexStatus, error) {
+	out := new(ReindexStatus)
+	err := c.cc.Invoke(ctx, IndexService_Reindex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) GetReindexStatus(ctx context.Context, in *ReindexStatusRequest, opts ...grpc.CallOption) (*ReindexStatus, error) {
+	out := new(ReindexStatus)
+	err := c.cc.Invoke(ctx, IndexService_GetReindexStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResult, error) {
+	out := new(StatsResult)
+	err := c.cc.Invoke(ctx, IndexService_Stats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndexServiceServer is the server API for IndexService service.
 // All implementations must embed UnimplementedIndexServiceServer
 // for forward compatibility
@@ -99,6 +288,21 @@ type IndexServiceServer interface {
 	Search(context.Context, *SearchRequest) (*Result, error)
 	Get(context.Context, *DocIdRequest) (*GetResult, error)
 	CreateIndex(context.Context, *CreateIndexRequest) (*Code, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResult, error)
+	SpellCheck(context.Context, *SpellCheckRequest) (*SpellCheckResult, error)
+	Generation(context.Context, *GenerationRequest) (*GenerationResult, error)
+	Count(context.Context, *SearchRequest) (*CountResult, error)
+	Explain(context.Context, *ExplainRequest) (*ExplainResult, error)
+	RegisterPercolator(context.Context, *PercolatorRequest) (*Code, error)
+	DeletePercolator(context.Context, *PercolatorRequest) (*Code, error)
+	Percolate(context.Context, *PercolateRequest) (*PercolateResult, error)
+	WatchChanges(*WatchRequest, IndexService_WatchChangesServer) error
+	DropIndex(context.Context, *DropIndexRequest) (*Code, error)
+	DeleteByQuery(context.Context, *DeleteByQueryRequest) (*DeleteByQueryResult, error)
+	SwitchAlias(context.Context, *SwitchAliasRequest) (*Code, error)
+	Reindex(context.Context, *ReindexRequest) (*ReindexStatus, error)
+	GetReindexStatus(context.Context, *ReindexStatusRequest) (*ReindexStatus, error)
+	Stats(context.Context, *StatsRequest) (*StatsResult, error)
 	mustEmbedUnimplementedIndexServiceServer()
 }
 
@@ -121,6 +325,51 @@ func (UnimplementedIndexServiceServer) Get(context.Context, *DocIdRequest) (*Get
 func (UnimplementedIndexServiceServer) CreateIndex(context.Context, *CreateIndexRequest) (*Code, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateIndex not implemented")
 }
+func (UnimplementedIndexServiceServer) Suggest(context.Context, *SuggestRequest) (*SuggestResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedIndexServiceServer) SpellCheck(context.Context, *SpellCheckRequest) (*SpellCheckResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpellCheck not implemented")
+}
+func (UnimplementedIndexServiceServer) Generation(context.Context, *GenerationRequest) (*GenerationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generation not implemented")
+}
+func (UnimplementedIndexServiceServer) Count(context.Context, *SearchRequest) (*CountResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedIndexServiceServer) Explain(context.Context, *ExplainRequest) (*ExplainResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedIndexServiceServer) RegisterPercolator(context.Context, *PercolatorRequest) (*Code, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPercolator not implemented")
+}
+func (UnimplementedIndexServiceServer) DeletePercolator(context.Context, *PercolatorRequest) (*Code, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePercolator not implemented")
+}
+func (UnimplementedIndexServiceServer) Percolate(context.Context, *PercolateRequest) (*PercolateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Percolate not implemented")
+}
+func (UnimplementedIndexServiceServer) WatchChanges(*WatchRequest, IndexService_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedIndexServiceServer) DropIndex(context.Context, *DropIndexRequest) (*Code, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropIndex not implemented")
+}
+func (UnimplementedIndexServiceServer) DeleteByQuery(context.Context, *DeleteByQueryRequest) (*DeleteByQueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteByQuery not implemented")
+}
+func (UnimplementedIndexServiceServer) SwitchAlias(context.Context, *SwitchAliasRequest) (*Code, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchAlias not implemented")
+}
+func (UnimplementedIndexServiceServer) Reindex(context.Context, *ReindexRequest) (*ReindexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
+func (UnimplementedIndexServiceServer) GetReindexStatus(context.Context, *ReindexStatusRequest) (*ReindexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReindexStatus not implemented")
+}
+func (UnimplementedIndexServiceServer) Stats(context.Context, *StatsRequest) (*StatsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
 func (UnimplementedIndexServiceServer) mustEmbedUnimplementedIndexServiceServer() {}
 
 // UnsafeIndexServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +473,279 @@ func _IndexService_CreateIndex_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexService_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_SpellCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpellCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).SpellCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_SpellCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).SpellCheck(ctx, req.(*SpellCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Generation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Generation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Generation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Generation(ctx, req.(*GenerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Count(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_RegisterPercolator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PercolatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).RegisterPercolator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_RegisterPercolator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).RegisterPercolator(ctx, req.(*PercolatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_DeletePercolator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PercolatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).DeletePercolator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_DeletePercolator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).DeletePercolator(ctx, req.(*PercolatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Percolate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PercolateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Percolate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Percolate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Percolate(ctx, req.(*PercolateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndexServiceServer).WatchChanges(m, &indexServiceWatchChangesServer{stream})
+}
+
+type IndexService_WatchChangesServer interface {
+	Send(*types.ChangeEvent) error
+	grpc.ServerStream
+}
+
+type indexServiceWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *indexServiceWatchChangesServer) Send(m *types.ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IndexService_DropIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).DropIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_DropIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).DropIndex(ctx, req.(*DropIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_DeleteByQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteByQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).DeleteByQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_DeleteByQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).DeleteByQuery(ctx, req.(*DeleteByQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_SwitchAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).SwitchAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_SwitchAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).SwitchAlias(ctx, req.(*SwitchAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Reindex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Reindex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Reindex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Reindex(ctx, req.(*ReindexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_GetReindexStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).GetReindexStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_GetReindexStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).GetReindexStatus(ctx, req.(*ReindexStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexService_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IndexService_ServiceDesc is the grpc.ServiceDesc for IndexService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,7 +773,69 @@ var IndexService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateIndex",
 			Handler:    _IndexService_CreateIndex_Handler,
 		},
+		{
+			MethodName: "Suggest",
+			Handler:    _IndexService_Suggest_Handler,
+		},
+		{
+			MethodName: "SpellCheck",
+			Handler:    _IndexService_SpellCheck_Handler,
+		},
+		{
+			MethodName: "Generation",
+			Handler:    _IndexService_Generation_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _IndexService_Count_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _IndexService_Explain_Handler,
+		},
+		{
+			MethodName: "RegisterPercolator",
+			Handler:    _IndexService_RegisterPercolator_Handler,
+		},
+		{
+			MethodName: "DeletePercolator",
+			Handler:    _IndexService_DeletePercolator_Handler,
+		},
+		{
+			MethodName: "Percolate",
+			Handler:    _IndexService_Percolate_Handler,
+		},
+		{
+			MethodName: "DropIndex",
+			Handler:    _IndexService_DropIndex_Handler,
+		},
+		{
+			MethodName: "DeleteByQuery",
+			Handler:    _IndexService_DeleteByQuery_Handler,
+		},
+		{
+			MethodName: "SwitchAlias",
+			Handler:    _IndexService_SwitchAlias_Handler,
+		},
+		{
+			MethodName: "Reindex",
+			Handler:    _IndexService_Reindex_Handler,
+		},
+		{
+			MethodName: "GetReindexStatus",
+			Handler:    _IndexService_GetReindexStatus_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _IndexService_Stats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _IndexService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "engine/index.proto",
 }
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 	"github.com/cylScripter/NexusFind/types/doc"
 	"github.com/cylScripter/NexusFind/utils"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type IndexInfo struct {
@@ -20,8 +23,14 @@ type IndexInfo struct {
 type IndexManager struct {
 	indexers       map[string]*index.Index
 	indexMapLocker map[string]*sync.RWMutex
-	IndexInfos     map[string]IndexInfo `json:"index_infos"`
-	Logger         *utils.Log           `json:"-"`
+	IndexInfos     map[string]IndexInfo   `json:"index_infos"`
+	Aliases        map[string]*IndexAlias `json:"aliases"` // 别名 -> 指向的索引
+	aliasLock      sync.RWMutex
+	managerLock    sync.RWMutex // 保护 indexers、indexMapLocker 和 IndexInfos 的增删和读取
+	reindexJobs    sync.Map     // 任务 Id -> *reindexJob
+	generations    sync.Map     // 索引名 -> *uint64，索引内容的版本号
+	reaper         ttlReaper    // 过期文档的清理协程
+	Logger         *utils.Log   `json:"-"`
 }
 
 func NewIndexManager(logger *utils.Log) *IndexManager {
@@ -30,6 +39,7 @@ func NewIndexManager(logger *utils.Log) *IndexManager {
 		indexers:       make(map[string]*index.Index),
 		indexMapLocker: make(map[string]*sync.RWMutex),
 		IndexInfos:     make(map[string]IndexInfo),
+		Aliases:        make(map[string]*IndexAlias),
 	}
 	// 如果之前有记录则进行反序列化
 	if utils.Exist(fmt.Sprintf("%v%v.idm.meta", utils.IDX_ROOT_PATH, utils.NexusFind)) {
@@ -42,6 +52,9 @@ func NewIndexManager(logger *utils.Log) *IndexManager {
 		if err != nil {
 			return idm
 		}
+		if idm.Aliases == nil {
+			idm.Aliases = make(map[string]*IndexAlias)
+		}
 		for _, idxInfo := range idm.IndexInfos {
 			idm.indexMapLocker[idxInfo.Name] = &sync.RWMutex{}
 			idm.indexers[idxInfo.Name] = index.NewIndexFromLocalFile(idxInfo.Name, idxInfo.Path, logger)
@@ -51,34 +64,87 @@ func NewIndexManager(logger *utils.Log) *IndexManager {
 }
 
 func (idm *IndexManager) GetIndex(indexName string) *index.Index {
-	if idm.indexMapLocker[indexName] == nil {
+	lock := idm.locker(indexName)
+	if lock == nil {
 		return nil
 	}
-	idm.indexMapLocker[indexName].RLock()
-	defer idm.indexMapLocker[indexName].RUnlock()
-	if _, ok := idm.indexers[indexName]; !ok {
+	lock.RLock()
+	defer lock.RUnlock()
+	idx := idm.indexer(indexName)
+	if idx == nil {
 		idm.Logger.NFLog.Warningf("Index [%v] does not exist", indexName)
 		return nil
 	}
-	idx := idm.indexers[indexName]
 	return idx
 }
 
+// CreateIndex
+// @Description 创建索引，在管理器的锁内检查并登记，并发创建同名索引时只有一个生效，已经存在时直接返回
+// @Param indexName 索引名，不能与别名相同
+// @Param fields 字段定义
+// @Return 与别名冲突或者持久化元数据失败时返回错误
 func (idm *IndexManager) CreateIndex(indexName string, fields []segment.SimpleFieldInfo) error {
-	idm.indexMapLocker[indexName] = &sync.RWMutex{}
-	idm.indexMapLocker[indexName].Lock()
-	defer idm.indexMapLocker[indexName].Unlock()
+	idm.managerLock.Lock()
+	if idm.isAlias(indexName) {
+		idm.managerLock.Unlock()
+		return fmt.Errorf("index [%v] conflicts with an existing alias", indexName)
+	}
 	if _, ok := idm.indexers[indexName]; ok {
+		idm.managerLock.Unlock()
 		idm.Logger.NFLog.Warningf("Index [%v] already exists", indexName)
 		return nil
 	}
-	idm.indexers[indexName] = index.NewEmptyIndex(indexName, utils.IDX_ROOT_PATH, idm.Logger)
+	// 先设置好字段再登记，其他请求看到索引时字段已经完整
+	idx := index.NewEmptyIndex(indexName, utils.IDX_ROOT_PATH, idm.Logger)
+	idx.SetFields(fields)
+	idm.indexers[indexName] = idx
+	idm.indexMapLocker[indexName] = &sync.RWMutex{}
 	idm.IndexInfos[indexName] = IndexInfo{Name: indexName, Path: utils.IDX_ROOT_PATH}
-	idm.indexers[indexName].SetFields(fields)
+	idm.managerLock.Unlock()
+	idm.bumpGeneration(indexName)
 	return idm.storeIndexManager()
 }
 
+// 内部方法，在管理器的锁内取出索引，不存在时返回 nil
+func (idm *IndexManager) indexer(indexName string) *index.Index {
+	idm.managerLock.RLock()
+	defer idm.managerLock.RUnlock()
+	return idm.indexers[indexName]
+}
+
+// 内部方法，在管理器的锁内取出索引的读写锁，不存在时返回 nil。
+// 调用方加锁之后需要再确认索引仍然存在，索引可能在等待锁的过程中被删除
+func (idm *IndexManager) locker(indexName string) *sync.RWMutex {
+	idm.managerLock.RLock()
+	defer idm.managerLock.RUnlock()
+	return idm.indexMapLocker[indexName]
+}
+
+// Generation
+// @Description 返回索引内容的版本号，新增、删除文档以及内存段落盘时变化。
+// 版本号从进程启动时间开始计数，worker 重启之后不会和重启前的版本号重复
+// @Param indexName 索引名
+// @Return 版本号
+func (idm *IndexManager) Generation(indexName string) uint64 {
+	value, _ := idm.generations.LoadOrStore(indexName, newGeneration())
+	return atomic.LoadUint64(value.(*uint64))
+}
+
+// 内部方法，索引内容变化后推进版本号
+func (idm *IndexManager) bumpGeneration(indexName string) {
+	value, _ := idm.generations.LoadOrStore(indexName, newGeneration())
+	atomic.AddUint64(value.(*uint64), 1)
+}
+
+// 内部方法，新的版本号计数器
+func newGeneration() *uint64 {
+	generation := uint64(time.Now().UnixNano())
+	return &generation
+}
+
 func (idm *IndexManager) storeIndexManager() error {
+	idm.managerLock.RLock()
+	defer idm.managerLock.RUnlock()
 	metaFileName := fmt.Sprintf("%v%v.idm.meta", utils.IDX_ROOT_PATH, utils.NexusFind)
 	if err := utils.WriteToJson(idm, metaFileName); err != nil {
 
@@ -88,61 +154,455 @@ func (idm *IndexManager) storeIndexManager() error {
 }
 
 func (idm *IndexManager) Add(indexName string, doc *doc.Document) (uint64, error) {
-	if _, ok := idm.indexers[indexName]; !ok {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return 0, fmt.Errorf("no has %v", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
 		return 0, fmt.Errorf("no has %v", indexName)
 	}
-	idm.indexMapLocker[indexName].Lock()
-	defer idm.indexMapLocker[indexName].Unlock()
-	return idm.indexers[indexName].AddDocument(doc)
+	defer idm.bumpGeneration(indexName)
+	return idm.indexer(indexName).AddDocument(doc)
 }
 
 func (idm *IndexManager) Get(indexName string, id string) (*doc.Document, bool) {
-	idm.indexMapLocker[indexName].RLock()
-	defer idm.indexMapLocker[indexName].RUnlock()
-	docpk, exits := idm.indexers[indexName].IsNotDelete(id)
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, false
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, false
+	}
+	docpk, exits := idm.indexer(indexName).IsNotDelete(id)
 	if exits {
-		return idm.indexers[indexName].GetDocument(docpk)
+		return idm.indexer(indexName).GetDocument(docpk)
 	}
 	return nil, false
 }
 
 func (idm *IndexManager) Delete(indexName string, pk string) error {
-	idm.indexMapLocker[indexName].RLock()
-	defer idm.indexMapLocker[indexName].RUnlock()
-	if _, ok := idm.indexers[indexName]; !ok {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	// 删除修改删除位图、DelDocNum 并写变更日志，与 DeleteByQuery 一样需要写锁
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
 		return fmt.Errorf("index[%v] not found", indexName)
 	}
-	return idm.indexers[indexName].DeleteDocument(pk)
+	defer idm.bumpGeneration(indexName)
+	return idm.indexer(indexName).DeleteDocument(pk)
 }
 
-func (idm *IndexManager) Search(indexName string, query *types.TermQuery, filters []*types.SearchFilters) []*doc.Document {
-	idm.indexMapLocker[indexName].RLock()
-	defer idm.indexMapLocker[indexName].RUnlock()
-	if _, ok := idm.indexers[indexName]; !ok {
-		fmt.Println("")
+func (idm *IndexManager) Search(indexName string, query *types.TermQuery, filters []*types.SearchFilters, profile *types.ShardProfile) []*doc.Document {
+	lock := idm.locker(indexName)
+	if lock == nil {
 		return nil
 	}
-	return idm.indexers[indexName].Search(query, filters)
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil
+	}
+	return idm.indexer(indexName).Search(query, filters, profile)
+}
+
+// Count
+// @Description 统计命中的文档数，不读取文档内容
+// @Param indexName 索引名
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param terminateAfter 统计到这么多条后提前结束，为 0 时统计全部
+// @Return 命中的文档数、索引不存在时返回错误
+func (idm *IndexManager) Count(indexName string, query *types.TermQuery, filters []*types.SearchFilters, terminateAfter uint64) (uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Count(query, filters, terminateAfter), nil
+}
+
+// DropIndex
+// @Description 关闭并删除索引，删除索引在磁盘上的文件并从索引管理器的元数据中移除
+// @Param indexName 索引名
+// @Return 索引不存在、被别名引用或者删除文件失败时返回错误
+func (idm *IndexManager) DropIndex(indexName string) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	idx := idm.indexer(indexName)
+	if idx == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	// 别名的检查和删除在同一次加锁内完成，SwitchAlias 不会在两者之间指向该索引
+	idm.managerLock.Lock()
+	if alias := idm.aliasOf(indexName); alias != "" {
+		idm.managerLock.Unlock()
+		return fmt.Errorf("index [%v] is used by alias [%v], switch the alias first", indexName, alias)
+	}
+	delete(idm.indexers, indexName)
+	delete(idm.indexMapLocker, indexName)
+	delete(idm.IndexInfos, indexName)
+	idm.managerLock.Unlock()
+	idm.reaper.stats.Delete(indexName)
+	// 版本号不删除，同名索引重建后不会和删除前的版本号重复
+	idm.bumpGeneration(indexName)
+	if err := idm.storeIndexManager(); err != nil {
+		return err
+	}
+	return idx.Drop()
+}
+
+// DeleteByQuery
+// @Description 删除所有满足关键词查询和过滤条件的文档
+// @Param indexName 索引名
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Return 删除的文档数、错误
+func (idm *IndexManager) DeleteByQuery(indexName string, query *types.TermQuery, filters []*types.SearchFilters) (uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	deleted, err := idm.indexer(indexName).DeleteByQuery(query, filters)
+	if deleted > 0 {
+		idm.bumpGeneration(indexName)
+	}
+	return deleted, err
+}
+
+// Watch
+// @Description 从变更日志中按序号推送索引的变更，推送完已有的变更后等待新的变更，直到 ctx 结束或者索引关闭
+// @Param ctx 控制推送结束
+// @Param indexName 索引名
+// @Param from 从该序号开始推送，为 0 时从头开始
+// @Param includeDoc 新增事件是否带上文档内容
+// @Param send 推送一条变更，返回错误时结束推送
+// @Return 索引不存在、索引关闭或者推送失败时返回错误，ctx 结束时返回空
+func (idm *IndexManager) Watch(ctx context.Context, indexName string, from uint64, includeDoc bool, send func(*types.ChangeEvent) error) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	idx := idm.indexer(indexName)
+	lock.RUnlock()
+	if idx == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	changes := idx.ChangeLog()
+	if changes == nil {
+		return fmt.Errorf("index[%v] has no change log", indexName)
+	}
+	// 先订阅再读取，读取过程中写入的变更也会收到通知
+	notify, cancel := changes.Subscribe()
+	defer cancel()
+	const batchSize = 1024
+	for {
+		events, err := changes.ReadSince(from, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if !includeDoc {
+				event.Doc = nil
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+			from = event.Seq + 1
+		}
+		if len(events) == batchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-notify:
+			if !ok {
+				return index.ErrChangeLogClosed
+			}
+		}
+	}
+}
+
+// RegisterPercolator
+// @Description 在索引上注册查询
+// @Param indexName 索引名
+// @Param query 查询及过滤条件
+// @Return 查询不合法或者索引不存在时返回错误
+func (idm *IndexManager) RegisterPercolator(indexName string, query *types.PercolateQuery) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).RegisterPercolator(query)
+}
+
+// DeletePercolator
+// @Description 删除索引上注册的查询
+// @Param indexName 索引名
+// @Param id 查询的 Id
+// @Return 查询或者索引不存在时返回错误
+func (idm *IndexManager) DeletePercolator(indexName, id string) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).DeletePercolator(id)
+}
+
+// Percolate
+// @Description 判断文档命中了索引上哪些注册的查询，文档不会写入索引
+// @Param indexName 索引名
+// @Param d 文档
+// @Return 命中的查询的 Id、文档不合法或者索引不存在时返回错误
+func (idm *IndexManager) Percolate(indexName string, d *doc.Document) ([]string, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Percolate(d)
+}
+
+// Explain
+// @Description 解释某个文档为什么命中或者没有命中查询
+// @Param indexName 索引名
+// @Param primaryKey 文档主键
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Return 解释结果、索引不存在时返回错误
+func (idm *IndexManager) Explain(indexName, primaryKey string, query *types.TermQuery, filters []*types.SearchFilters) (*types.Explanation, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Explain(primaryKey, query, filters), nil
+}
+
+// ValidateCollapse
+// @Description 按索引的字段定义校验折叠条件
+// @Param indexName 索引名
+// @Param collapse 折叠条件
+// @Return 折叠字段不合法或者索引不存在时返回错误
+func (idm *IndexManager) ValidateCollapse(indexName string, collapse *types.Collapse) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).ValidateCollapse(collapse)
+}
+
+// SearchAfter
+// @Description 按 docId 升序分页检索
+// @Param indexName 索引名
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档、对应的 docId、索引不存在时返回错误
+func (idm *IndexManager) SearchAfter(indexName string, query *types.TermQuery, filters []*types.SearchFilters, after, size uint64) ([]*doc.Document, []uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	docs, docIds := idm.indexer(indexName).SearchAfter(query, filters, after, size)
+	return docs, docIds, nil
+}
+
+// SearchProfile
+// @Description 与 SearchAfter 相同，同时返回各个段的耗时
+// @Param indexName 索引名
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档、对应的 docId、各个段的耗时信息、索引不存在时返回错误
+func (idm *IndexManager) SearchProfile(indexName string, query *types.TermQuery, filters []*types.SearchFilters, after, size uint64) ([]*doc.Document, []uint64, []*types.SegmentProfile, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	docs, docIds, profiles := idm.indexer(indexName).SearchProfile(query, filters, after, size)
+	return docs, docIds, profiles, nil
+}
+
+// ValidateFilters
+// @Description 按索引的字段定义校验过滤条件
+// @Param indexName 索引名
+// @Param filters 过滤条件
+// @Return 过滤条件不合法或者索引不存在时返回错误
+func (idm *IndexManager) ValidateFilters(indexName string, filters []*types.SearchFilters) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).ValidateFilters(filters)
+}
+
+func (idm *IndexManager) MoreLikeThis(indexName string, mlt *types.MoreLikeThis, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, nil, 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, nil, 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).MoreLikeThis(mlt, filters, profile)
+}
+
+func (idm *IndexManager) Knn(indexName string, knn *types.KnnQuery, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Knn(knn, filters, profile)
+}
+
+func (idm *IndexManager) Hybrid(indexName string, query *types.TermQuery, knn *types.KnnQuery, fusion *types.Fusion, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, nil, 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, nil, 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Hybrid(query, knn, fusion, filters, profile)
+}
+
+func (idm *IndexManager) Suggest(indexName string, fieldName, prefix string, size uint64) ([]*types.Suggestion, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).Suggest(fieldName, prefix, size)
+}
+
+func (idm *IndexManager) SpellCheck(indexName string, request *SpellCheckRequest) ([]*types.TermCorrection, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return nil, fmt.Errorf("index[%v] not found", indexName)
+	}
+	maxEdits := int(request.MaxEdits)
+	if maxEdits <= 0 {
+		maxEdits = 2
+	}
+	size := request.Size
+	if size == 0 {
+		size = 5
+	}
+	return idm.indexer(indexName).SpellCheck(request.Field, request.Text, request.Query, maxEdits, size)
+}
+
+// FilterCacheStats
+// @Description 返回所有索引共用的过滤结果缓存的命中、未命中、淘汰次数以及内存占用
+// @Return 统计信息
+func (idm *IndexManager) FilterCacheStats() segment.FilterCacheStats {
+	return segment.DefaultFilterCache.Stats()
 }
 
 func (idm *IndexManager) sync(indexName string) error {
-	idm.indexMapLocker[indexName].RLock()
-	defer idm.indexMapLocker[indexName].RUnlock()
-	if _, ok := idm.indexers[indexName]; !ok {
+	lock := idm.locker(indexName)
+	if lock == nil {
 		return errors.New(fmt.Sprintf("[ERROR] index[%v] not found", indexName))
 	}
-	return idm.indexers[indexName].SyncMemorySegment()
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(indexName) == nil {
+		return errors.New(fmt.Sprintf("[ERROR] index[%v] not found", indexName))
+	}
+	defer idm.bumpGeneration(indexName)
+	return idm.indexer(indexName).SyncMemorySegment()
 }
 
 func (idm *IndexManager) Close() error {
-	for _, idxInfo := range idm.IndexInfos {
-		err := idm.sync(idxInfo.Name)
+	idm.stopTTLReaper()
+	for _, name := range idm.indexNames() {
+		err := idm.sync(name)
 		if err != nil {
 			fmt.Println(err)
 		}
-		err = idm.indexers[idxInfo.Name].Close()
-		if err != nil {
-			fmt.Println(err)
+		if idx := idm.indexer(name); idx != nil {
+			if err := idx.Close(); err != nil {
+				fmt.Println(err)
+			}
 		}
 	}
 	return idm.storeIndexManager()
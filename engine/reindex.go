@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 内部类型，一个重建索引任务的进度
+type reindexJob struct {
+	id, source, dest string
+	query            *types.TermQuery
+	filters          []*types.SearchFilters
+	total            uint64
+	copied           uint64
+	failed           uint64
+	startTime        int64
+	endTime          int64 // 为 0 时任务还没有结束
+	err              string
+	lock             sync.Mutex // 保护 endTime 和 err
+}
+
+// Reindex
+// @Description 在后台把源索引中的文档逐批复制到目标索引，目标索引按自己的 schema 重新建索引。
+// 复制过程中写入源索引的文档只要在任务结束前写入就会被复制，删除不会同步到目标索引
+// @Param jobId 任务 Id，已经存在时返回错误
+// @Param source 源索引
+// @Param dest 目标索引，需要已经创建
+// @Param query 关键词查询，与 filters 都为空时复制全部文档
+// @Param filters 过滤条件
+// @Return 任务开始时的进度
+func (idm *IndexManager) Reindex(jobId, source, dest string, query *types.TermQuery, filters []*types.SearchFilters) (*ReindexStatus, error) {
+	if source == dest {
+		return nil, fmt.Errorf("reindex source and dest are the same index [%v]", dest)
+	}
+	if idm.GetIndex(dest) == nil {
+		return nil, fmt.Errorf("index[%v] not found", dest)
+	}
+	total, err := idm.reindexTotal(source, query, filters)
+	if err != nil {
+		return nil, err
+	}
+	idm.pruneReindexJobs(time.Now())
+	job := &reindexJob{id: jobId, source: source, dest: dest, query: query, filters: filters, total: total, startTime: time.Now().UnixMilli()}
+	if _, loaded := idm.reindexJobs.LoadOrStore(jobId, job); loaded {
+		return nil, fmt.Errorf("reindex job [%v] already exists", jobId)
+	}
+	go idm.runReindex(job)
+	return job.status(), nil
+}
+
+// ReindexStatus
+// @Description 返回重建索引任务的进度
+// @Param jobId 任务 Id
+// @Return 进度，任务不存在或者结束后已经被清理时返回错误
+func (idm *IndexManager) ReindexStatus(jobId string) (*ReindexStatus, error) {
+	value, ok := idm.reindexJobs.Load(jobId)
+	if !ok {
+		return nil, fmt.Errorf("reindex job [%v] not found", jobId)
+	}
+	return value.(*reindexJob).status(), nil
+}
+
+// 内部方法，删除结束超过 REINDEX_JOB_RETENTION 的任务，
+// 剩下的已结束任务超过 REINDEX_MAX_FINISHED_JOBS 时按结束时间删除最早的任务。运行中的任务不删除
+func (idm *IndexManager) pruneReindexJobs(now time.Time) {
+	expire := now.Add(-utils.REINDEX_JOB_RETENTION).UnixMilli()
+	finished := make([]*reindexJob, 0)
+	idm.reindexJobs.Range(func(key, value any) bool {
+		job := value.(*reindexJob)
+		endTime := job.finishedAt()
+		if endTime == 0 {
+			return true
+		}
+		if endTime < expire {
+			idm.reindexJobs.Delete(key)
+			return true
+		}
+		finished = append(finished, job)
+		return true
+	})
+	if len(finished) <= utils.REINDEX_MAX_FINISHED_JOBS {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].finishedAt() < finished[j].finishedAt()
+	})
+	for _, job := range finished[:len(finished)-utils.REINDEX_MAX_FINISHED_JOBS] {
+		idm.reindexJobs.Delete(job.id)
+	}
+}
+
+// 内部方法，逐批读取源索引并写入目标索引，读取时只持有源索引的读锁
+func (idm *IndexManager) runReindex(job *reindexJob) {
+	const batchSize = 1024
+	after := uint64(0)
+	for {
+		docs, docIds, err := idm.reindexBatch(job, after, batchSize)
+		if err != nil {
+			job.finish(err)
+			return
+		}
+		if len(docs) == 0 {
+			break
+		}
+		for _, d := range docs {
+			if _, err := idm.Add(job.dest, d); err != nil {
+				atomic.AddUint64(&job.failed, 1)
+				job.setError(fmt.Errorf("copy document [%v] failed: %v", d.Id, err))
+				continue
+			}
+			atomic.AddUint64(&job.copied, 1)
+		}
+		after = docIds[len(docIds)-1] + 1
+	}
+	idm.Logger.NFLog.Infof("reindex job [%v] copied %v documents from [%v] to [%v]", job.id, atomic.LoadUint64(&job.copied), job.source, job.dest)
+	job.finish(nil)
+}
+
+// 内部方法，读取源索引中 docId 不小于 after 的一批文档
+func (idm *IndexManager) reindexBatch(job *reindexJob, after, size uint64) ([]*doc.Document, []uint64, error) {
+	if !job.query.Empty() || len(job.filters) > 0 {
+		return idm.SearchAfter(job.source, job.query, job.filters, after, size)
+	}
+	lock := idm.locker(job.source)
+	if lock == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", job.source)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	if idm.indexer(job.source) == nil {
+		return nil, nil, fmt.Errorf("index[%v] not found", job.source)
+	}
+	docs, docIds := idm.indexer(job.source).ScanDocuments(after, size)
+	return docs, docIds, nil
+}
+
+// 内部方法，任务开始时源索引中待复制的文档数
+func (idm *IndexManager) reindexTotal(source string, query *types.TermQuery, filters []*types.SearchFilters) (uint64, error) {
+	if !query.Empty() || len(filters) > 0 {
+		return idm.Count(source, query, filters, 0)
+	}
+	lock := idm.locker(source)
+	if lock == nil {
+		return 0, fmt.Errorf("index[%v] not found", source)
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	idx := idm.indexer(source)
+	if idx == nil {
+		return 0, fmt.Errorf("index[%v] not found", source)
+	}
+	return idx.MaxDocId - idx.StartDocId - uint64(idx.DelDocNum), nil
+}
+
+// 内部方法，记录第一个错误
+func (job *reindexJob) setError(err error) {
+	job.lock.Lock()
+	defer job.lock.Unlock()
+	if job.err == "" {
+		job.err = err.Error()
+	}
+}
+
+// 内部方法，结束任务
+func (job *reindexJob) finish(err error) {
+	if err != nil {
+		job.setError(err)
+	}
+	job.lock.Lock()
+	defer job.lock.Unlock()
+	job.endTime = time.Now().UnixMilli()
+}
+
+// 内部方法，任务结束的时间，还没有结束时为 0
+func (job *reindexJob) finishedAt() int64 {
+	job.lock.Lock()
+	defer job.lock.Unlock()
+	return job.endTime
+}
+
+// 内部方法，任务当前进度的快照
+func (job *reindexJob) status() *ReindexStatus {
+	job.lock.Lock()
+	defer job.lock.Unlock()
+	return &ReindexStatus{
+		JobId:     job.id,
+		Source:    job.source,
+		Dest:      job.dest,
+		Total:     job.total,
+		Copied:    atomic.LoadUint64(&job.copied),
+		Failed:    atomic.LoadUint64(&job.failed),
+		Done:      job.endTime > 0,
+		Error:     job.err,
+		StartTime: job.startTime,
+		EndTime:   job.endTime,
+	}
+}
@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"container/list"
+	"github.com/cylScripter/NexusFind/utils"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultCache Sentinel 上的查询结果缓存。key 为规范化后的 SearchRequest，
+// 每条结果记录查询时各个 worker 上该索引的版本号，版本号变化或者超过 ttl 后失效。
+// 版本号本身也缓存 generationTTL，避免每次查找结果都访问所有 worker
+type ResultCache struct {
+	mutex            sync.Mutex
+	ttl              time.Duration
+	generationTTL    time.Duration
+	capacity         int
+	lru              *list.List
+	items            map[string]*list.Element
+	generations      map[string]generationEntry // 索引名及 worker 列表 -> 版本号
+	hits             uint64
+	misses           uint64
+	generationHits   uint64
+	generationMisses uint64
+}
+
+type generationEntry struct {
+	fingerprint string
+	expire      time.Time
+}
+
+type resultCacheEntry struct {
+	key         string
+	fingerprint string
+	result      *Result
+	expire      time.Time
+}
+
+// NewResultCache
+// @Description 创建查询结果缓存
+// @Param ttl 结果的有效期
+// @Param capacity 最多缓存多少条结果
+// @Return 查询结果缓存
+func NewResultCache(ttl time.Duration, capacity int) *ResultCache {
+	return &ResultCache{
+		ttl:           ttl,
+		generationTTL: utils.GENERATION_CACHE_TTL,
+		capacity:      capacity,
+		lru:           list.New(),
+		items:         make(map[string]*list.Element),
+		generations:   make(map[string]generationEntry),
+	}
+}
+
+// SetGenerationTTL
+// @Description 修改版本号的缓存时间，为 0 时每次查找结果都重新取得版本号
+// @Param ttl 版本号的缓存时间
+func (rc *ResultCache) SetGenerationTTL(ttl time.Duration) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.generationTTL = ttl
+	rc.generations = make(map[string]generationEntry)
+}
+
+// Fingerprint
+// @Description 返回缓存的版本号，没有缓存或者已经过期时调用 load 取得并缓存
+// @Param indexName 索引名
+// @Param endpoints 当前的 worker 列表，worker 增减时使用不同的缓存
+// @Param load 访问各个 worker 取得版本号，返回 false 时不缓存
+// @Return 版本号、是否取得
+func (rc *ResultCache) Fingerprint(indexName string, endpoints []string, load func() (string, bool)) (string, bool) {
+	sorted := append([]string(nil), endpoints...)
+	sort.Strings(sorted)
+	key := indexName + "|" + strings.Join(sorted, ",")
+	rc.mutex.Lock()
+	if entry, ok := rc.generations[key]; ok && time.Now().Before(entry.expire) {
+		rc.generationHits++
+		rc.mutex.Unlock()
+		return entry.fingerprint, true
+	}
+	rc.generationMisses++
+	rc.mutex.Unlock()
+	fingerprint, ok := load()
+	if !ok {
+		return "", false
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.generationTTL > 0 {
+		rc.generations[key] = generationEntry{fingerprint: fingerprint, expire: time.Now().Add(rc.generationTTL)}
+	}
+	return fingerprint, true
+}
+
+// InvalidateGenerations
+// @Description 清除缓存的版本号，经过该 Sentinel 的写入完成后调用，下一次查询重新取得版本号
+func (rc *ResultCache) InvalidateGenerations() {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.generations = make(map[string]generationEntry)
+}
+
+// Get
+// @Description 查找缓存的结果，版本号不一致或者已经过期的结果会被删除
+// @Param key resultCacheKey 的返回值
+// @Param fingerprint 当前各个 worker 的版本号
+// @Return 结果的副本
+func (rc *ResultCache) Get(key, fingerprint string) (*Result, bool) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	element, ok := rc.items[key]
+	if !ok {
+		rc.misses++
+		return nil, false
+	}
+	entry := element.Value.(*resultCacheEntry)
+	if entry.fingerprint != fingerprint || time.Now().After(entry.expire) {
+		rc.lru.Remove(element)
+		delete(rc.items, key)
+		rc.misses++
+		return nil, false
+	}
+	rc.hits++
+	rc.lru.MoveToFront(element)
+	return proto.Clone(entry.result).(*Result), true
+}
+
+// Put
+// @Description 缓存查询结果，超过容量时淘汰最久没有使用的结果
+// @Param key resultCacheKey 的返回值
+// @Param fingerprint 查询前各个 worker 的版本号
+// @Param result 查询结果，缓存保存的是副本
+func (rc *ResultCache) Put(key, fingerprint string, result *Result) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.capacity <= 0 {
+		return
+	}
+	if element, ok := rc.items[key]; ok {
+		rc.lru.Remove(element)
+	}
+	entry := &resultCacheEntry{key: key, fingerprint: fingerprint, result: proto.Clone(result).(*Result), expire: time.Now().Add(rc.ttl)}
+	rc.items[key] = rc.lru.PushFront(entry)
+	for rc.lru.Len() > rc.capacity {
+		oldest := rc.lru.Remove(rc.lru.Back()).(*resultCacheEntry)
+		delete(rc.items, oldest.key)
+	}
+}
+
+// Stats
+// @Description 返回缓存的命中、未命中次数、条数以及版本号缓存的命中、未命中次数
+// @Return 统计信息
+func (rc *ResultCache) Stats() *ResultCacheStats {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return &ResultCacheStats{
+		Hits:             rc.hits,
+		Misses:           rc.misses,
+		Entries:          uint64(rc.lru.Len()),
+		Capacity:         uint64(rc.capacity),
+		GenerationHits:   rc.generationHits,
+		GenerationMisses: rc.generationMisses,
+	}
+}
+
+// 内部方法，规范化查询请求作为缓存的 key，过滤条件之间是与的关系，按 CacheKey 排序。
+// 过滤条件中有 now 这类随时间变化的日期表达式时不缓存，需要返回耗时信息的查询也不缓存
+func resultCacheKey(request *SearchRequest) (string, bool) {
+	if request.Profile {
+		return "", false
+	}
+	normalized := proto.Clone(request).(*SearchRequest)
+	for i, filter := range normalized.Filter {
+		if !filter.Cacheable() {
+			return "", false
+		}
+		normalized.Filter[i] = filter.Normalize()
+	}
+	sort.Slice(normalized.Filter, func(i, j int) bool {
+		return normalized.Filter[i].CacheKey() < normalized.Filter[j].CacheKey()
+	})
+	buf, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalized)
+	if err != nil {
+		return "", false
+	}
+	return string(buf), true
+}
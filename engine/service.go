@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"github.com/cylScripter/NexusFind/utils"
+	"time"
 )
 
 type Service struct {
@@ -35,14 +36,62 @@ func (svc *Service) CreateIndex(ctx context.Context, request *CreateIndexRequest
 }
 
 func (svc *Service) Add(ctx context.Context, request *AddRequest) (*Code, error) {
-	code, err := svc.sentinel.Add(request)
+	return svc.sentinel.Add(request)
+}
+
+func (svc *Service) RegisterPercolator(ctx context.Context, request *PercolatorRequest) (*Code, error) {
+	code, err := svc.sentinel.RegisterPercolator(request)
 	return &Code{StatusCode: uint64(code)}, err
 }
 
-func (svc *Service) Search(ctx context.Context, request *SearchRequest) (*Result, error) {
-	docList, err := svc.sentinel.Search(request)
+func (svc *Service) DeletePercolator(ctx context.Context, request *PercolatorRequest) (*Code, error) {
+	code, err := svc.sentinel.DeletePercolator(request)
+	return &Code{StatusCode: uint64(code)}, err
+}
+
+func (svc *Service) DropIndex(ctx context.Context, request *DropIndexRequest) (*Code, error) {
+	code, err := svc.sentinel.DropIndex(request)
+	return &Code{StatusCode: uint64(code)}, err
+}
+
+func (svc *Service) DeleteByQuery(ctx context.Context, request *DeleteByQueryRequest) (*DeleteByQueryResult, error) {
+	return svc.sentinel.DeleteByQuery(request)
+}
 
-	return &Result{DocResult: docList}, err
+func (svc *Service) SwitchAlias(ctx context.Context, request *SwitchAliasRequest) (*Code, error) {
+	code, err := svc.sentinel.SwitchAlias(request)
+	return &Code{StatusCode: uint64(code)}, err
+}
+
+func (svc *Service) Reindex(ctx context.Context, request *ReindexRequest) (*ReindexStatus, error) {
+	return svc.sentinel.Reindex(request)
+}
+
+func (svc *Service) GetReindexStatus(ctx context.Context, request *ReindexStatusRequest) (*ReindexStatus, error) {
+	return svc.sentinel.GetReindexStatus(request)
+}
+
+func (svc *Service) Stats(ctx context.Context, request *StatsRequest) (*StatsResult, error) {
+	return svc.sentinel.Stats(request)
+}
+
+func (svc *Service) Percolate(ctx context.Context, request *PercolateRequest) (*PercolateResult, error) {
+	return svc.sentinel.Percolate(request)
+}
+
+func (svc *Service) Search(ctx context.Context, request *SearchRequest) (*Result, error) {
+	result, err := svc.sentinel.Search(request)
+	if err != nil {
+		return nil, err
+	}
+	// 没有命中任何文档时，对查询进行纠错
+	if len(result.DocResult) == 0 && request.MoreLikeThis == nil && (request.Knn == nil || isHybrid(request)) && request.Query != nil && !request.Query.Empty() {
+		didYouMean, e := svc.sentinel.SpellCheck(&SpellCheckRequest{IndexName: request.IndexName, Query: request.Query})
+		if e == nil && didYouMean.Corrected.ToString() != request.Query.ToString() {
+			result.DidYouMean = didYouMean
+		}
+	}
+	return result, nil
 }
 
 func (svc *Service) Get(ctx context.Context, request *DocIdRequest) (*GetResult, error) {
@@ -56,6 +105,35 @@ func (svc *Service) Get(ctx context.Context, request *DocIdRequest) (*GetResult,
 	return &GetResult{Doc: docs, Exist: exist}, err
 }
 
+func (svc *Service) Suggest(ctx context.Context, request *SuggestRequest) (*SuggestResult, error) {
+	suggestions, err := svc.sentinel.Suggest(request)
+	return &SuggestResult{Suggestions: suggestions}, err
+}
+
+func (svc *Service) SpellCheck(ctx context.Context, request *SpellCheckRequest) (*SpellCheckResult, error) {
+	return svc.sentinel.SpellCheck(request)
+}
+
+func (svc *Service) Count(ctx context.Context, request *SearchRequest) (*CountResult, error) {
+	return svc.sentinel.Count(request)
+}
+
+func (svc *Service) Explain(ctx context.Context, request *ExplainRequest) (*ExplainResult, error) {
+	return svc.sentinel.Explain(request)
+}
+
+func (svc *Service) Generation(ctx context.Context, request *GenerationRequest) (*GenerationResult, error) {
+	return svc.sentinel.Generation(request)
+}
+
+// EnableResultCache
+// @Description 开启查询结果缓存
+// @Param ttl 结果的有效期
+// @Param capacity 最多缓存多少条结果
+func (svc *Service) EnableResultCache(ttl time.Duration, capacity int) {
+	svc.sentinel.EnableResultCache(ttl, capacity)
+}
+
 func (svc *Service) Close() {
 	err := svc.sentinel.Close()
 	if err != nil {
@@ -66,3 +144,7 @@ func (svc *Service) Close() {
 func (svc *Service) Watch() {
 	svc.sentinel.hub.watchService(INDEX_SERVICE)
 }
+
+func (svc *Service) WatchChanges(request *WatchRequest, stream IndexService_WatchChangesServer) error {
+	return svc.sentinel.WatchChanges(stream.Context(), request, stream.Send)
+}
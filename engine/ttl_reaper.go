@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 内部类型，清理协程和各个索引的统计信息
+type ttlReaper struct {
+	stop  chan struct{}
+	done  chan struct{}
+	stats sync.Map // 索引名 -> *TTLStats
+}
+
+// SetTTL
+// @Description 设置索引的过期规则
+// @Param indexName 索引名
+// @Param field 判断过期的日期字段，为空并且 defaultTTL 大于 0 时按写入时间判断
+// @Param defaultTTL 有效期，单位秒
+// @Return 索引不存在或者字段不合法时返回错误
+func (idm *IndexManager) SetTTL(indexName, field string, defaultTTL int64) error {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if idm.indexer(indexName) == nil {
+		return fmt.Errorf("index[%v] not found", indexName)
+	}
+	return idm.indexer(indexName).SetTTL(field, defaultTTL)
+}
+
+// ExpireDocuments
+// @Description 立即清理索引中已经过期的文档，并记录统计信息
+// @Param indexName 索引名
+// @Return 本次过期的文档数、错误
+func (idm *IndexManager) ExpireDocuments(indexName string) (uint64, error) {
+	lock := idm.locker(indexName)
+	if lock == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	idx := idm.indexer(indexName)
+	if idx == nil {
+		return 0, fmt.Errorf("index[%v] not found", indexName)
+	}
+	if idx.TTLField == "" {
+		return 0, nil
+	}
+	now := time.Now()
+	expired, err := idx.ExpireDocuments(now)
+	if expired > 0 {
+		idm.bumpGeneration(indexName)
+	}
+	stats := &TTLStats{Expired: idx.ExpiredDocNum, LastExpired: expired, LastRun: now.UnixMilli()}
+	if err != nil {
+		stats.LastError = err.Error()
+	}
+	idm.reaper.stats.Store(indexName, stats)
+	return expired, err
+}
+
+// TTLStats
+// @Description 返回各个索引的过期清理统计信息，没有清理过的索引不在结果中
+// @Return 索引名 -> 统计信息的副本
+func (idm *IndexManager) TTLStats() map[string]*TTLStats {
+	result := make(map[string]*TTLStats)
+	idm.reaper.stats.Range(func(key, value any) bool {
+		result[key.(string)] = proto.Clone(value.(*TTLStats)).(*TTLStats)
+		return true
+	})
+	return result
+}
+
+// StartTTLReaper
+// @Description 启动后台协程，每隔 interval 清理一次所有设置了过期规则的索引，重复调用时只保留第一个协程
+// @Param interval 清理间隔
+func (idm *IndexManager) StartTTLReaper(interval time.Duration) {
+	if interval <= 0 || idm.reaper.stop != nil {
+		return
+	}
+	idm.reaper.stop = make(chan struct{})
+	idm.reaper.done = make(chan struct{})
+	go func(stop <-chan struct{}, done chan<- struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				for _, name := range idm.indexNames() {
+					expired, err := idm.ExpireDocuments(name)
+					if err != nil {
+						idm.Logger.NFLog.Errorf("expire documents of index [%v] failed: %v", name, err)
+					} else if expired > 0 {
+						idm.Logger.NFLog.Infof("expired %v documents in index [%v]", expired, name)
+					}
+				}
+			}
+		}
+	}(idm.reaper.stop, idm.reaper.done)
+}
+
+// 内部方法，停止清理协程并等待正在进行的清理结束
+func (idm *IndexManager) stopTTLReaper() {
+	if idm.reaper.stop == nil {
+		return
+	}
+	close(idm.reaper.stop)
+	<-idm.reaper.done
+	idm.reaper.stop = nil
+}
+
+// 内部方法，在管理器的锁内复制当前所有索引名，清理过程中创建或者删除索引不影响遍历
+func (idm *IndexManager) indexNames() []string {
+	idm.managerLock.RLock()
+	defer idm.managerLock.RUnlock()
+	names := make([]string, 0, len(idm.IndexInfos))
+	for name := range idm.IndexInfos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,377 @@
+package index
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/utils"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrChangeLogClosed 变更日志已经关闭，例如索引被关闭或者删除
+var ErrChangeLogClosed = errors.New("change log closed")
+
+// ErrChangeLogTruncated 要读取的变更已经因为超过保留条数被截掉，调用方需要重新全量同步
+var ErrChangeLogTruncated = errors.New("change log truncated")
+
+// ChangeLog 索引的变更日志，记录为 4 字节大端长度加上 ChangeEvent 的序列化结果。
+// 写入后按 syncInterval 合并落盘，为 0 时每条都落盘之后才返回；超过 maxEvents 条时截掉较早的一半。
+// 每 CHANGE_LOG_INDEX_STEP 条记录一次文件偏移，Watch 按序号读取时从最近的偏移开始
+type ChangeLog struct {
+	mutex        sync.Mutex
+	fileName     string
+	file         *os.File
+	size         int64  // 文件中完整记录的总长度，即下一条记录的偏移
+	firstSeq     uint64 // 日志中最早的序号，日志为空时为 lastSeq+1
+	lastSeq      uint64
+	checkpoints  []int64 // checkpoints[i] 为序号 firstSeq+i*CHANGE_LOG_INDEX_STEP 的记录的偏移
+	syncInterval time.Duration
+	syncTimer    *time.Timer // 等待落盘的定时器，没有未落盘的记录时为空
+	syncErr      error       // 合并落盘失败时的错误，下一次 Append 会同步落盘
+	maxEvents    uint64
+	closed       bool
+	subscribers  map[chan struct{}]struct{}
+}
+
+// OpenChangeLog
+// @Description 打开变更日志，不存在时创建，读取已有的记录得到序号范围并建立偏移索引。末尾不完整的记录会被截掉
+// @Param fileName 日志文件名
+// @Return 变更日志、错误
+func OpenChangeLog(fileName string) (*ChangeLog, error) {
+	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	cl := &ChangeLog{
+		fileName:     fileName,
+		file:         file,
+		syncInterval: utils.CHANGE_LOG_SYNC_INTERVAL,
+		maxEvents:    utils.CHANGE_LOG_MAX_EVENTS,
+		subscribers:  make(map[chan struct{}]struct{}),
+	}
+	if err := cl.load(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	// 写入过程中宕机会留下不完整的记录，截掉之后继续追加
+	if err := file.Truncate(cl.size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(cl.size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return cl, nil
+}
+
+// SetSyncInterval
+// @Description 设置合并落盘的间隔，为 0 时每条变更都落盘之后才返回
+// @Param interval 落盘间隔
+func (cl *ChangeLog) SetSyncInterval(interval time.Duration) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	cl.syncInterval = interval
+}
+
+// SetMaxEvents
+// @Description 设置最多保留的变更条数，超过时截掉较早的一半，为 0 时不截断
+// @Param maxEvents 保留条数
+func (cl *ChangeLog) SetMaxEvents(maxEvents uint64) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	cl.maxEvents = maxEvents
+}
+
+// Append
+// @Description 追加一条变更，分配序号并按落盘间隔落盘，然后通知所有订阅者
+// @Param event 变更，Seq 和 Timestamp 由日志填写
+// @Return 分配的序号、错误
+func (cl *ChangeLog) Append(event *types.ChangeEvent) (uint64, error) {
+	return cl.AppendBatch([]*types.ChangeEvent{event})
+}
+
+// AppendBatch
+// @Description 一次追加多条变更，只写一次文件、最多落盘一次，然后通知所有订阅者，用于批量删除等一次产生很多变更的操作
+// @Param events 变更，Seq 和 Timestamp 由日志填写，序号连续
+// @Return 最后一条变更的序号、错误。写入失败时所有变更都不会记录
+func (cl *ChangeLog) AppendBatch(events []*types.ChangeEvent) (uint64, error) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	if cl.closed {
+		return 0, ErrChangeLogClosed
+	}
+	if len(events) == 0 {
+		return cl.lastSeq, nil
+	}
+	now := time.Now().UnixMilli()
+	buffer := make([]byte, 0)
+	checkpoints := make([]int64, 0)
+	offset := cl.size
+	for i, event := range events {
+		event.Seq = cl.lastSeq + uint64(i) + 1
+		event.Timestamp = now
+		buf, err := proto.Marshal(event)
+		if err != nil {
+			return 0, err
+		}
+		if (event.Seq-cl.firstSeq)%utils.CHANGE_LOG_INDEX_STEP == 0 {
+			checkpoints = append(checkpoints, offset)
+		}
+		buffer = binary.BigEndian.AppendUint32(buffer, uint32(len(buf)))
+		buffer = append(buffer, buf...)
+		offset += int64(4 + len(buf))
+	}
+	if _, err := cl.file.Write(buffer); err != nil {
+		// 截掉写了一半的记录，之后的追加从完整记录的末尾继续
+		cl.file.Truncate(cl.size)
+		cl.file.Seek(cl.size, io.SeekStart)
+		return 0, err
+	}
+	// 上一次合并落盘失败时这一批同步落盘，成功后之前的记录也已经落盘
+	if cl.syncInterval == 0 || cl.syncErr != nil {
+		if err := cl.file.Sync(); err != nil {
+			return 0, err
+		}
+		cl.syncErr = nil
+	} else if cl.syncTimer == nil {
+		cl.syncTimer = time.AfterFunc(cl.syncInterval, cl.flush)
+	}
+	cl.checkpoints = append(cl.checkpoints, checkpoints...)
+	cl.size = offset
+	cl.lastSeq = events[len(events)-1].Seq
+	for ch := range cl.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	// 变更已经写入，截断失败时日志只是暂时变长，下一次超出时会重试
+	if cl.maxEvents > 0 && cl.lastSeq-cl.firstSeq+1 > cl.maxEvents {
+		return cl.lastSeq, cl.truncateBefore(cl.lastSeq - cl.maxEvents/2 + 1)
+	}
+	return cl.lastSeq, nil
+}
+
+// Truncate
+// @Description 截掉序号小于 seq 的变更，最后一条变更总会保留，之后的序号继续递增
+// @Param seq 保留的最早序号
+// @Return 错误
+func (cl *ChangeLog) Truncate(seq uint64) error {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	if cl.closed {
+		return ErrChangeLogClosed
+	}
+	return cl.truncateBefore(min(seq, cl.lastSeq))
+}
+
+// ReadSince
+// @Description 读取序号不小于 seq 的变更，从偏移索引中最近的位置开始读取
+// @Param seq 起始序号，为 0 时从最早保留的变更开始
+// @Param limit 最多读取多少条，为 0 时读取全部
+// @Return 按序号升序排列的变更，seq 之前的变更已经截掉时返回 ErrChangeLogTruncated
+func (cl *ChangeLog) ReadSince(seq, limit uint64) ([]*types.ChangeEvent, error) {
+	cl.mutex.Lock()
+	if seq > cl.lastSeq {
+		cl.mutex.Unlock()
+		return nil, nil
+	}
+	if seq > 0 && seq < cl.firstSeq {
+		cl.mutex.Unlock()
+		return nil, fmt.Errorf("%w: seq %v is before the first kept seq %v", ErrChangeLogTruncated, seq, cl.firstSeq)
+	}
+	offset := cl.offsetOf(max(seq, cl.firstSeq))
+	// 在锁内另外打开一个只读句柄，保证偏移与文件对应，截断时替换的是新文件，不影响已经打开的句柄。
+	// 读取时不阻塞写入，正在写入的不完整记录会被忽略
+	file, err := os.Open(cl.fileName)
+	cl.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	events := make([]*types.ChangeEvent, 0)
+	err = readChangeEvents(file, offset, func(event *types.ChangeEvent, _, _ int64) bool {
+		if event.Seq >= seq {
+			events = append(events, event)
+		}
+		return limit == 0 || uint64(len(events)) < limit
+	})
+	return events, err
+}
+
+// Subscribe
+// @Description 订阅新的变更，有新变更时 channel 收到通知，日志关闭时 channel 被关闭
+// @Return 通知 channel、取消订阅的函数
+func (cl *ChangeLog) Subscribe() (<-chan struct{}, func()) {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	ch := make(chan struct{}, 1)
+	if cl.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	cl.subscribers[ch] = struct{}{}
+	return ch, func() {
+		cl.mutex.Lock()
+		defer cl.mutex.Unlock()
+		if _, ok := cl.subscribers[ch]; ok {
+			delete(cl.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// LastSeq 返回最后一条变更的序号，没有变更时为 0
+func (cl *ChangeLog) LastSeq() uint64 {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	return cl.lastSeq
+}
+
+// Close
+// @Description 关闭日志文件并结束所有订阅
+// @Return 错误
+func (cl *ChangeLog) Close() error {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	if cl.closed {
+		return nil
+	}
+	cl.closed = true
+	for ch := range cl.subscribers {
+		close(ch)
+	}
+	cl.subscribers = make(map[chan struct{}]struct{})
+	if cl.syncTimer != nil {
+		cl.syncTimer.Stop()
+		cl.syncTimer = nil
+		if err := cl.file.Sync(); err != nil {
+			cl.file.Close()
+			return err
+		}
+	}
+	return cl.file.Close()
+}
+
+// 内部方法，合并落盘的定时器到期时把之前写入的变更落盘
+func (cl *ChangeLog) flush() {
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+	if cl.closed || cl.syncTimer == nil {
+		return
+	}
+	cl.syncTimer = nil
+	if err := cl.file.Sync(); err != nil {
+		cl.syncErr = err
+	}
+}
+
+// 内部方法，从头读取所有完整的记录，得到序号范围、完整记录的总长度以及偏移索引
+func (cl *ChangeLog) load() error {
+	cl.size, cl.firstSeq, cl.checkpoints = 0, 0, nil
+	err := readChangeEvents(cl.file, 0, func(event *types.ChangeEvent, start, end int64) bool {
+		if cl.firstSeq == 0 {
+			cl.firstSeq = event.Seq
+		}
+		if (event.Seq-cl.firstSeq)%utils.CHANGE_LOG_INDEX_STEP == 0 {
+			cl.checkpoints = append(cl.checkpoints, start)
+		}
+		cl.lastSeq = event.Seq
+		cl.size = end
+		return true
+	})
+	if cl.firstSeq == 0 {
+		cl.firstSeq = cl.lastSeq + 1
+	}
+	return err
+}
+
+// 内部方法，序号为 seq 的记录之前最近的一个偏移，seq 需要在 firstSeq 和 lastSeq 之间，调用方持有锁
+func (cl *ChangeLog) offsetOf(seq uint64) int64 {
+	i := (seq - cl.firstSeq) / utils.CHANGE_LOG_INDEX_STEP
+	if i >= uint64(len(cl.checkpoints)) {
+		return 0
+	}
+	return cl.checkpoints[i]
+}
+
+// 内部方法，把序号不小于 seq 的记录复制到临时文件，落盘后替换原文件并重新建立偏移索引，调用方持有锁
+func (cl *ChangeLog) truncateBefore(seq uint64) error {
+	if seq <= cl.firstSeq {
+		return nil
+	}
+	start := cl.size
+	err := readChangeEvents(cl.file, cl.offsetOf(seq), func(event *types.ChangeEvent, begin, _ int64) bool {
+		if event.Seq >= seq {
+			start = begin
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	tmpName := cl.fileName + ".tmp"
+	tmp, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, io.NewSectionReader(cl.file, start, cl.size-start)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmpName, cl.fileName); err != nil {
+		tmp.Close()
+		return err
+	}
+	cl.file.Close()
+	cl.file = tmp
+	if cl.syncTimer != nil {
+		cl.syncTimer.Stop()
+		cl.syncTimer = nil
+	}
+	lastSeq := cl.lastSeq
+	if err := cl.load(); err != nil {
+		return err
+	}
+	cl.lastSeq = lastSeq
+	_, err = cl.file.Seek(cl.size, io.SeekStart)
+	return err
+}
+
+// 内部方法，从 offset 开始依次读取完整的记录，fn 返回 false 时停止。start、end 为该记录开始和结束的位置
+func readChangeEvents(file *os.File, offset int64, fn func(event *types.ChangeEvent, start, end int64) bool) error {
+	reader := bufio.NewReader(io.NewSectionReader(file, offset, math.MaxInt64-offset))
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return nil
+		}
+		size := binary.BigEndian.Uint32(header)
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil
+		}
+		event := &types.ChangeEvent{}
+		if err := proto.Unmarshal(buf, event); err != nil {
+			return fmt.Errorf("change log %v corrupted at offset %v: %v", file.Name(), offset, err)
+		}
+		start := offset
+		offset += int64(4 + size)
+		if !fn(event, start, offset) {
+			return nil
+		}
+	}
+}
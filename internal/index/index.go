@@ -10,26 +10,40 @@ import (
 	"github.com/cylScripter/NexusFind/types"
 	"github.com/cylScripter/NexusFind/types/doc"
 	"github.com/cylScripter/NexusFind/utils"
+	"math"
+	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 )
 
 // Index 索引类
 type Index struct {
-	Name              string            `json:"name"`
-	PathName          string            `json:"pathName"`
-	Fields            map[string]uint64 `json:"fields"`
-	PrimaryKey        string            `json:"primaryKey"`
-	StartDocId        uint64            `json:"startDocId"`
-	MaxDocId          uint64            `json:"maxDocId"`
-	DelDocNum         int               `json:"delDocNum"`
-	NextSegmentSuffix uint64            `json:"nextSegmentSuffix"`
-	SegmentNames      []string          `json:"segmentNames"`
+	Name              string                           `json:"name"`
+	PathName          string                           `json:"pathName"`
+	Fields            map[string]uint64                `json:"fields"`
+	PrimaryKey        string                           `json:"primaryKey"`
+	StartDocId        uint64                           `json:"startDocId"`
+	MaxDocId          uint64                           `json:"maxDocId"`
+	DelDocNum         int                              `json:"delDocNum"`
+	NextSegmentSuffix uint64                           `json:"nextSegmentSuffix"`
+	SegmentNames      []string                         `json:"segmentNames"`
+	SuggestFields     map[string]string                `json:"suggestFields"` // 建立补全词典的字段，value 为权重字段
+	VectorOptions     map[string]segment.VectorOption  `json:"vectorOptions"` // 向量字段的配置
+	DateOptions       map[string]segment.DateOption    `json:"dateOptions"`   // 毫秒日期字段的格式和时区
+	Percolators       map[string]*types.PercolateQuery `json:"percolators"`   // 注册的查询，新增文档时判断文档命中了哪些查询
+	TTLField          string                           `json:"ttlField"`      // 判断文档过期的日期字段，为空时文档不过期
+	DefaultTTL        int64                            `json:"defaultTTL"`    // 文档的有效期，单位秒，为 0 时 TTLField 的值即过期时间
+	ExpiredDocNum     uint64                           `json:"expiredDocNum"` // 累计过期的文档数
 	tempSegmentName   map[string]int
 	segments          []*segment.Segment
 	memorySegment     *segment.Segment
 	primary           *tree.BTreeDB
 	bitmap            *roaring64.Bitmap
 	segmentMutex      *sync.Mutex
+	changes           *ChangeLog // 变更日志，Watch 从中读取变更
 	Logger            *utils.Log `json:"-"`
 }
 
@@ -43,6 +57,10 @@ func NewEmptyIndex(name, pathname string, logger *utils.Log) *Index {
 		Name:              name,
 		PathName:          pathname,
 		Fields:            make(map[string]uint64),
+		SuggestFields:     make(map[string]string),
+		VectorOptions:     make(map[string]segment.VectorOption),
+		DateOptions:       make(map[string]segment.DateOption),
+		Percolators:       make(map[string]*types.PercolateQuery),
 		PrimaryKey:        "",
 		StartDocId:        0,
 		MaxDocId:          0,
@@ -54,6 +72,7 @@ func NewEmptyIndex(name, pathname string, logger *utils.Log) *Index {
 		Logger:            logger,
 	}
 	idx.bitmap = roaring64.NewBitmap()
+	idx.openChangeLog()
 	return idx
 }
 
@@ -64,13 +83,17 @@ func NewEmptyIndex(name, pathname string, logger *utils.Log) *Index {
 // @Return 返回索引
 func NewIndexFromLocalFile(name, pathname string, logger *utils.Log) *Index {
 	idx := &Index{
-		Name:         name,
-		PathName:     pathname,
-		Fields:       make(map[string]uint64),
-		SegmentNames: make([]string, 0),
-		segments:     make([]*segment.Segment, 0),
-		segmentMutex: new(sync.Mutex),
-		Logger:       logger,
+		Name:          name,
+		PathName:      pathname,
+		Fields:        make(map[string]uint64),
+		SuggestFields: make(map[string]string),
+		VectorOptions: make(map[string]segment.VectorOption),
+		DateOptions:   make(map[string]segment.DateOption),
+		Percolators:   make(map[string]*types.PercolateQuery),
+		SegmentNames:  make([]string, 0),
+		segments:      make([]*segment.Segment, 0),
+		segmentMutex:  new(sync.Mutex),
+		Logger:        logger,
 	}
 	metaFileName := fmt.Sprintf("%v%v.meta", pathname, name)
 	buffer, err := utils.ReadFromJson(metaFileName)
@@ -81,6 +104,7 @@ func NewIndexFromLocalFile(name, pathname string, logger *utils.Log) *Index {
 	if err != nil {
 		return idx
 	}
+	idx.openChangeLog()
 	idx.tempSegmentName = make(map[string]int, 0)
 
 	for index, segmentName := range idx.SegmentNames {
@@ -104,7 +128,7 @@ func NewIndexFromLocalFile(name, pathname string, logger *utils.Log) *Index {
 					fields[fieldName] = fieldType
 				}
 			}
-			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 			idx.NextSegmentSuffix++
 		}
 	} else {
@@ -115,7 +139,7 @@ func NewIndexFromLocalFile(name, pathname string, logger *utils.Log) *Index {
 				fields[fieldName] = fieldType
 			}
 		}
-		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 		idx.NextSegmentSuffix++
 	}
 
@@ -137,6 +161,15 @@ func (idx *Index) AddField(field segment.SimpleFieldInfo) error {
 		return fmt.Errorf("[INFO] Load Index %v success", idx.Name)
 	}
 	idx.Fields[field.FieldName] = field.FieldType
+	if field.Suggest && (field.FieldType == utils.IDX_TYPE_STRING || field.FieldType == utils.IDX_TYPE_STRING_SEG) {
+		idx.SuggestFields[field.FieldName] = field.SuggestWeight
+	}
+	if field.FieldType == utils.IDX_TYPE_VECTOR {
+		idx.VectorOptions[field.FieldName] = newVectorOption(field)
+	}
+	if field.FieldType == utils.IDX_TYPE_DATETIME {
+		idx.DateOptions[field.FieldName] = segment.DateOption{Formats: field.DateFormats, TimeZone: field.TimeZone}
+	}
 	if field.FieldType == utils.IDX_TYPE_PK {
 		idx.PrimaryKey = field.FieldName
 		primaryBtree := fmt.Sprintf("%v%v_primary.pk", idx.PathName, idx.Name)
@@ -154,7 +187,7 @@ func (idx *Index) AddField(field segment.SimpleFieldInfo) error {
 					fields[fieldName] = fieldType
 				}
 			}
-			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 			idx.NextSegmentSuffix++
 		} else if idx.memorySegment.IsEmpty() {
 			// 如果内存段大小为0，则直接添加字段
@@ -178,7 +211,7 @@ func (idx *Index) AddField(field segment.SimpleFieldInfo) error {
 					fields[fieldName] = fieldType
 				}
 			}
-			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+			idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 			idx.NextSegmentSuffix++
 		}
 	}
@@ -191,6 +224,15 @@ func (idx *Index) SetFields(fields []segment.SimpleFieldInfo) {
 		if field.FieldType == utils.IDX_TYPE_PK {
 			idx.PrimaryKey = field.FieldName
 		}
+		if field.Suggest && (field.FieldType == utils.IDX_TYPE_STRING || field.FieldType == utils.IDX_TYPE_STRING_SEG) {
+			idx.SuggestFields[field.FieldName] = field.SuggestWeight
+		}
+		if field.FieldType == utils.IDX_TYPE_VECTOR {
+			idx.VectorOptions[field.FieldName] = newVectorOption(field)
+		}
+		if field.FieldType == utils.IDX_TYPE_DATETIME {
+			idx.DateOptions[field.FieldName] = segment.DateOption{Formats: field.DateFormats, TimeZone: field.TimeZone}
+		}
 	}
 	if idx.PrimaryKey != "" {
 		primaryName := fmt.Sprintf("%v%v_primary.pk", idx.PathName, idx.Name)
@@ -228,6 +270,9 @@ func (idx *Index) DeleteField(fieldName string) error {
 	idx.segmentMutex.Lock()
 	defer idx.segmentMutex.Unlock()
 	delete(idx.Fields, fieldName)
+	delete(idx.SuggestFields, fieldName)
+	delete(idx.VectorOptions, fieldName)
+	delete(idx.DateOptions, fieldName)
 
 	if idx.memorySegment == nil {
 		segmentName := fmt.Sprintf("%v%v_%v/", idx.PathName, idx.Name, idx.NextSegmentSuffix)
@@ -237,7 +282,7 @@ func (idx *Index) DeleteField(fieldName string) error {
 				fields[fn] = fieldType
 			}
 		}
-		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 		idx.NextSegmentSuffix++
 	} else if idx.memorySegment.IsEmpty() {
 		err := idx.memorySegment.DeleteField(fieldName)
@@ -259,7 +304,7 @@ func (idx *Index) DeleteField(fieldName string) error {
 				fields[fn] = fieldType
 			}
 		}
-		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 		idx.NextSegmentSuffix++
 	}
 
@@ -279,6 +324,10 @@ func (idx *Index) AddDocument(doc *doc.Document) (uint64, error) {
 	if len(idx.Fields) == 0 {
 		return 0, errors.New("index has no Field")
 	}
+	// 在分配 docId 之前按字段定义校验文档，避免写入不合法的值，也避免索引和段的 docId 错位
+	if err := idx.validateDocument(doc); err != nil {
+		return 0, err
+	}
 	// 在段内文档数到达阈值时进行持久化
 	if idx.memorySegment != nil && idx.memorySegment.MaxDocId-idx.memorySegment.StartDocId >= utils.MAX_SEGMENT_SIZE {
 		err := idx.SyncMemorySegment()
@@ -289,13 +338,7 @@ func (idx *Index) AddDocument(doc *doc.Document) (uint64, error) {
 	if idx.memorySegment == nil {
 		idx.segmentMutex.Lock()
 		segmentName := fmt.Sprintf("%v%v_%v/", idx.PathName, idx.Name, idx.NextSegmentSuffix)
-		fields := make(map[string]uint64)
-		for fieldName, fieldType := range idx.Fields {
-			if fieldType != utils.IDX_TYPE_PK && fieldType != utils.IDX_TYPE_DESC {
-				fields[fieldName] = fieldType
-			}
-		}
-		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, fields, idx.Logger)
+		idx.memorySegment = segment.NewEmptySegmentByFieldsInfo(segmentName, idx.MaxDocId, idx.segmentFields(), idx.SuggestFields, idx.VectorOptions, idx.DateOptions, idx.Logger)
 		idx.NextSegmentSuffix++
 		if err := idx.storeIndex(); err != nil {
 			idx.segmentMutex.Unlock()
@@ -308,7 +351,12 @@ func (idx *Index) AddDocument(doc *doc.Document) (uint64, error) {
 	if idx.PrimaryKey != "" {
 		idx.primary.Set(idx.PrimaryKey, PrimaryKey(doc.Id), docId)
 	}
-	return docId, idx.memorySegment.AddDocument(docId, doc)
+	doc = idx.stampTimestamp(doc)
+	if err := idx.memorySegment.AddDocument(docId, doc); err != nil {
+		return docId, err
+	}
+	idx.recordChanges(&types.ChangeEvent{Type: utils.CHANGE_ADD, PrimaryKey: doc.Id, DocId: docId, Doc: doc})
+	return docId, nil
 }
 
 // GetDocument
@@ -316,17 +364,13 @@ func (idx *Index) AddDocument(doc *doc.Document) (uint64, error) {
 // @Param docId 文档ID
 // @Return map[string]string 文档内容，key是字段名，value是内容
 func (idx *Index) GetDocument(docId uint64) (*doc.Document, bool) {
-	segIndex := uint64((docId - 1) / utils.MAX_SEGMENT_SIZE)
-	segmentName := fmt.Sprintf("%v%v_%v/", idx.PathName, idx.Name, segIndex)
-	if len(idx.segments) == 0 {
-		idx.Logger.NFLog.Warningf("segment [%v] no has exsits", segmentName)
-		return nil, false
-	}
-	seg := idx.segments[idx.tempSegmentName[segmentName]]
-	if docId >= seg.StartDocId && docId < seg.MaxDocId {
-		return seg.GetDocument(docId)
+	for _, seg := range idx.allSegments() {
+		if docId >= seg.StartDocId && docId < seg.MaxDocId {
+			return seg.GetDocument(docId)
+		}
 	}
-	return idx.memorySegment.GetDocument(docId)
+	idx.Logger.NFLog.Warningf("document [%v] no has exsits", docId)
+	return nil, false
 }
 
 func (idx *Index) IsNotDelete(primaryKey string) (uint64, bool) {
@@ -382,11 +426,107 @@ func (idx *Index) DeleteDocument(primaryKey string) error {
 		}
 		idx.bitmap.Add(docId)
 		idx.DelDocNum++
+		idx.recordChanges(&types.ChangeEvent{Type: utils.CHANGE_DELETE, PrimaryKey: primaryKey, DocId: docId})
 		return nil
 	}
 	return nil
 }
 
+// DeleteByQuery
+// @Description 把所有满足关键词查询和过滤条件的文档标记为删除，查询和过滤条件不能同时为空
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Return 删除的文档数、错误
+func (idx *Index) DeleteByQuery(query *types.TermQuery, filters []*types.SearchFilters) (uint64, error) {
+	if query.Empty() && len(filters) == 0 {
+		return 0, fmt.Errorf("delete by query needs a query or filters")
+	}
+	deleted, events := idx.deleteMatched(func(seg *segment.Segment) *roaring64.Bitmap {
+		return seg.Match(query, filters, idx.bitmap)
+	})
+	if deleted == 0 {
+		return 0, nil
+	}
+	idx.recordChanges(events...)
+	return deleted, idx.storeIndex()
+}
+
+// Drop
+// @Description 关闭索引并删除索引在磁盘上的所有文件，包括元数据、删除位图、变更日志、主键和所有段
+// @Return 任何error
+func (idx *Index) Drop() error {
+	if err := idx.Close(); err != nil {
+		return err
+	}
+	files := []string{
+		fmt.Sprintf("%v%v.meta", idx.PathName, idx.Name),
+		fmt.Sprintf("%v%v.bitmap", idx.PathName, idx.Name),
+		fmt.Sprintf("%v%v.changes", idx.PathName, idx.Name),
+		fmt.Sprintf("%v%v_primary.pk", idx.PathName, idx.Name),
+	}
+	// 段目录按后缀依次命名，内存段可能还没有落盘
+	for suffix := uint64(0); suffix < idx.NextSegmentSuffix; suffix++ {
+		files = append(files, fmt.Sprintf("%v%v_%v/", idx.PathName, idx.Name, suffix))
+	}
+	for _, file := range files {
+		if err := os.RemoveAll(file); err != nil {
+			return fmt.Errorf("remove [%v] of index [%v] failed: %v", file, idx.Name, err)
+		}
+	}
+	return nil
+}
+
+// ChangeLog 返回索引的变更日志，打开失败时为空
+func (idx *Index) ChangeLog() *ChangeLog {
+	return idx.changes
+}
+
+// 内部方法，打开索引的变更日志，失败时只记录错误，之后的写入不记录变更
+func (idx *Index) openChangeLog() {
+	changes, err := OpenChangeLog(fmt.Sprintf("%v%v.changes", idx.PathName, idx.Name))
+	if err != nil {
+		idx.Logger.NFLog.Errorf("open change log of index [%v] failed: %v", idx.Name, err)
+		return
+	}
+	idx.changes = changes
+}
+
+// 内部方法，按段把 match 命中的文档整体加入删除位图，返回删除的文档数以及对应的删除变更，变更由调用方一次写入日志
+func (idx *Index) deleteMatched(match func(seg *segment.Segment) *roaring64.Bitmap) (uint64, []*types.ChangeEvent) {
+	var deleted uint64
+	events := make([]*types.ChangeEvent, 0)
+	for _, seg := range idx.allSegments() {
+		matched := match(seg)
+		if matched.IsEmpty() {
+			continue
+		}
+		idx.bitmap.Or(matched)
+		deleted += matched.GetCardinality()
+		iterator := matched.Iterator()
+		for iterator.HasNext() {
+			docId := iterator.Next()
+			event := &types.ChangeEvent{Type: utils.CHANGE_DELETE, DocId: docId}
+			if d, ok := seg.GetDocument(docId); ok {
+				event.PrimaryKey = d.Id
+			}
+			events = append(events, event)
+		}
+	}
+	idx.DelDocNum += int(deleted)
+	return deleted, events
+}
+
+// 内部方法，把变更一次写入变更日志。调用时文档已经写入或者删除，写日志失败只影响 Watch，
+// 只记录错误，不让已经生效的写入返回失败；日志没有打开时打开失败的错误已经记录过
+func (idx *Index) recordChanges(events ...*types.ChangeEvent) {
+	if idx.changes == nil || len(events) == 0 {
+		return
+	}
+	if _, err := idx.changes.AppendBatch(events); err != nil {
+		idx.Logger.NFLog.Errorf("record %v changes in index [%v] failed: %v", len(events), idx.Name, err)
+	}
+}
+
 type PrimaryKey string
 
 func (pk PrimaryKey) ToBytes() []byte {
@@ -404,11 +544,23 @@ func (idx *Index) findPrimaryKey(primaryKey string) (uint64, bool) {
 	return docId, true
 }
 
-func (idx *Index) Search(query *types.TermQuery, filters []*types.SearchFilters) []*doc.Document {
+// Search
+// @Description 在所有段中检索，包括内存段，与 Count 使用相同的段
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param profile 不为 nil 时记录每个段的耗时，返回的文档与不记录时相同
+// @Return 命中的文档
+func (idx *Index) Search(query *types.TermQuery, filters []*types.SearchFilters, profile *types.ShardProfile) []*doc.Document {
 	docList := make([]*doc.Document, 0)
-	for _, segIndex := range idx.tempSegmentName {
-
-		temp := idx.segments[segIndex].Search(query, filters, idx.bitmap)
+	for _, seg := range idx.allSegments() {
+		var temp []*doc.Document
+		if profile != nil {
+			var segmentProfile *types.SegmentProfile
+			temp, _, segmentProfile = seg.SearchProfile(query, filters, idx.bitmap, 0, 0)
+			profile.Segments = append(profile.Segments, segmentProfile)
+		} else {
+			temp = seg.Search(query, filters, idx.bitmap)
+		}
 		if len(temp) > 0 {
 			docList = append(docList, temp...)
 		}
@@ -416,23 +568,540 @@ func (idx *Index) Search(query *types.TermQuery, filters []*types.SearchFilters)
 	return docList
 }
 
+// Count
+// @Description 统计命中的文档数，包括内存段，不读取文档内容
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param terminateAfter 统计到这么多条后提前结束，为 0 时统计全部，为 1 时只判断是否存在
+// @Return 命中的文档数，提前结束时不超过 terminateAfter
+func (idx *Index) Count(query *types.TermQuery, filters []*types.SearchFilters, terminateAfter uint64) uint64 {
+	var count uint64
+	for _, seg := range idx.allSegments() {
+		count += seg.Count(query, filters, idx.bitmap)
+		if terminateAfter > 0 && count >= terminateAfter {
+			return terminateAfter
+		}
+	}
+	return count
+}
+
+// SearchAfter
+// @Description 按 docId 升序分页检索，包括内存段，各段的 docId 区间不重叠，依次读取直到凑够一页
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档以及对应的 docId
+func (idx *Index) SearchAfter(query *types.TermQuery, filters []*types.SearchFilters, after, size uint64) ([]*doc.Document, []uint64) {
+	docList, docIds, _ := idx.searchAfter(query, filters, after, size, false)
+	return docList, docIds
+}
+
+// ScanDocuments
+// @Description 不经过倒排，按 docId 升序分页读取没有删除的文档，用于把文档复制到其他索引
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档以及对应的 docId
+func (idx *Index) ScanDocuments(after, size uint64) ([]*doc.Document, []uint64) {
+	docList := make([]*doc.Document, 0)
+	docIds := make([]uint64, 0)
+	segments := idx.allSegments()
+	sort.Slice(segments, func(i, j int) bool { return segments[i].StartDocId < segments[j].StartDocId })
+	for _, seg := range segments {
+		for docId := max(after, seg.StartDocId); docId < seg.MaxDocId; docId++ {
+			if size > 0 && uint64(len(docList)) >= size {
+				return docList, docIds
+			}
+			if idx.bitmap.Contains(docId) {
+				continue
+			}
+			if d, ok := seg.GetDocument(docId); ok {
+				docList = append(docList, d)
+				docIds = append(docIds, docId)
+			}
+		}
+	}
+	return docList, docIds
+}
+
+// SearchProfile
+// @Description 与 SearchAfter 相同，同时返回每个段中各个查询子句、过滤条件以及读取文档的耗时
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档、对应的 docId、各个段的耗时信息
+func (idx *Index) SearchProfile(query *types.TermQuery, filters []*types.SearchFilters, after, size uint64) ([]*doc.Document, []uint64, []*types.SegmentProfile) {
+	return idx.searchAfter(query, filters, after, size, true)
+}
+
+// 内部方法，依次在各个段中检索，profile 为 true 时记录每个段的耗时
+func (idx *Index) searchAfter(query *types.TermQuery, filters []*types.SearchFilters, after, size uint64, profile bool) ([]*doc.Document, []uint64, []*types.SegmentProfile) {
+	docList := make([]*doc.Document, 0)
+	docIds := make([]uint64, 0)
+	profiles := make([]*types.SegmentProfile, 0)
+	segments := idx.allSegments()
+	sort.Slice(segments, func(i, j int) bool { return segments[i].StartDocId < segments[j].StartDocId })
+	for _, seg := range segments {
+		if size > 0 && uint64(len(docList)) >= size {
+			break
+		}
+		if seg.MaxDocId <= after {
+			continue
+		}
+		limit := uint64(0)
+		if size > 0 {
+			limit = size - uint64(len(docList))
+		}
+		var docs []*doc.Document
+		var ids []uint64
+		if profile {
+			var segmentProfile *types.SegmentProfile
+			docs, ids, segmentProfile = seg.SearchProfile(query, filters, idx.bitmap, after, limit)
+			profiles = append(profiles, segmentProfile)
+		} else {
+			docs, ids = seg.SearchAfter(query, filters, idx.bitmap, after, limit)
+		}
+		docList = append(docList, docs...)
+		docIds = append(docIds, ids...)
+	}
+	return docList, docIds, profiles
+}
+
+// Explain
+// @Description 按主键找到文档，解释它为什么命中或者没有命中查询
+// @Param primaryKey 文档主键
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Return 解释结果，主键不存在时 Found 为 false
+func (idx *Index) Explain(primaryKey string, query *types.TermQuery, filters []*types.SearchFilters) *types.Explanation {
+	explanation := &types.Explanation{}
+	docId, ok := idx.findPrimaryKey(primaryKey)
+	if !ok {
+		return explanation
+	}
+	explanation.Found = true
+	explanation.DocId = docId
+	explanation.Deleted = idx.bitmap != nil && idx.bitmap.Contains(docId)
+	for _, seg := range idx.allSegments() {
+		if docId >= seg.StartDocId && docId < seg.MaxDocId {
+			seg.Explain(query, filters, docId, explanation)
+			break
+		}
+	}
+	if explanation.Deleted {
+		explanation.Matched = false
+		explanation.Score = 0
+	}
+	return explanation
+}
+
+// Suggest
+// @Description 前缀补全，合并所有段（包括内存段）的结果并去重
+// @Param fieldName 建立了补全词典的字段
+// @Param prefix 前缀
+// @Param size 最多返回多少条
+// @Return 按权重降序排列的补全结果
+func (idx *Index) Suggest(fieldName, prefix string, size uint64) ([]*types.Suggestion, error) {
+	if _, ok := idx.SuggestFields[fieldName]; !ok {
+		return nil, fmt.Errorf("field [%v] has no suggester", fieldName)
+	}
+	lists := make([][]*types.Suggestion, 0, len(idx.segments)+1)
+	for _, seg := range idx.allSegments() {
+		if temp, ok := seg.Suggest(fieldName, prefix, size); ok {
+			lists = append(lists, temp)
+		}
+	}
+	return types.MergeSuggestions(size, lists...), nil
+}
+
+// SpellCheck
+// @Description 纠错，query 不为空时对查询中的每个 Keyword 纠错，否则按 fieldName 的分词方式切分 text 后逐词纠错
+// @Param fieldName text 所属的字段
+// @Param text 待纠错的文本
+// @Param query 待纠错的查询
+// @Param maxEdits 最大编辑距离
+// @Param size 每个词最多返回多少个候选词
+// @Return 按词的顺序排列的纠错结果
+func (idx *Index) SpellCheck(fieldName, text string, query *types.TermQuery, maxEdits int, size uint64) ([]*types.TermCorrection, error) {
+	words := make([]*types.Keyword, 0)
+	if query != nil && !query.Empty() {
+		words = query.Keywords()
+	} else {
+		fieldType, ok := idx.Fields[fieldName]
+		if !ok {
+			return nil, fmt.Errorf("field [%v] not found", fieldName)
+		}
+		if fieldType == utils.IDX_TYPE_STRING_SEG {
+			segmenter := utils.GetGseSegmenter()
+			for _, token := range segmenter.Cut(text, true) {
+				words = append(words, &types.Keyword{Field: fieldName, Word: token})
+			}
+		} else {
+			words = append(words, &types.Keyword{Field: fieldName, Word: text})
+		}
+	}
+	corrections := make([]*types.TermCorrection, 0, len(words))
+	segments := idx.allSegments()
+	for _, kw := range words {
+		correction := &types.TermCorrection{Field: kw.Field, Word: kw.Word, Candidates: make([]*types.Candidate, 0)}
+		corrections = append(corrections, correction)
+		if isBlankTerm(kw.Word) {
+			continue
+		}
+		docFreq := make(map[string]uint64)
+		for _, seg := range segments {
+			terms, ok := seg.FuzzyTerms(kw.Field, kw.Word, maxEdits)
+			if !ok {
+				continue
+			}
+			for term, df := range terms {
+				docFreq[term] += df
+			}
+		}
+		for term, df := range docFreq {
+			if term == kw.Word {
+				correction.DocFreq = df
+				continue
+			}
+			if isBlankTerm(term) {
+				continue
+			}
+			distance := uint64(utils.EditDistance(kw.Word, term, maxEdits))
+			correction.Candidates = append(correction.Candidates, &types.Candidate{
+				Word:     term,
+				Distance: distance,
+				DocFreq:  df,
+				Score:    types.CandidateScore(distance, df),
+			})
+		}
+		correction.Candidates = types.SortCandidates(correction.Candidates, size)
+	}
+	return corrections, nil
+}
+
+// MoreLikeThis
+// @Description 查找与源文档相似的文档：按 TF-IDF 选出源文档中最重要的词，构造带权重的 Should 查询，结果中排除源文档
+// @Param mlt 源文档及选词参数
+// @Param filters 过滤条件
+// @Param profile 不为 nil 时记录每个段打分的耗时
+// @Return 按得分降序排列的文档及其打分信息、截断到 Size 之前命中的文档总数
+func (idx *Index) MoreLikeThis(mlt *types.MoreLikeThis, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, uint64, error) {
+	exclude := roaring64.NewBitmap()
+	if idx.bitmap != nil {
+		exclude.Or(idx.bitmap)
+	}
+	source := mlt.Like
+	primaryKey := mlt.DocId
+	if primaryKey == "" && source != nil {
+		primaryKey = source.Id
+	}
+	if primaryKey != "" {
+		if docId, ok := idx.IsNotDelete(primaryKey); ok {
+			exclude.Add(docId)
+			if source == nil {
+				source, _ = idx.GetDocument(docId)
+			}
+		}
+	}
+	if source == nil {
+		return nil, nil, 0, fmt.Errorf("document [%v] no has exists", mlt.DocId)
+	}
+	fields := mlt.Fields
+	if len(fields) == 0 {
+		for fieldName, fieldType := range idx.Fields {
+			if fieldType == utils.IDX_TYPE_STRING || fieldType == utils.IDX_TYPE_STRING_SEG {
+				fields = append(fields, fieldName)
+			}
+		}
+	}
+	for _, fieldName := range mlt.Fields {
+		if err := idx.validateTextField(fieldName); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	maxTerms := mlt.MaxQueryTerms
+	if maxTerms == 0 {
+		maxTerms = 25
+	}
+	size := mlt.Size
+	if size == 0 {
+		size = 10
+	}
+	terms := idx.likeTerms(source, fields, maxTerms)
+	if len(terms) == 0 {
+		return []*doc.Document{}, []*types.Hit{}, 0, nil
+	}
+	query := &types.TermQuery{Should: terms}
+	hits := make([]*types.Hit, 0)
+	for _, seg := range idx.allSegments() {
+		start := time.Now()
+		temp := seg.SearchScored(query, filters, exclude)
+		profileSegment(profile, seg, "more_like_this", start, uint64(len(temp)))
+		hits = append(hits, temp...)
+	}
+	types.SortHits(hits)
+	total := uint64(len(hits))
+	if total > size {
+		hits = hits[:size]
+	}
+	docList, result := idx.hitDocuments(hits)
+	return docList, result, total, nil
+}
+
+// 内部方法，按 TF-IDF 从源文档的 Keywords 和 fields 的内容中选出最多 maxTerms 个词，权重归一化到 (0, 1]
+func (idx *Index) likeTerms(source *doc.Document, fields []string, maxTerms uint64) []*types.TermQuery {
+	type likeTerm struct {
+		field string
+		word  string
+		tf    float64
+		score float64
+	}
+	candidates := make(map[string]*likeTerm)
+	addTerm := func(field, word string, tf float64) {
+		if isBlankTerm(word) {
+			return
+		}
+		key := field + "\001" + word
+		if old, ok := candidates[key]; !ok || tf > old.tf {
+			candidates[key] = &likeTerm{field: field, word: word, tf: tf}
+		}
+	}
+	for _, field := range fields {
+		values := source.FieldValues(field)
+		if len(values) == 0 {
+			continue
+		}
+		var tokens []string
+		for _, content := range values {
+			if idx.Fields[field] == utils.IDX_TYPE_STRING_SEG {
+				segmenter := utils.GetGseSegmenter()
+				tokens = append(tokens, segmenter.CutSearch(content, false)...)
+			} else {
+				tokens = append(tokens, content)
+			}
+		}
+		for _, kw := range doc.TF(tokens) {
+			addTerm(field, kw.Word, float64(kw.WordTF))
+		}
+		for _, kw := range source.Keywords {
+			addTerm(field, kw.Word, float64(kw.WordTF))
+		}
+	}
+	segments := idx.allSegments()
+	total := float64(idx.MaxDocId-idx.StartDocId) - float64(idx.DelDocNum)
+	selected := make([]*likeTerm, 0, len(candidates))
+	for _, term := range candidates {
+		var df uint64
+		for _, seg := range segments {
+			df += seg.DocFreq(term.field, term.word)
+		}
+		if df == 0 {
+			continue
+		}
+		term.score = term.tf * (math.Log((total+1)/float64(df+1)) + 1)
+		selected = append(selected, term)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].score != selected[j].score {
+			return selected[i].score > selected[j].score
+		}
+		return selected[i].word < selected[j].word
+	})
+	if uint64(len(selected)) > maxTerms {
+		selected = selected[:maxTerms]
+	}
+	queries := make([]*types.TermQuery, 0, len(selected))
+	for _, term := range selected {
+		query := types.NewTermQuery(term.field, term.word)
+		query.Boost = float32(term.score / selected[0].score)
+		queries = append(queries, query)
+	}
+	return queries
+}
+
+// Knn
+// @Description 向量检索，合并所有段的结果后取得分最高的 K 篇文档
+// @Param knn kNN 查询
+// @Param filters 过滤条件
+// @Param profile 不为 nil 时记录每个段向量检索的耗时
+// @Return 按得分降序排列的文档及其打分信息
+func (idx *Index) Knn(knn *types.KnnQuery, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, error) {
+	hits, err := idx.knnHits(knn, filters, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	docList, result := idx.hitDocuments(hits)
+	return docList, result, nil
+}
+
+// Hybrid
+// @Description 混合检索，分别执行关键词查询和向量查询，再按 fusion 融合两路结果
+// @Param query 关键词查询
+// @Param knn kNN 查询
+// @Param fusion 融合参数
+// @Param filters 过滤条件，对两路查询同时生效
+// @Param profile 不为 nil 时分别记录每个段两路查询的耗时
+// @Return 按融合得分降序排列的文档及其打分信息、两路查询命中的不同文档总数
+func (idx *Index) Hybrid(query *types.TermQuery, knn *types.KnnQuery, fusion *types.Fusion, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*doc.Document, []*types.Hit, uint64, error) {
+	fusion = fusion.Normalize()
+	vector, err := idx.knnHits(knn, filters, profile)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	lexical := make([]*types.Hit, 0)
+	for _, seg := range idx.allSegments() {
+		start := time.Now()
+		temp := seg.SearchScored(query, filters, idx.bitmap)
+		profileSegment(profile, seg, "lexical", start, uint64(len(temp)))
+		lexical = append(lexical, temp...)
+	}
+	matched := roaring64.New()
+	for _, hit := range append(lexical, vector...) {
+		matched.Add(hit.DocId)
+	}
+	docList, result := idx.hitDocuments(types.FuseHits(fusion, lexical, vector))
+	return docList, result, matched.GetCardinality(), nil
+}
+
+// 内部方法，执行 kNN 查询并返回得分最高的 K 条结果
+func (idx *Index) knnHits(knn *types.KnnQuery, filters []*types.SearchFilters, profile *types.ShardProfile) ([]*types.Hit, error) {
+	option, ok := idx.VectorOptions[knn.Field]
+	if !ok {
+		return nil, fmt.Errorf("field [%v] is not a vector field", knn.Field)
+	}
+	if len(knn.Vector) == 0 || (option.Dims > 0 && uint64(len(knn.Vector)) != option.Dims) {
+		return nil, fmt.Errorf("vector field [%v] dims mismatch, want %v got %v", knn.Field, option.Dims, len(knn.Vector))
+	}
+	query := &types.KnnQuery{Field: knn.Field, Vector: knn.Vector, K: knn.K, NumCandidates: knn.NumCandidates, Metric: knn.Metric}
+	if query.K == 0 {
+		query.K = 10
+	}
+	if query.NumCandidates == 0 {
+		query.NumCandidates = max(query.K, 100)
+	}
+	query.NumCandidates = max(query.NumCandidates, query.K)
+	hits := make([]*types.Hit, 0)
+	for _, seg := range idx.allSegments() {
+		start := time.Now()
+		if temp, ok := seg.SearchKnn(query, filters, idx.bitmap); ok {
+			profileSegment(profile, seg, "knn", start, uint64(len(temp)))
+			hits = append(hits, temp...)
+		}
+	}
+	types.SortHits(hits)
+	if uint64(len(hits)) > query.K {
+		hits = hits[:query.K]
+	}
+	return hits, nil
+}
+
+// 内部方法，profile 不为 nil 时把段内一路打分查询的耗时和命中数记到该段的耗时信息中，同一个段的多路查询合并到一起
+func profileSegment(profile *types.ShardProfile, seg *segment.Segment, name string, start time.Time, hits uint64) {
+	if profile == nil {
+		return
+	}
+	elapsed := uint64(time.Since(start).Nanoseconds())
+	var segmentProfile *types.SegmentProfile
+	for _, sp := range profile.Segments {
+		if sp.Segment == seg.SegmentName {
+			segmentProfile = sp
+		}
+	}
+	if segmentProfile == nil {
+		segmentProfile = &types.SegmentProfile{Segment: seg.SegmentName}
+		profile.Segments = append(profile.Segments, segmentProfile)
+	}
+	segmentProfile.TimeNanos += elapsed
+	segmentProfile.Entries = append(segmentProfile.Entries, &types.ProfileEntry{Name: name, Type: "scored", TimeNanos: elapsed, Hits: hits})
+}
+
+// 内部方法，取出命中文档的内容，已经取不到的文档连同其打分信息一起丢弃
+func (idx *Index) hitDocuments(hits []*types.Hit) ([]*doc.Document, []*types.Hit) {
+	docList := make([]*doc.Document, 0, len(hits))
+	result := make([]*types.Hit, 0, len(hits))
+	for _, hit := range hits {
+		if document, ok := idx.GetDocument(hit.DocId); ok {
+			docList = append(docList, document)
+			result = append(result, hit)
+		}
+	}
+	return docList, result
+}
+
+// 内部方法，从字段描述信息中取出向量字段的配置，相似度默认为余弦
+func newVectorOption(field segment.SimpleFieldInfo) segment.VectorOption {
+	option := segment.VectorOption{Dims: field.Dims, Metric: field.Metric}
+	if option.Metric == 0 {
+		option.Metric = utils.KNN_COSINE
+	}
+	return option
+}
+
+// 内部方法，判断词项是否只由空白和标点组成
+func isBlankTerm(term string) bool {
+	return strings.TrimFunc(term, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsPunct(r) }) == ""
+}
+
+// 内部方法，段中需要建立倒排的字段，主键和只存储的字段不进入段
+func (idx *Index) segmentFields() map[string]uint64 {
+	fields := make(map[string]uint64)
+	for fieldName, fieldType := range idx.Fields {
+		if fieldType != utils.IDX_TYPE_PK && fieldType != utils.IDX_TYPE_DESC {
+			fields[fieldName] = fieldType
+		}
+	}
+	return fields
+}
+
+// 内部方法，返回所有需要参与查询的段，包括还没有序列化的内存段
+func (idx *Index) allSegments() []*segment.Segment {
+	segments := make([]*segment.Segment, 0, len(idx.segments)+1)
+	segments = append(segments, idx.segments...)
+	if idx.memorySegment != nil {
+		if _, ok := idx.tempSegmentName[idx.memorySegment.SegmentName]; !ok {
+			segments = append(segments, idx.memorySegment)
+		}
+	}
+	return segments
+}
+
 func (idx *Index) Close() error {
 	idx.segmentMutex.Lock()
 	defer idx.segmentMutex.Unlock()
-	// Close memory segment
-	idx.memorySegment = nil
+	// 内存段没有落盘的内容会丢弃，调用方需要先 SyncMemorySegment
+	if idx.memorySegment != nil {
+		if err := idx.memorySegment.Close(); err != nil {
+			return fmt.Errorf("failed to close memory segment: %v", err)
+		}
+	}
 	// Close segments
 	for _, seg := range idx.segments {
+		// 从本地文件恢复时内存段也在 segments 中，上面已经关闭过
+		if seg == idx.memorySegment {
+			continue
+		}
 		if err := seg.Close(); err != nil {
 			return fmt.Errorf("failed to close segment: %v", err)
 		}
 	}
+	idx.memorySegment = nil
 	idx.segments = nil
 
 	idx.tempSegmentName = nil
 
 	idx.bitmap = nil
 
+	if idx.primary != nil {
+		if err := idx.primary.Close(); err != nil {
+			return fmt.Errorf("failed to close primary key: %v", err)
+		}
+	}
 	idx.primary = nil
+	if idx.changes != nil {
+		if err := idx.changes.Close(); err != nil {
+			return fmt.Errorf("failed to close change log: %v", err)
+		}
+	}
 	return nil
 }
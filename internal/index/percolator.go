@@ -0,0 +1,73 @@
+package index
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"google.golang.org/protobuf/proto"
+	"sort"
+)
+
+// RegisterPercolator
+// @Description 注册查询，Id 相同时覆盖原来的查询，注册的查询随索引元数据持久化
+// @Param query 查询及过滤条件
+// @Return 查询不合法时返回错误
+func (idx *Index) RegisterPercolator(query *types.PercolateQuery) error {
+	if query.GetId() == "" {
+		return fmt.Errorf("percolator query needs an id")
+	}
+	if query.Query.Empty() && len(query.Filter) == 0 {
+		return fmt.Errorf("percolator query [%v] has neither query nor filters", query.Id)
+	}
+	// 注册的查询在每次写入时都会执行，字段不合法的查询在注册时就拒绝
+	if err := idx.ValidateQuery(query.Query); err != nil {
+		return err
+	}
+	if err := idx.ValidateFilters(query.Filter); err != nil {
+		return err
+	}
+	if idx.Percolators == nil {
+		idx.Percolators = make(map[string]*types.PercolateQuery)
+	}
+	idx.Percolators[query.Id] = proto.Clone(query).(*types.PercolateQuery)
+	return idx.storeIndex()
+}
+
+// DeletePercolator
+// @Description 删除注册的查询
+// @Param id 查询的 Id
+// @Return 查询不存在时返回错误
+func (idx *Index) DeletePercolator(id string) error {
+	if _, ok := idx.Percolators[id]; !ok {
+		return fmt.Errorf("percolator query [%v] not found", id)
+	}
+	delete(idx.Percolators, id)
+	return idx.storeIndex()
+}
+
+// Percolate
+// @Description 判断文档命中了哪些注册的查询。把文档写入一个只有这一篇文档的内存段，
+// 分析和建倒排的方式与正常写入完全一致，再在这个段上逐个执行注册的查询
+// @Param d 文档
+// @Return 命中的查询的 Id，按 Id 排序
+func (idx *Index) Percolate(d *doc.Document) ([]string, error) {
+	if len(idx.Percolators) == 0 {
+		return nil, nil
+	}
+	if err := idx.validateDocument(d); err != nil {
+		return nil, err
+	}
+	seg := segment.NewEmptySegmentByFieldsInfo(fmt.Sprintf("%v%v_percolate/", idx.PathName, idx.Name), 0, idx.segmentFields(), nil, idx.VectorOptions, idx.DateOptions, idx.Logger)
+	if err := seg.AddDocument(0, d); err != nil {
+		return nil, err
+	}
+	matches := make([]string, 0)
+	for id, query := range idx.Percolators {
+		if seg.Count(query.Query, query.Filter, nil) > 0 {
+			matches = append(matches, id)
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
@@ -0,0 +1,241 @@
+/*****************************************************************************
+ *  file name : schema.go
+ *  author : cyl
+ *  email  : 2842871262@qq.com
+ *  file description : 按索引的字段定义校验文档和过滤条件
+ *
+******************************************************************************/
+
+package index
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+	"strings"
+)
+
+// validateDocument
+// @Description 按字段定义校验文档中每个字段的每个值，不在字段定义中的内容只存储不校验
+// @Param d 待校验的文档
+// @Return 所有不合法的值汇总成一个错误
+func (idx *Index) validateDocument(d *doc.Document) error {
+	problems := make([]string, 0)
+	for fieldName, fieldType := range idx.Fields {
+		for _, value := range d.TypedValues(fieldName) {
+			if err := idx.validateValue(fieldName, fieldType, value); err != nil {
+				problems = append(problems, fmt.Sprintf("field[%v] %v", fieldName, err))
+			}
+		}
+	}
+	for fieldName, vector := range d.GetVectors() {
+		option, ok := idx.VectorOptions[fieldName]
+		if !ok {
+			continue
+		}
+		if len(vector.GetValues()) == 0 || (option.Dims > 0 && uint64(len(vector.GetValues())) != option.Dims) {
+			problems = append(problems, fmt.Sprintf("vector field [%v] dims mismatch, want %v got %v", fieldName, option.Dims, len(vector.GetValues())))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("document [%v] has invalid values: %v", d.Id, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateValue 校验单个值是否符合字段类型，字符串形式的值按字段类型解析
+func (idx *Index) validateValue(fieldName string, fieldType uint64, value *doc.Value) error {
+	switch fieldType {
+	case utils.IDX_TYPE_STRING, utils.IDX_TYPE_STRING_SEG, utils.IDX_TYPE_PK:
+		if _, ok := value.GetKind().(*doc.Value_Str); !ok {
+			return fmt.Errorf("want string, got %v", kindName(value))
+		}
+	case utils.IDX_TYPE_NUMBER:
+		switch value.GetKind().(type) {
+		case *doc.Value_Int:
+		case *doc.Value_Str:
+			_, err := segment.ParseNumberValue(fieldType, value.GetStr())
+			if err != nil {
+				return fmt.Errorf("value %q is not an integer", value.GetStr())
+			}
+		default:
+			return fmt.Errorf("want int, got %v", kindName(value))
+		}
+	case utils.IDX_TYPE_FLOAT, utils.IDX_TYPE_DOUBLE:
+		switch value.GetKind().(type) {
+		case *doc.Value_Int, *doc.Value_Float:
+			_, err := segment.ParseNumberValue(fieldType, value.Text())
+			if err != nil {
+				return fmt.Errorf("value %v is not a finite float", value.Text())
+			}
+		case *doc.Value_Str:
+			_, err := segment.ParseNumberValue(fieldType, value.GetStr())
+			if err != nil {
+				return fmt.Errorf("value %q is not a float", value.GetStr())
+			}
+		default:
+			return fmt.Errorf("want float, got %v", kindName(value))
+		}
+	case utils.IDX_TYPE_DATE:
+		switch value.GetKind().(type) {
+		case *doc.Value_Timestamp:
+		case *doc.Value_Str:
+			_, err := segment.ParseNumberValue(fieldType, value.GetStr())
+			if err != nil {
+				return fmt.Errorf("value %q is not a date", value.GetStr())
+			}
+		default:
+			return fmt.Errorf("want timestamp, got %v", kindName(value))
+		}
+	case utils.IDX_TYPE_DATETIME:
+		switch value.GetKind().(type) {
+		case *doc.Value_Timestamp, *doc.Value_Int, *doc.Value_Str:
+			_, err := segment.ParseDateValue(idx.DateOptions[fieldName], value.Text())
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("want timestamp, got %v", kindName(value))
+		}
+	case utils.IDX_TYPE_GEO:
+		if _, ok := value.GetKind().(*doc.Value_Str); !ok {
+			return fmt.Errorf("want \"lat,lon\" string, got %v", kindName(value))
+		}
+		_, err := segment.ParseNumberValue(fieldType, value.GetStr())
+		if err != nil {
+			return fmt.Errorf("value %q is not a geo point: %v", value.GetStr(), err)
+		}
+	case utils.IDX_TYPE_VECTOR:
+		return fmt.Errorf("vector values must be set in Vectors")
+	}
+	return nil
+}
+
+// ValidateQuery
+// @Description 校验关键词查询引用的字段存在并且是文本字段，包括 Must、Should 中嵌套的查询
+// @Param query 关键词查询，为空时不校验
+// @Return 第一个不合法的字段对应的错误
+func (idx *Index) ValidateQuery(query *types.TermQuery) error {
+	if query.Empty() {
+		return nil
+	}
+	if query.Keyword != nil {
+		if err := idx.validateTextField(query.Keyword.Field); err != nil {
+			return err
+		}
+	}
+	for _, q := range query.Must {
+		if err := idx.ValidateQuery(q); err != nil {
+			return err
+		}
+	}
+	for _, q := range query.Should {
+		if err := idx.ValidateQuery(q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTextField 校验字段存在并且有文本倒排，关键词查询只能作用于这类字段
+func (idx *Index) validateTextField(fieldName string) error {
+	fieldType, ok := idx.Fields[fieldName]
+	if !ok {
+		return fmt.Errorf("query field [%v] not found in index [%v]", fieldName, idx.Name)
+	}
+	if fieldType != utils.IDX_TYPE_STRING && fieldType != utils.IDX_TYPE_STRING_SEG {
+		return fmt.Errorf("query field [%v] is type %v, keyword queries need a string field", fieldName, fieldType)
+	}
+	return nil
+}
+
+// ValidateFilters
+// @Description 按字段定义校验过滤条件，包括 FILT_AND、FILT_OR、FILT_NOT 中嵌套的条件
+// @Param filters 过滤条件
+// @Return 第一个不合法的条件对应的错误
+func (idx *Index) ValidateFilters(filters []*types.SearchFilters) error {
+	for _, filter := range filters {
+		if err := idx.validateFilter(filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFilter 校验单个过滤条件引用的字段存在并且字段类型支持该条件
+func (idx *Index) validateFilter(filter *types.SearchFilters) error {
+	if filter == nil {
+		return fmt.Errorf("filter is nil")
+	}
+	if filter.IsBoolFilter() {
+		return idx.ValidateFilters(filter.Filters)
+	}
+	fieldType, ok := idx.Fields[filter.FieldName]
+	if !ok {
+		return fmt.Errorf("filter field [%v] not found in index [%v]", filter.FieldName, idx.Name)
+	}
+	switch filter.Type {
+	case utils.FILT_EQ, utils.FILT_OVER, utils.FILT_LESS, utils.FILT_RANGE, utils.FILT_IN:
+		switch fieldType {
+		case utils.IDX_TYPE_NUMBER, utils.IDX_TYPE_FLOAT, utils.IDX_TYPE_DOUBLE, utils.IDX_TYPE_DATE, utils.IDX_TYPE_DATETIME:
+		default:
+			return fmt.Errorf("filter type %v needs a numeric field, field [%v] is type %v", filter.Type, filter.FieldName, fieldType)
+		}
+		if len(filter.RangeFloat) > 0 && fieldType != utils.IDX_TYPE_DOUBLE {
+			return fmt.Errorf("RangeFloat needs a double field, field [%v] is type %v", filter.FieldName, fieldType)
+		}
+	case utils.FILT_GEO_DISTANCE, utils.FILT_GEO_BBOX:
+		if fieldType != utils.IDX_TYPE_GEO {
+			return fmt.Errorf("filter type %v needs a geo field, field [%v] is type %v", filter.Type, filter.FieldName, fieldType)
+		}
+		if filter.Type == utils.FILT_GEO_DISTANCE && filter.GeoDistance.GetCenter() == nil {
+			return fmt.Errorf("geo distance filter on field [%v] needs a center", filter.FieldName)
+		}
+		if filter.Type == utils.FILT_GEO_BBOX && (filter.GeoBoundingBox.GetTopLeft() == nil || filter.GeoBoundingBox.GetBottomRight() == nil) {
+			return fmt.Errorf("geo bounding box filter on field [%v] needs both corners", filter.FieldName)
+		}
+	case utils.FILT_TERMS, utils.FILT_EXISTS, utils.FILT_MISSING:
+		if fieldType == utils.IDX_TYPE_PK || fieldType == utils.IDX_TYPE_VECTOR {
+			return fmt.Errorf("filter type %v is not supported on field [%v] of type %v", filter.Type, filter.FieldName, fieldType)
+		}
+	default:
+		return fmt.Errorf("unknown filter type %v", filter.Type)
+	}
+	return nil
+}
+
+// ValidateCollapse
+// @Description 校验折叠字段存在并且是 IDX_TYPE_STRING 类型
+// @Param collapse 折叠条件
+// @Return 折叠字段不合法时返回错误
+func (idx *Index) ValidateCollapse(collapse *types.Collapse) error {
+	fieldType, ok := idx.Fields[collapse.GetField()]
+	if !ok {
+		return fmt.Errorf("collapse field [%v] not found in index [%v]", collapse.GetField(), idx.Name)
+	}
+	if fieldType != utils.IDX_TYPE_STRING {
+		return fmt.Errorf("collapse needs a string field, field [%v] is type %v", collapse.GetField(), fieldType)
+	}
+	return nil
+}
+
+// kindName 返回值的类型名，用于错误信息
+func kindName(value *doc.Value) string {
+	switch value.GetKind().(type) {
+	case *doc.Value_Int:
+		return "int"
+	case *doc.Value_Float:
+		return "float"
+	case *doc.Value_Bool:
+		return "bool"
+	case *doc.Value_Timestamp:
+		return "timestamp"
+	case *doc.Value_Str:
+		return "string"
+	case *doc.Value_Bytes:
+		return "bytes"
+	}
+	return "empty"
+}
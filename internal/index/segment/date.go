@@ -0,0 +1,97 @@
+package segment
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/utils"
+	"math"
+	"strings"
+	"time"
+)
+
+// DateOption 毫秒精度日期字段的配置
+type DateOption struct {
+	Formats  []string `json:"formats"`  // 接受的格式，rfc3339、epoch_millis、epoch_second 或者 Go 的时间布局，为空时使用默认格式
+	TimeZone string   `json:"timeZone"` // 没有时区信息的值按该时区解析，为空时使用本地时区
+}
+
+// location 返回字段的时区，时区名无法识别时退回本地时区
+func (o DateOption) location() *time.Location {
+	loc, err := utils.LoadLocation(o.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// ParseDateValue
+// @Description 按字段声明的格式和时区解析日期，带时区的 RFC3339 值没有歧义，总是接受
+// @Param option 日期字段的配置
+// @Param contentStr 字段内容
+// @Return 毫秒时间戳、错误
+func ParseDateValue(option DateOption, contentStr string) (int64, error) {
+	millis, err := utils.ParseDate(contentStr, option.Formats, option.location())
+	if err != nil {
+		if t, e := time.Parse(time.RFC3339Nano, contentStr); e == nil {
+			return t.UnixMilli(), nil
+		}
+		return 0, err
+	}
+	return millis, nil
+}
+
+// bounds 把日期过滤条件转换成毫秒区间，都是闭区间。
+// StartDate/EndDate 为日期表达式，为空时使用 Start/End 的毫秒时间戳。
+// FILT_EQ 没有指定取整单位时按天取整，即匹配字段时区内的那一个自然日
+func (o DateOption) bounds(filter *types.SearchFilters, now time.Time) (int64, int64, error) {
+	loc := o.location()
+	parse := func(expr string, fallback int64, roundUp bool) (int64, bool, error) {
+		if expr == "" {
+			return fallback, false, nil
+		}
+		return utils.ParseDateMath(expr, now, o.Formats, loc, roundUp)
+	}
+	switch filter.Type {
+	case utils.FILT_EQ:
+		if filter.StartDate == "" {
+			start, end := utils.DayBounds(time.UnixMilli(filter.Start).Unix(), loc)
+			return start * 1000, end*1000 + 999, nil
+		}
+		expr := filter.StartDate
+		_, rounded, err := parse(expr, 0, false)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !rounded {
+			expr += dateMathSeparator(expr) + "/d"
+		}
+		start, _, _ := parse(expr, 0, false)
+		end, _, err := parse(expr, 0, true)
+		return start, end, err
+	case utils.FILT_RANGE:
+		start, _, err := parse(filter.StartDate, filter.Start, false)
+		if err != nil {
+			return 0, 0, err
+		}
+		end, _, err := parse(filter.EndDate, filter.End, true)
+		return start, end, err
+	case utils.FILT_LESS:
+		end, _, err := parse(filter.StartDate, filter.Start, true)
+		return math.MinInt64, end, err
+	case utils.FILT_OVER:
+		start, _, err := parse(filter.StartDate, filter.Start, false)
+		return start, math.MaxInt64, err
+	}
+	return 0, 0, fmt.Errorf("unsupported date filter type %v", filter.Type)
+}
+
+// 内部方法，日期锚点之后追加运算前需要 "||" 分隔，now 之后不需要
+func dateMathSeparator(expr string) string {
+	if strings.HasPrefix(expr, "now") {
+		return ""
+	}
+	if strings.Contains(expr, "||") {
+		return ""
+	}
+	return "||"
+}
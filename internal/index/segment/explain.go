@@ -0,0 +1,104 @@
+package segment
+
+import "github.com/cylScripter/NexusFind/types"
+
+// Explain
+// @Description 解释段内某个文档为什么命中或者没有命中查询，逐个子句、逐个过滤条件判断
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param docId 段内的文档
+// @Param explanation 填充 Segment、Query、Filters、Matched、Score，删除状态由调用方判断
+func (seg *Segment) Explain(query *types.TermQuery, filters []*types.SearchFilters, docId uint64, explanation *types.Explanation) {
+	explanation.Segment = seg.SegmentName
+	matched := false
+	if !query.Empty() {
+		explanation.Query = seg.explainQuery(query, docId, 1)
+		matched = explanation.Query.Matched
+	}
+	filterMatched := true
+	for _, filter := range filters {
+		node := seg.explainFilter(filter, docId)
+		filterMatched = filterMatched && node.Matched
+		explanation.Filters = append(explanation.Filters, node)
+	}
+	// 与 match 一致：没有关键词查询时只按过滤条件，两者都没有时不命中
+	if query.Empty() {
+		matched = len(filters) > 0 && filterMatched
+	} else {
+		matched = matched && filterMatched
+	}
+	explanation.Matched = matched
+	if matched && explanation.Query != nil {
+		explanation.Score = explanation.Query.Score
+	}
+}
+
+// 内部方法，判断文档是否满足关键词子句。是否命中与 search 一致：有 Keyword 时只看 Keyword，
+// 否则有 Must 时所有 Must 都要命中，没有 Must 时命中任意一个 Should 即可；
+// 得分与 score 一致，Keyword、所有 Must 和所有 Should 的得分都会累加
+func (seg *Segment) explainQuery(query *types.TermQuery, docId uint64, factor float64) *types.ClauseExplanation {
+	boost := factor
+	if query.Boost != 0 {
+		boost *= float64(query.Boost)
+	}
+	must := seg.explainClauses("must", query.Must, docId, boost)
+	should := seg.explainClauses("should", query.Should, docId, boost)
+	node := &types.ClauseExplanation{}
+	switch {
+	case query.Keyword != nil:
+		node.Clause = query.Keyword.Field + ":" + query.Keyword.Word
+		if field, ok := seg.fields[query.Keyword.Field]; ok {
+			if bitmap, exits := field.Query(query.Keyword.Word); exits && bitmap != nil && bitmap.Contains(docId) {
+				node.Matched = true
+				node.Score = boost
+			}
+		}
+	case must != nil && should == nil:
+		return must
+	case must == nil && should != nil:
+		return should
+	case must != nil:
+		node.Clause = "bool"
+		node.Matched = must.Matched
+	default:
+		return node
+	}
+	for _, clauses := range []*types.ClauseExplanation{must, should} {
+		if clauses != nil {
+			node.Details = append(node.Details, clauses)
+			node.Score += clauses.Score
+		}
+	}
+	return node
+}
+
+// 内部方法，解释一组 must 或 should 子句，得分为各子句得分之和
+func (seg *Segment) explainClauses(clause string, children []*types.TermQuery, docId uint64, boost float64) *types.ClauseExplanation {
+	if len(children) == 0 {
+		return nil
+	}
+	node := &types.ClauseExplanation{Clause: clause, Matched: clause == "must"}
+	for _, child := range children {
+		detail := seg.explainQuery(child, docId, boost)
+		node.Details = append(node.Details, detail)
+		node.Score += detail.Score
+		if clause == "must" {
+			node.Matched = node.Matched && detail.Matched
+		} else {
+			node.Matched = node.Matched || detail.Matched
+		}
+	}
+	return node
+}
+
+// 内部方法，判断文档是否满足过滤条件，组合条件逐个解释子条件
+func (seg *Segment) explainFilter(filter *types.SearchFilters, docId uint64) *types.FilterExplanation {
+	node := &types.FilterExplanation{Filter: filter.Describe()}
+	if filter.IsBoolFilter() {
+		for _, child := range filter.Filters {
+			node.Details = append(node.Details, seg.explainFilter(child, docId))
+		}
+	}
+	node.Matched = seg.cachedFilterNode(filter).Contains(docId)
+	return node
+}
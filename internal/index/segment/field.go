@@ -7,11 +7,20 @@ import (
 	"github.com/cylScripter/NexusFind/internal/index/tree"
 	"github.com/cylScripter/NexusFind/types"
 	"github.com/cylScripter/NexusFind/utils"
+	"math"
+	"strings"
+	"time"
 )
 
 type SimpleFieldInfo struct {
-	FieldName string `json:"fieldName"`
-	FieldType uint64 `json:"fieldType"`
+	FieldName     string   `json:"fieldName"`
+	FieldType     uint64   `json:"fieldType"`
+	Suggest       bool     `json:"suggest"`       // 是否为该字段建立补全词典
+	SuggestWeight string   `json:"suggestWeight"` // 补全权重取自哪个数值字段
+	Dims          uint64   `json:"dims"`          // 向量字段的维度
+	Metric        uint64   `json:"metric"`        // 向量字段的相似度，KNN_COSINE/KNN_DOT/KNN_L2
+	DateFormats   []string `json:"dateFormats"`   // 毫秒日期字段接受的格式
+	TimeZone      string   `json:"timeZone"`      // 毫秒日期字段的时区
 }
 
 type Field struct {
@@ -22,6 +31,8 @@ type Field struct {
 	isMemory     bool
 	textInvert   *TextInvert
 	numberInvert *NumberInvert
+	dateOption   DateOption
+	exists       *roaring64.Bitmap // 字段有值的文档，旧版本的段没有持久化时为 nil，第一次使用时从倒排中汇总
 	btree        *tree.BTreeDB
 	logger       *utils.Log
 }
@@ -33,6 +44,7 @@ func NewEmptyField(fieldName string, start, fieldType uint64, logger *utils.Log)
 		maxDocId:   start,
 		fieldType:  fieldType,
 		isMemory:   true,
+		exists:     roaring64.New(),
 		logger:     logger,
 	}
 	if fieldType == utils.IDX_TYPE_STRING ||
@@ -41,7 +53,10 @@ func NewEmptyField(fieldName string, start, fieldType uint64, logger *utils.Log)
 	}
 	if fieldType == utils.IDX_TYPE_NUMBER ||
 		fieldType == utils.IDX_TYPE_DATE ||
-		fieldType == utils.IDX_TYPE_FLOAT {
+		fieldType == utils.IDX_TYPE_DATETIME ||
+		fieldType == utils.IDX_TYPE_FLOAT ||
+		fieldType == utils.IDX_TYPE_DOUBLE ||
+		fieldType == utils.IDX_TYPE_GEO {
 		f.numberInvert = NewEmptyNumberInvert(fieldType, start, fieldName, logger)
 	}
 	return f
@@ -60,6 +75,9 @@ func NewFieldFromLocalFile(fieldName, segmentName string, start, max uint64, fie
 	if max-start < utils.MAX_SEGMENT_SIZE && flag {
 		f.isMemory = true
 		f.maxDocId = start
+		f.exists = roaring64.New()
+	} else {
+		f.exists = loadExists(fmt.Sprintf("%v%v_exists.idx", segmentName, f.fieldName))
 	}
 	mmap, err := utils.NewMmap(fmt.Sprintf("%v%v_invert.idx", segmentName, f.fieldName), utils.ModeAppend)
 	if err != nil {
@@ -71,34 +89,73 @@ func NewFieldFromLocalFile(fieldName, segmentName string, start, max uint64, fie
 	}
 	if fieldType == utils.IDX_TYPE_NUMBER ||
 		fieldType == utils.IDX_TYPE_DATE ||
-		fieldType == utils.IDX_TYPE_FLOAT {
+		fieldType == utils.IDX_TYPE_DATETIME ||
+		fieldType == utils.IDX_TYPE_FLOAT ||
+		fieldType == utils.IDX_TYPE_DOUBLE ||
+		fieldType == utils.IDX_TYPE_GEO {
 		f.numberInvert = NewNumberInvert(fieldType, btree, fieldName, mmap, f.maxDocId, f.isMemory, logger)
 	}
 	return f
 }
 
+// SetDateOption
+// @Description 设置毫秒日期字段的格式和时区，写入和过滤时都按该配置解析
+// @Param option 日期字段的配置
+func (f *Field) SetDateOption(option DateOption) {
+	f.dateOption = option
+	if f.numberInvert != nil {
+		f.numberInvert.dateOption = option
+	}
+}
+
+// SetLegacyKeys 字段所在的段是格式版本 0，数值倒排的 key 按旧的小端序编码
+func (f *Field) SetLegacyKeys() {
+	if f.numberInvert != nil && !f.isMemory {
+		f.numberInvert.legacyKeys = true
+	}
+}
+
 func (f *Field) SetMemory() {
 	f.isMemory = true
 }
 
 func (f *Field) AddDocument(docId uint64, contentStr string) error {
+	return f.AddValues(docId, []string{contentStr})
+}
+
+// AddValues
+// @Description 为字段新增一个文档，多值字段的每个值都会建立倒排。文档没有该字段时 values 为空，只推进 docId
+// @Param docId 文档ID
+// @Param values 字段的所有值
+// @Return 任何错误
+func (f *Field) AddValues(docId uint64, values []string) error {
 	if docId != f.maxDocId || f.isMemory == false {
 		return errors.New("[ERROR] Wrong docid")
 	}
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			f.exists.Add(docId)
+			break
+		}
+	}
 	if (f.fieldType == utils.IDX_TYPE_STRING_SEG ||
 		f.fieldType == utils.IDX_TYPE_STRING) &&
 		f.textInvert != nil {
-		if err := f.textInvert.AddDocument(docId, contentStr); err != nil {
+		if err := f.textInvert.AddValues(docId, values); err != nil {
 			return err
 		}
 	}
 	if (f.fieldType == utils.IDX_TYPE_NUMBER ||
 		f.fieldType == utils.IDX_TYPE_DATE ||
-		f.fieldType == utils.IDX_TYPE_FLOAT) &&
+		f.fieldType == utils.IDX_TYPE_DATETIME ||
+		f.fieldType == utils.IDX_TYPE_FLOAT ||
+		f.fieldType == utils.IDX_TYPE_DOUBLE ||
+		f.fieldType == utils.IDX_TYPE_GEO) &&
 		f.numberInvert != nil {
-		if err := f.numberInvert.AddDocument(docId, contentStr); err != nil {
-			return err
-		}
+		// 数值解析失败时倒排的 docId 已经推进，字段也要推进，只把错误返回给调用方
+		err := f.numberInvert.AddValues(docId, values)
+		f.maxDocId++
+		return err
 	}
 	f.maxDocId++
 	return nil
@@ -111,16 +168,39 @@ func (f *Field) Query(key string) (*roaring64.Bitmap, bool) {
 	return f.textInvert.QueryTerm(fmt.Sprintf("%v", key))
 }
 
+func (f *Field) FuzzyTerms(word string, maxEdits int) (map[string]uint64, bool) {
+	if f.textInvert == nil {
+		return nil, false
+	}
+	return f.textInvert.FuzzyTerms(word, maxEdits), true
+}
+
+// QueryFilter
+// @Description 计算过滤条件命中的文档，过滤条件不参与打分。返回的位图调用方可以修改
+// @Param filter 过滤条件
+// @Return 命中的文档，字段类型不支持该过滤条件时返回 false
 func (f *Field) QueryFilter(filter *types.SearchFilters) (*roaring64.Bitmap, bool) {
+	if filter.IsSetFilter() {
+		return f.querySet(filter)
+	}
 	if f.numberInvert == nil {
 		return nil, false
 	}
+	if filter.Type == utils.FILT_GEO_DISTANCE || filter.Type == utils.FILT_GEO_BBOX {
+		return f.queryGeo(filter)
+	}
+	if f.fieldType == utils.IDX_TYPE_DOUBLE {
+		return f.queryDouble(filter)
+	}
+	if f.fieldType == utils.IDX_TYPE_DATETIME {
+		return f.queryDateTime(filter)
+	}
 	var start, end int64
 	switch filter.Type {
 	case utils.FILT_EQ:
 		if f.fieldType == utils.IDX_TYPE_DATE {
-			start = filter.Start
-			end = filter.Start + 86399
+			// 秒级日期字段按本地时区解析，匹配 Start 所在的本地自然日
+			start, end = utils.DayBounds(filter.Start, time.Local)
 			break
 		} else {
 			start = filter.Start
@@ -139,6 +219,170 @@ func (f *Field) QueryFilter(filter *types.SearchFilters) (*roaring64.Bitmap, boo
 	return f.numberInvert.QueryRange(start, end)
 }
 
+// 内部方法，集合类过滤。FILT_TERMS 的取值按字段类型解析，FILT_IN 的取值含义同 Start，RangeFloat 的取值含义同 StartFloat
+func (f *Field) querySet(filter *types.SearchFilters) (*roaring64.Bitmap, bool) {
+	switch filter.Type {
+	case utils.FILT_EXISTS:
+		return f.existsBitmap().Clone(), true
+	case utils.FILT_MISSING:
+		bitmap := roaring64.New()
+		bitmap.AddRange(f.startDocId, f.maxDocId)
+		bitmap.AndNot(f.existsBitmap())
+		return bitmap, true
+	case utils.FILT_TERMS:
+		bitmap := roaring64.New()
+		for _, term := range filter.Terms {
+			if result, ok := f.queryValue(term); ok {
+				bitmap.Or(result)
+			}
+		}
+		return bitmap, true
+	case utils.FILT_IN:
+		if f.numberInvert == nil {
+			return nil, false
+		}
+		bitmap := roaring64.New()
+		for _, value := range filter.Range {
+			key := value
+			if f.fieldType == utils.IDX_TYPE_DOUBLE {
+				key = utils.FloatToSortable(float64(value))
+			}
+			if result, ok := f.numberInvert.QueryTerm(key); ok {
+				bitmap.Or(result)
+			}
+		}
+		if f.fieldType == utils.IDX_TYPE_DOUBLE {
+			for _, value := range filter.RangeFloat {
+				if math.IsNaN(value) {
+					continue
+				}
+				if result, ok := f.numberInvert.QueryTerm(utils.FloatToSortable(value)); ok {
+					bitmap.Or(result)
+				}
+			}
+		}
+		return bitmap, true
+	}
+	return nil, false
+}
+
+// 内部方法，精确匹配一个取值，文本字段按词项匹配，数值类字段先按字段类型解析
+func (f *Field) queryValue(value string) (*roaring64.Bitmap, bool) {
+	if f.textInvert != nil {
+		return f.textInvert.QueryTerm(value)
+	}
+	if f.numberInvert != nil {
+		key, err := f.numberInvert.parse(value)
+		if err != nil {
+			return nil, false
+		}
+		return f.numberInvert.QueryTerm(key)
+	}
+	return nil, false
+}
+
+// 内部方法，返回字段有值的文档。旧版本的段没有持久化该位图，从倒排中汇总一次后缓存
+func (f *Field) existsBitmap() *roaring64.Bitmap {
+	if f.exists != nil {
+		return f.exists
+	}
+	exists := roaring64.New()
+	if f.textInvert != nil {
+		exists = f.textInvert.QueryAll()
+	}
+	if f.numberInvert != nil {
+		if result, ok := f.numberInvert.QueryRange(math.MinInt64, math.MaxInt64); ok {
+			exists = result
+		}
+	}
+	f.exists = exists
+	return f.exists
+}
+
+// 内部方法，读取序列化的有值文档位图，文件不存在时返回 nil
+func loadExists(fileName string) *roaring64.Bitmap {
+	if !utils.FileExist(fileName) {
+		return nil
+	}
+	mmap, err := utils.NewMmap(fileName, utils.ModeAppend)
+	if err != nil {
+		return nil
+	}
+	defer mmap.Unmap()
+	lenBuffer := mmap.ReadUInt64(8)
+	exists := roaring64.New()
+	if err := exists.UnmarshalBinary(mmap.MmapBytes[16 : 16+lenBuffer]); err != nil {
+		return nil
+	}
+	return exists
+}
+
+// 内部方法，任意精度浮点数过滤，边界使用 StartFloat/EndFloat，都是闭区间
+func (f *Field) queryDouble(filter *types.SearchFilters) (*roaring64.Bitmap, bool) {
+	if math.IsNaN(filter.StartFloat) || math.IsNaN(filter.EndFloat) {
+		return nil, false
+	}
+	var start, end float64
+	switch filter.Type {
+	case utils.FILT_EQ:
+		start, end = filter.StartFloat, filter.StartFloat
+	case utils.FILT_RANGE:
+		start, end = filter.StartFloat, filter.EndFloat
+	case utils.FILT_LESS:
+		start, end = math.Inf(-1), filter.StartFloat
+	case utils.FILT_OVER:
+		start, end = filter.StartFloat, math.Inf(1)
+	default:
+		return nil, false
+	}
+	return f.numberInvert.QueryRange(utils.FloatToSortable(start), utils.FloatToSortable(end))
+}
+
+// 内部方法，毫秒日期过滤，日期表达式按字段的时区计算。表达式不合法时不匹配任何文档
+func (f *Field) queryDateTime(filter *types.SearchFilters) (*roaring64.Bitmap, bool) {
+	start, end, err := f.dateOption.bounds(filter, time.Now())
+	if err != nil {
+		f.logger.NFLog.Errorf("field[%v] date filter error : %v", f.fieldName, err)
+		return roaring64.New(), true
+	}
+	return f.numberInvert.QueryRange(start, end)
+}
+
+// 内部方法，地理坐标过滤。字段不是地理坐标类型或者条件不完整时返回 false
+func (f *Field) queryGeo(filter *types.SearchFilters) (*roaring64.Bitmap, bool) {
+	if f.fieldType != utils.IDX_TYPE_GEO {
+		return nil, false
+	}
+	var boxes [][4]float64
+	var accept func(lat, lon float64) bool
+	switch {
+	case filter.Type == utils.FILT_GEO_DISTANCE && filter.GeoDistance.GetCenter() != nil:
+		center, distance := filter.GeoDistance.Center, filter.GeoDistance.Distance
+		boxes = utils.GeoDistanceBox(center.Lat, center.Lon, distance)
+		accept = func(lat, lon float64) bool {
+			return utils.GeoDistance(center.Lat, center.Lon, lat, lon) <= distance
+		}
+	case filter.Type == utils.FILT_GEO_BBOX && filter.GeoBoundingBox.GetTopLeft() != nil && filter.GeoBoundingBox.GetBottomRight() != nil:
+		topLeft, bottomRight := filter.GeoBoundingBox.TopLeft, filter.GeoBoundingBox.BottomRight
+		boxes = utils.GeoBoxes(bottomRight.Lat, topLeft.Lon, topLeft.Lat, bottomRight.Lon)
+		accept = func(lat, lon float64) bool {
+			for _, box := range boxes {
+				if lat >= box[0] && lon >= box[1] && lat <= box[2] && lon <= box[3] {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return nil, false
+	}
+	ranges := make([][2]int64, 0)
+	for _, box := range boxes {
+		ranges = append(ranges, utils.GeoCoverRanges(box[0], box[1], box[2], box[3])...)
+	}
+	return f.numberInvert.QueryGeo(ranges, accept)
+}
+
 func (f *Field) Serialization(segmentName string, db *tree.BTreeDB) error {
 	f.btree = db
 	if (f.fieldType == utils.IDX_TYPE_STRING_SEG ||
@@ -152,7 +396,10 @@ func (f *Field) Serialization(segmentName string, db *tree.BTreeDB) error {
 	}
 	if (f.fieldType == utils.IDX_TYPE_NUMBER ||
 		f.fieldType == utils.IDX_TYPE_DATE ||
-		f.fieldType == utils.IDX_TYPE_FLOAT) &&
+		f.fieldType == utils.IDX_TYPE_DATETIME ||
+		f.fieldType == utils.IDX_TYPE_FLOAT ||
+		f.fieldType == utils.IDX_TYPE_DOUBLE ||
+		f.fieldType == utils.IDX_TYPE_GEO) &&
 		f.numberInvert != nil {
 		f.numberInvert.setBti(db)
 		err := f.numberInvert.Serialization(segmentName, db)
@@ -160,6 +407,22 @@ func (f *Field) Serialization(segmentName string, db *tree.BTreeDB) error {
 			return err
 		}
 	}
+	return f.storeExists(segmentName)
+}
+
+// 内部方法，序列化有值文档位图
+func (f *Field) storeExists(segmentName string) error {
+	mmap, err := utils.NewMmap(fmt.Sprintf("%v%v_exists.idx", segmentName, f.fieldName), utils.ModeCreate)
+	if err != nil {
+		return err
+	}
+	defer mmap.Unmap()
+	bits, err := f.existsBitmap().ToBytes()
+	if err != nil {
+		return err
+	}
+	mmap.AppendUInt64(uint64(len(bits)))
+	mmap.AppendBytes(bits)
 	return nil
 }
 
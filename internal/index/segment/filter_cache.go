@@ -0,0 +1,158 @@
+package segment
+
+import (
+	"container/list"
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/cylScripter/NexusFind/utils"
+	"sync"
+)
+
+// DefaultFilterCache 所有索引共用的过滤结果缓存
+var DefaultFilterCache = NewFilterCache(utils.FILTER_CACHE_SIZE)
+
+// FilterCacheStats 过滤结果缓存的统计信息
+type FilterCacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   uint64 `json:"entries"`
+	Bytes     uint64 `json:"bytes"`    // 缓存的位图占用的内存
+	Capacity  uint64 `json:"capacity"` // 内存上限
+}
+
+// FilterCache 按 (段名, 规范化的过滤条件) 缓存过滤结果的 LRU。
+// 只缓存已经序列化、不会再修改的段，段关闭或者删除时清除该段的所有缓存
+type FilterCache struct {
+	mutex     sync.Mutex
+	capacity  uint64
+	size      uint64
+	lru       *list.List
+	items     map[string]*list.Element
+	segments  map[string]map[string]struct{} // 段名 -> 该段的缓存 key
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type filterCacheEntry struct {
+	segmentName string
+	key         string
+	bitmap      *roaring64.Bitmap
+	size        uint64
+}
+
+// NewFilterCache
+// @Description 创建过滤结果缓存
+// @Param capacity 内存上限，单位字节，为 0 时不缓存
+// @Return 过滤结果缓存
+func NewFilterCache(capacity uint64) *FilterCache {
+	return &FilterCache{
+		capacity: capacity,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element),
+		segments: make(map[string]map[string]struct{}),
+	}
+}
+
+// Get
+// @Description 查找缓存的过滤结果
+// @Param segmentName 段名
+// @Param key 过滤条件的 CacheKey
+// @Return 过滤结果的副本，调用方可以修改
+func (c *FilterCache) Get(segmentName, key string) (*roaring64.Bitmap, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.items[segmentName+"\x00"+key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(element)
+	return element.Value.(*filterCacheEntry).bitmap.Clone(), true
+}
+
+// Put
+// @Description 缓存过滤结果，超过内存上限时淘汰最久没有使用的结果
+// @Param segmentName 段名
+// @Param key 过滤条件的 CacheKey
+// @Param bitmap 过滤结果，缓存保存的是副本
+func (c *FilterCache) Put(segmentName, key string, bitmap *roaring64.Bitmap) {
+	size := bitmap.GetSizeInBytes()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if size > c.capacity {
+		return
+	}
+	fullKey := segmentName + "\x00" + key
+	if element, ok := c.items[fullKey]; ok {
+		c.remove(element)
+	}
+	entry := &filterCacheEntry{segmentName: segmentName, key: fullKey, bitmap: bitmap.Clone(), size: size}
+	c.items[fullKey] = c.lru.PushFront(entry)
+	if c.segments[segmentName] == nil {
+		c.segments[segmentName] = make(map[string]struct{})
+	}
+	c.segments[segmentName][fullKey] = struct{}{}
+	c.size += size
+	c.evict()
+}
+
+// InvalidateSegment
+// @Description 清除某个段的所有缓存，段关闭、删除或者合并时调用
+// @Param segmentName 段名
+func (c *FilterCache) InvalidateSegment(segmentName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key := range c.segments[segmentName] {
+		c.remove(c.items[key])
+	}
+	delete(c.segments, segmentName)
+}
+
+// SetCapacity
+// @Description 修改内存上限，变小时立即淘汰
+// @Param capacity 内存上限，单位字节
+func (c *FilterCache) SetCapacity(capacity uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.capacity = capacity
+	c.evict()
+}
+
+// Stats
+// @Description 返回缓存的命中、未命中、淘汰次数以及内存占用
+// @Return 统计信息
+func (c *FilterCache) Stats() FilterCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return FilterCacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   uint64(len(c.items)),
+		Bytes:     c.size,
+		Capacity:  c.capacity,
+	}
+}
+
+// 内部方法，淘汰最久没有使用的结果直到不超过内存上限，调用方持有锁
+func (c *FilterCache) evict() {
+	for c.size > c.capacity && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+		c.evictions++
+	}
+}
+
+// 内部方法，删除一条缓存，调用方持有锁
+func (c *FilterCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*filterCacheEntry)
+	delete(c.items, entry.key)
+	if keys, ok := c.segments[entry.segmentName]; ok {
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.segments, entry.segmentName)
+		}
+	}
+	c.size -= entry.size
+}
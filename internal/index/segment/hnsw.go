@@ -0,0 +1,223 @@
+/*****************************************************************************
+ *  file name : hnsw.go
+ *  author : cyl
+ *  email  : 2842871262@qq.com
+ *  file description : HNSW 近似最近邻图
+ *
+******************************************************************************/
+
+package segment
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	hnswM              = 16  // 每个节点在非 0 层的最大邻居数，第 0 层为 2*M
+	hnswEfConstruction = 200 // 构图时的候选集大小
+)
+
+/************************************************************************
+
+HNSW 图，节点编号即向量在 VectorField 中的下标
+序列化时用 gob 写入 [segmentName][fieldName]_hnsw.idx
+
+************************************************************************/
+
+type hnswGraph struct {
+	M              int
+	EfConstruction int
+	MaxLevel       int
+	EntryPoint     int
+	Levels         []int
+	Friends        [][][]int32 // Friends[节点][层] 为该节点在该层的邻居
+	vectors        [][]float32
+	distance       func(a, b []float32) float32
+}
+
+type hnswItem struct {
+	node int
+	dist float32
+}
+
+// hnswHeap isMax 为 true 时是大顶堆，否则是小顶堆
+type hnswHeap struct {
+	items []hnswItem
+	isMax bool
+}
+
+func (h *hnswHeap) Len() int { return len(h.items) }
+func (h *hnswHeap) Less(i, j int) bool {
+	if h.isMax {
+		return h.items[i].dist > h.items[j].dist
+	}
+	return h.items[i].dist < h.items[j].dist
+}
+func (h *hnswHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *hnswHeap) Push(x any)    { h.items = append(h.items, x.(hnswItem)) }
+func (h *hnswHeap) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
+
+// newHnswGraph
+// @Description 用段内所有向量构建 HNSW 图
+// @Param vectors 向量
+// @Param metric 相似度
+// @Return HNSW 图
+func newHnswGraph(vectors [][]float32, metric uint64) *hnswGraph {
+	g := &hnswGraph{
+		M:              hnswM,
+		EfConstruction: hnswEfConstruction,
+		EntryPoint:     -1,
+		Levels:         make([]int, 0, len(vectors)),
+		Friends:        make([][][]int32, 0, len(vectors)),
+	}
+	g.attach(vectors, metric)
+	// 固定随机种子，同样的数据构建出同样的图
+	rng := rand.New(rand.NewSource(int64(len(vectors))))
+	levelMult := 1 / math.Log(float64(g.M))
+	for node := range vectors {
+		level := int(-math.Log(1-rng.Float64()) * levelMult)
+		g.insert(node, level)
+	}
+	return g
+}
+
+// attach 反序列化之后需要重新关联向量和距离函数
+func (g *hnswGraph) attach(vectors [][]float32, metric uint64) {
+	g.vectors = vectors
+	g.distance = vectorDistance(metric)
+}
+
+func (g *hnswGraph) insert(node, level int) {
+	g.Levels = append(g.Levels, level)
+	g.Friends = append(g.Friends, make([][]int32, level+1))
+	if g.EntryPoint < 0 {
+		g.EntryPoint = node
+		g.MaxLevel = level
+		return
+	}
+	query := g.vectors[node]
+	ep := g.EntryPoint
+	for l := g.MaxLevel; l > level; l-- {
+		ep = g.greedy(query, ep, l)
+	}
+	for l := min(level, g.MaxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(query, ep, g.EfConstruction, l, nil)
+		maxFriends := g.M
+		if l == 0 {
+			maxFriends = 2 * g.M
+		}
+		neighbors := make([]int32, 0, g.M)
+		for i := 0; i < len(candidates) && i < g.M; i++ {
+			neighbors = append(neighbors, int32(candidates[i].node))
+		}
+		g.Friends[node][l] = neighbors
+		for _, nb := range neighbors {
+			friends := append(g.Friends[nb][l], int32(node))
+			if len(friends) > maxFriends {
+				friends = g.prune(int(nb), friends, maxFriends)
+			}
+			g.Friends[nb][l] = friends
+		}
+		if len(candidates) > 0 {
+			ep = candidates[0].node
+		}
+	}
+	if level > g.MaxLevel {
+		g.MaxLevel = level
+		g.EntryPoint = node
+	}
+}
+
+// prune 只保留离 node 最近的 maxFriends 个邻居
+func (g *hnswGraph) prune(node int, friends []int32, maxFriends int) []int32 {
+	base := g.vectors[node]
+	sort.Slice(friends, func(i, j int) bool {
+		return g.distance(base, g.vectors[friends[i]]) < g.distance(base, g.vectors[friends[j]])
+	})
+	return friends[:maxFriends]
+}
+
+// greedy 在某一层上贪心地走向离 query 最近的节点
+func (g *hnswGraph) greedy(query []float32, ep, level int) int {
+	best := g.distance(query, g.vectors[ep])
+	for changed := true; changed; {
+		changed = false
+		for _, nb := range g.Friends[ep][level] {
+			if d := g.distance(query, g.vectors[nb]); d < best {
+				best = d
+				ep = int(nb)
+				changed = true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer
+// @Description 在某一层上做 ef 宽度的最佳优先搜索
+// @Param accept 结果过滤函数，为空时不过滤。被过滤掉的节点仍然参与遍历
+// @Return 按距离升序排列的结果
+func (g *hnswGraph) searchLayer(query []float32, ep, ef, level int, accept func(node int) bool) []hnswItem {
+	visited := make(map[int]struct{}, ef*4)
+	candidates := &hnswHeap{}
+	results := &hnswHeap{isMax: true}
+	start := hnswItem{node: ep, dist: g.distance(query, g.vectors[ep])}
+	visited[ep] = struct{}{}
+	heap.Push(candidates, start)
+	if accept == nil || accept(ep) {
+		heap.Push(results, start)
+	}
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(hnswItem)
+		if results.Len() >= ef && current.dist > results.items[0].dist {
+			break
+		}
+		for _, nb := range g.Friends[current.node][level] {
+			if _, ok := visited[int(nb)]; ok {
+				continue
+			}
+			visited[int(nb)] = struct{}{}
+			item := hnswItem{node: int(nb), dist: g.distance(query, g.vectors[nb])}
+			if results.Len() < ef || item.dist < results.items[0].dist {
+				heap.Push(candidates, item)
+				if accept == nil || accept(item.node) {
+					heap.Push(results, item)
+					if results.Len() > ef {
+						heap.Pop(results)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(results.items, func(i, j int) bool {
+		return results.items[i].dist < results.items[j].dist
+	})
+	return results.items
+}
+
+// Search
+// @Description 查找离 query 最近的 k 个节点
+// @Param ef 第 0 层的候选集大小
+// @Param accept 结果过滤函数
+// @Return 按距离升序排列的结果
+func (g *hnswGraph) Search(query []float32, k, ef int, accept func(node int) bool) []hnswItem {
+	if g.EntryPoint < 0 {
+		return nil
+	}
+	ep := g.EntryPoint
+	for l := g.MaxLevel; l > 0; l-- {
+		ep = g.greedy(query, ep, l)
+	}
+	result := g.searchLayer(query, ep, max(ef, k), 0, accept)
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
@@ -9,12 +9,15 @@
 package segment
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/cylScripter/NexusFind/internal/index/tree"
 	"github.com/cylScripter/NexusFind/utils"
+	"math"
 	"strconv"
+	"strings"
 )
 
 type Term string
@@ -25,8 +28,16 @@ func (t Term) ToBytes() []byte {
 
 type Number int64
 
+// ToBytes 数值型的 key 需要按字节序和数值大小一致，范围查询才能直接 Seek
 func (n Number) ToBytes() []byte {
-	return utils.ItoBytes(n)
+	return tree.EncodeInt64Key(int64(n))
+}
+
+// legacyNumber 格式版本 0 的段中数值型的 key，按小端序编码，字节序与数值大小无关
+type legacyNumber int64
+
+func (n legacyNumber) ToBytes() []byte {
+	return utils.ItoBytes(int64(n))
 }
 
 /************************************************************************
@@ -103,6 +114,8 @@ type TextInvert struct {
 type NumberInvert struct {
 	*invert
 	memoryHashMap map[Number]*roaring64.Bitmap //key为词项，value为用位图保存倒排列表
+	dateOption    DateOption                   // 毫秒日期字段的格式和时区
+	legacyKeys    bool                         // 磁盘上的 key 是否为格式版本 0 的小端序编码
 }
 
 func NewEmptyTextInvert(fieldType uint64, startDocId uint64, fieldName string, logger *utils.Log) *TextInvert {
@@ -126,17 +139,24 @@ func (ivt *TextInvert) destroy() {
 }
 
 func (ivt *TextInvert) AddDocument(docId uint64, contentStr string) error {
+	return ivt.AddValues(docId, []string{contentStr})
+}
+
+// AddValues 多值字段的每个值都建立倒排，values 为空时只推进 docId
+func (ivt *TextInvert) AddValues(docId uint64, values []string) error {
 	if docId != ivt.curDocId {
 		return errors.New("text invert AddDocument :: Wrong DocId Number")
 	}
 	var segResult []string
-	if ivt.fieldType == utils.IDX_TYPE_STRING {
-		segResult = []string{contentStr}
-	} else if ivt.fieldType == utils.IDX_TYPE_STRING_SEG {
-		segmented := utils.GetGseSegmenter()
-		segResult = segmented.CutSearch(contentStr, false)
-	} else {
-		return errors.New("invert fieldType is not exists")
+	for _, contentStr := range values {
+		if ivt.fieldType == utils.IDX_TYPE_STRING {
+			segResult = append(segResult, contentStr)
+		} else if ivt.fieldType == utils.IDX_TYPE_STRING_SEG {
+			segmented := utils.GetGseSegmenter()
+			segResult = append(segResult, segmented.CutSearch(contentStr, false)...)
+		} else {
+			return errors.New("invert fieldType is not exists")
+		}
 	}
 	if ivt.memoryHashMap == nil {
 		ivt.memoryHashMap = make(map[Term]*roaring64.Bitmap)
@@ -163,7 +183,7 @@ func (ivt *TextInvert) Serialization(segmentName string, btree *tree.BTreeDB) er
 	defer mmap.Unmap()
 	err = btree.AddBTree(btName)
 	if err != nil {
-		fmt.Println(err)
+		ivt.logger.NFLog.Errorf("add btree [%v] failed: %v", btName, err)
 	}
 	tx, err := btree.BeginTx()
 	if err != nil {
@@ -199,7 +219,7 @@ func (ivt *TextInvert) QueryTerm(keyStr string) (*roaring64.Bitmap, bool) {
 
 			err := idxBitMap.UnmarshalBinary(bits)
 			if err != nil {
-				fmt.Println(err)
+				ivt.logger.NFLog.Errorf("read bitmap of term [%v] in field [%v] failed: %v", keyStr, ivt.fieldName, err)
 			}
 			return idxBitMap, true
 		}
@@ -207,11 +227,91 @@ func (ivt *TextInvert) QueryTerm(keyStr string) (*roaring64.Bitmap, bool) {
 	return nil, false
 }
 
+// QueryAll
+// @Description 返回所有词项的倒排列表的并集，即字段有值的文档
+// @Return 文档位图
+func (ivt *TextInvert) QueryAll() *roaring64.Bitmap {
+	result := roaring64.New()
+	if ivt.isMemory == true {
+		for _, bitmap := range ivt.memoryHashMap {
+			result.Or(bitmap)
+		}
+	} else if ivt.idxMmap != nil {
+		btName := fmt.Sprintf("%v_invert", ivt.fieldName)
+		ok, _, offsets := ivt.bti.SearchPrefix(btName, "")
+		if !ok {
+			return result
+		}
+		for _, offset := range offsets {
+			lenBuffer := ivt.idxMmap.ReadUInt64(offset)
+			bits := ivt.idxMmap.MmapBytes[offset+8 : offset+8+lenBuffer]
+			idxBitMap := roaring64.New()
+			if err := idxBitMap.UnmarshalBinary(bits); err != nil {
+				ivt.logger.NFLog.Errorf("read bitmap in field [%v] failed: %v", ivt.fieldName, err)
+				continue
+			}
+			result.Or(idxBitMap)
+		}
+	}
+	return result
+}
+
+// FuzzyTerms
+// @Description 在词典中查找与 word 编辑距离不超过 maxEdits 的词项（包括 word 本身）。
+// 候选词的前 FUZZY_PREFIX_LENGTH 个字符必须与原词相同，磁盘段只扫描该前缀下的词项
+// @Param word 原词
+// @Param maxEdits 最大编辑距离
+// @Return map[词项]文档频率
+func (ivt *TextInvert) FuzzyTerms(word string, maxEdits int) map[string]uint64 {
+	result := make(map[string]uint64)
+	prefix := fuzzyPrefix(word)
+	if ivt.isMemory == true {
+		for term, bitmap := range ivt.memoryHashMap {
+			if !strings.HasPrefix(string(term), prefix) {
+				continue
+			}
+			if utils.EditDistance(word, string(term), maxEdits) <= maxEdits {
+				result[string(term)] = bitmap.GetCardinality()
+			}
+		}
+	} else if ivt.idxMmap != nil {
+		btName := fmt.Sprintf("%v_invert", ivt.fieldName)
+		ok, terms, offsets := ivt.bti.SearchPrefix(btName, prefix)
+		if !ok {
+			return result
+		}
+		for i, term := range terms {
+			if utils.EditDistance(word, term, maxEdits) > maxEdits {
+				continue
+			}
+			offset := offsets[i]
+			lenBuffer := ivt.idxMmap.ReadUInt64(offset)
+			bits := ivt.idxMmap.MmapBytes[offset+8 : offset+8+lenBuffer]
+			idxBitMap := roaring64.New()
+			if err := idxBitMap.UnmarshalBinary(bits); err != nil {
+				ivt.logger.NFLog.Errorf("read bitmap of term [%v] in field [%v] failed: %v", term, ivt.fieldName, err)
+				continue
+			}
+			result[term] = idxBitMap.GetCardinality()
+		}
+	}
+	return result
+}
+
+// 内部方法，模糊匹配时候选词必须相同的前缀
+func fuzzyPrefix(word string) string {
+	runes := []rune(word)
+	if len(runes) > utils.FUZZY_PREFIX_LENGTH {
+		runes = runes[:utils.FUZZY_PREFIX_LENGTH]
+	}
+	return string(runes)
+}
+
 func NewEmptyNumberInvert(fieldType uint64, startDocId uint64, fieldName string, logger *utils.Log) *NumberInvert {
 	ivt := newEmptyInvert(fieldType, startDocId, fieldName, logger)
 	return &NumberInvert{
-		ivt,
-		nil,
+		invert:        ivt,
+		memoryHashMap: nil,
 	}
 }
 
@@ -228,35 +328,84 @@ func (ivt *NumberInvert) destroy() {
 }
 
 func (ivt *NumberInvert) AddDocument(docId uint64, contentStr string) error {
+	return ivt.AddValues(docId, []string{contentStr})
+}
+
+// AddValues 多值字段的每个值都建立倒排，values 为空时只推进 docId。
+// 无法解析的值不建倒排，docId 照常推进，最后返回错误
+func (ivt *NumberInvert) AddValues(docId uint64, values []string) error {
 	if docId != ivt.curDocId {
 		return errors.New("number index AddDocument :: Wrong DocId Number")
 	}
-	var value int64 = -1
-	switch ivt.fieldType {
-	case utils.IDX_TYPE_NUMBER:
-		intValue, err := strconv.ParseInt(contentStr, 10, 64)
+	if ivt.memoryHashMap == nil {
+		ivt.memoryHashMap = make(map[Number]*roaring64.Bitmap)
+	}
+	var invalid []string
+	for _, contentStr := range values {
+		value, err := ivt.parse(contentStr)
 		if err != nil {
-			intValue = -1
+			invalid = append(invalid, contentStr)
+			continue
 		}
-		value = intValue
+		if ivt.memoryHashMap[Number(value)] == nil {
+			ivt.memoryHashMap[Number(value)] = roaring64.New()
+		}
+		ivt.memoryHashMap[Number(value)].Add(docId)
+	}
+	ivt.curDocId++
+	if len(invalid) > 0 {
+		return fmt.Errorf("number index AddDocument :: invalid values %q", invalid)
+	}
+	return nil
+}
+
+// 内部方法，毫秒日期按字段配置的格式和时区解析，其他类型见 ParseNumberValue
+func (ivt *NumberInvert) parse(contentStr string) (int64, error) {
+	if ivt.fieldType == utils.IDX_TYPE_DATETIME {
+		return ParseDateValue(ivt.dateOption, contentStr)
+	}
+	return ParseNumberValue(ivt.fieldType, contentStr)
+}
+
+// ParseNumberValue
+// @Description 把数值类字段的内容转换成倒排的 key
+// @Param fieldType 字段类型
+// @Param contentStr 字段内容
+// @Return 倒排的 key，浮点数保留两位小数后乘以 100，任意精度浮点数为保序编码，日期为时间戳，毫秒日期按默认格式和本地时区解析，地理坐标为 Morton 码
+func ParseNumberValue(fieldType uint64, contentStr string) (int64, error) {
+	switch fieldType {
+	case utils.IDX_TYPE_NUMBER:
+		return strconv.ParseInt(strings.TrimSpace(contentStr), 10, 64)
 	case utils.IDX_TYPE_FLOAT:
-		floatValue, err := strconv.ParseFloat(contentStr, 64)
+		floatValue, err := strconv.ParseFloat(strings.TrimSpace(contentStr), 64)
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
+			return 0, fmt.Errorf("invalid float %v", contentStr)
+		}
+		return int64(math.Round(floatValue * 100)), nil
+	case utils.IDX_TYPE_DOUBLE:
+		floatValue, err := strconv.ParseFloat(strings.TrimSpace(contentStr), 64)
 		if err != nil {
-			floatValue = -0.01
+			return 0, err
 		}
-		value = int64(floatValue * 100)
+		if math.IsNaN(floatValue) {
+			return 0, fmt.Errorf("invalid double %v", contentStr)
+		}
+		return utils.FloatToSortable(floatValue), nil
 	case utils.IDX_TYPE_DATE:
-		value, _ = utils.IsDateTime(contentStr)
-	}
-	if ivt.memoryHashMap == nil {
-		ivt.memoryHashMap = make(map[Number]*roaring64.Bitmap)
-	}
-	if ivt.memoryHashMap[Number(value)] == nil {
-		ivt.memoryHashMap[Number(value)] = roaring64.New()
+		return utils.IsDateTime(contentStr)
+	case utils.IDX_TYPE_DATETIME:
+		return ParseDateValue(DateOption{}, contentStr)
+	case utils.IDX_TYPE_GEO:
+		lat, lon, err := utils.ParseGeoPoint(contentStr)
+		if err != nil {
+			return 0, err
+		}
+		return utils.GeoEncode(lat, lon), nil
 	}
-	ivt.memoryHashMap[Number(value)].Add(docId)
-	ivt.curDocId++
-	return nil
+	return 0, fmt.Errorf("field type %v is not a number type", fieldType)
 }
 
 func (ivt *NumberInvert) Serialization(segmentName string, btree *tree.BTreeDB) error {
@@ -274,7 +423,18 @@ func (ivt *NumberInvert) Serialization(segmentName string, btree *tree.BTreeDB)
 	if err != nil {
 		return err
 	}
+	// 倒排文件是重新创建的，旧的 key 指向的偏移已经失效，旧格式的 key 也不能与新格式混在一起
+	if tx.Bucket([]byte(btName)) != nil {
+		if err := tx.DeleteBucket([]byte(btName)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 	b, err := tx.CreateBucketIfNotExists([]byte(btName))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 	for key, value := range ivt.memoryHashMap {
 		bits, _ := value.ToBytes()
 		mmap.AppendUInt64(uint64(len(bits)))
@@ -285,6 +445,7 @@ func (ivt *NumberInvert) Serialization(segmentName string, btree *tree.BTreeDB)
 	btree.Commit(tx)
 	ivt.memoryHashMap = nil
 	ivt.isMemory = false
+	ivt.legacyKeys = false
 	return nil
 }
 
@@ -306,18 +467,26 @@ func (ivt *NumberInvert) GetFirstKV() (int64, uint64, bool) {
 
 func (ivt *NumberInvert) QueryTerm(key int64) (*roaring64.Bitmap, bool) {
 	btName := fmt.Sprintf("%v_invert", ivt.fieldName)
-	fmt.Println(btName)
 	if ivt.isMemory == true {
-		return ivt.memoryHashMap[Number(key)], true
+		if bitmap, ok := ivt.memoryHashMap[Number(key)]; ok {
+			return bitmap, true
+		}
+		return nil, false
 	} else if ivt.idxMmap != nil {
 		idxBitMap := roaring64.New()
-		exits, offset := ivt.bti.Search(btName, Number(key))
+		var exits bool
+		var offset uint64
+		if ivt.legacyKeys {
+			exits, offset = ivt.bti.Search(btName, legacyNumber(key))
+		} else {
+			exits, offset = ivt.bti.Search(btName, Number(key))
+		}
 		if exits {
 			lenBuffer := ivt.idxMmap.ReadUInt64(offset)
 			bits := ivt.idxMmap.MmapBytes[offset+8 : offset+8+lenBuffer]
 			err := idxBitMap.UnmarshalBinary(bits)
 			if err != nil {
-				fmt.Println(err)
+				ivt.logger.NFLog.Errorf("read bitmap of key [%v] in field [%v] failed: %v", key, ivt.fieldName, err)
 			}
 			return idxBitMap, true
 		}
@@ -334,6 +503,8 @@ func (ivt *NumberInvert) QueryRange(keyMin, keyMax int64) (*roaring64.Bitmap, bo
 			}
 		}
 		return bitMap, true
+	} else if ivt.idxMmap != nil && ivt.legacyKeys {
+		return ivt.scanLegacy(func(key int64) bool { return key >= keyMin && key <= keyMax }), true
 	} else if ivt.idxMmap != nil {
 		btName := fmt.Sprintf("%v_invert", ivt.fieldName)
 		ok, offsets := ivt.bti.SearchRange(btName, keyMin, keyMax)
@@ -344,12 +515,88 @@ func (ivt *NumberInvert) QueryRange(keyMin, keyMax int64) (*roaring64.Bitmap, bo
 				idxBitMap := roaring64.New()
 				err := idxBitMap.UnmarshalBinary(bits)
 				if err != nil {
-					fmt.Println(err)
+					ivt.logger.NFLog.Errorf("read bitmap in field [%v] failed: %v", ivt.fieldName, err)
+				}
+				bitMap.Or(idxBitMap)
+			}
+		}
+		// 范围内没有任何 key 时返回空位图，而不是当作没有过滤条件
+		return bitMap, true
+	}
+	return nil, false
+}
+
+// QueryGeo
+// @Description 地理坐标查询，先按 Morton 码区间扫描，再用 accept 按真实坐标逐个检查
+// @Param ranges Morton 码区间
+// @Param accept 判断某个坐标是否满足条件
+// @Return 满足条件的文档
+func (ivt *NumberInvert) QueryGeo(ranges [][2]int64, accept func(lat, lon float64) bool) (*roaring64.Bitmap, bool) {
+	bitMap := roaring64.New()
+	if ivt.isMemory == true {
+		for k, v := range ivt.memoryHashMap {
+			if k < 0 {
+				continue
+			}
+			if lat, lon := utils.GeoDecode(int64(k)); accept(lat, lon) {
+				bitMap.Or(v)
+			}
+		}
+		return bitMap, true
+	} else if ivt.idxMmap != nil && ivt.legacyKeys {
+		return ivt.scanLegacy(func(key int64) bool {
+			if key < 0 {
+				return false
+			}
+			lat, lon := utils.GeoDecode(key)
+			return accept(lat, lon)
+		}), true
+	} else if ivt.idxMmap != nil {
+		btName := fmt.Sprintf("%v_invert", ivt.fieldName)
+		for _, r := range ranges {
+			ok, keys, offsets := ivt.bti.SearchRangeKV(btName, r[0], r[1])
+			if !ok {
+				continue
+			}
+			for i, offset := range offsets {
+				if lat, lon := utils.GeoDecode(keys[i]); !accept(lat, lon) {
+					continue
+				}
+				lenBuffer := ivt.idxMmap.ReadUInt64(offset)
+				bits := ivt.idxMmap.MmapBytes[offset+8 : offset+8+lenBuffer]
+				idxBitMap := roaring64.New()
+				if err := idxBitMap.UnmarshalBinary(bits); err != nil {
+					ivt.logger.NFLog.Errorf("read bitmap in field [%v] failed: %v", ivt.fieldName, err)
+					continue
 				}
 				bitMap.Or(idxBitMap)
 			}
-			return bitMap, true
 		}
+		return bitMap, true
 	}
 	return nil, false
 }
+
+// 内部方法，格式版本 0 的段的 key 字节序与数值大小无关，逐个解码 key 并用 accept 判断
+func (ivt *NumberInvert) scanLegacy(accept func(key int64) bool) *roaring64.Bitmap {
+	bitMap := roaring64.New()
+	btName := fmt.Sprintf("%v_invert", ivt.fieldName)
+	ok, keys, offsets := ivt.bti.SearchPrefix(btName, "")
+	if !ok {
+		return bitMap
+	}
+	for i, key := range keys {
+		if len(key) != 8 || !accept(int64(binary.LittleEndian.Uint64([]byte(key)))) {
+			continue
+		}
+		lenBuffer := ivt.idxMmap.ReadUInt64(offsets[i])
+		bits := ivt.idxMmap.MmapBytes[offsets[i]+8 : offsets[i]+8+lenBuffer]
+		idxBitMap := roaring64.New()
+		if err := idxBitMap.UnmarshalBinary(bits); err != nil {
+			ivt.logger.NFLog.Errorf("read bitmap in field [%v] failed: %v", ivt.fieldName, err)
+			continue
+		}
+		bitMap.Or(idxBitMap)
+	}
+	return bitMap
+}
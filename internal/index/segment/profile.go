@@ -110,7 +110,7 @@ func (pfl *Profile) Query(docId uint64) (*doc.Document, bool) {
 			if err != nil {
 				return nil, false
 			}
-			return nil, true
+			return &document, true
 		} else {
 			return nil, false
 		}
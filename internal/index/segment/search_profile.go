@@ -0,0 +1,95 @@
+package segment
+
+import (
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"time"
+)
+
+// SearchProfile
+// @Description 与 SearchAfter 相同，同时记录关键词查询的每个子句、每个过滤条件以及读取文档的耗时
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param deleteBitmap 已删除的文档
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档、对应的 docId、该段的耗时信息
+func (seg *Segment) SearchProfile(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap, after, size uint64) ([]*doc.Document, []uint64, *types.SegmentProfile) {
+	start := time.Now()
+	profile := &types.SegmentProfile{Segment: seg.SegmentName}
+	var result *roaring64.Bitmap
+	if !query.Empty() {
+		var entry *types.ProfileEntry
+		result, entry = seg.profileQuery(query)
+		profile.Entries = append(profile.Entries, entry)
+	}
+	if len(filters) > 0 {
+		filterResult := seg.allDocs()
+		for _, filter := range filters {
+			filterStart := time.Now()
+			bitmap := seg.cachedFilterNode(filter)
+			filterResult.And(bitmap)
+			profile.Entries = append(profile.Entries, &types.ProfileEntry{
+				Name:      filter.Describe(),
+				Type:      "filter",
+				TimeNanos: uint64(time.Since(filterStart).Nanoseconds()),
+				Hits:      bitmap.GetCardinality(),
+			})
+		}
+		// 与 match 一致：没有关键词查询时只按过滤条件
+		if result == nil {
+			result = filterResult
+		} else {
+			result.And(filterResult)
+		}
+	}
+	if result == nil {
+		result = roaring64.NewBitmap()
+	}
+	fetchStart := time.Now()
+	docList, docIds := seg.fetchAfter(result, deleteBitmap, after, size)
+	profile.Entries = append(profile.Entries, &types.ProfileEntry{
+		Name:      "fetch",
+		Type:      "fetch",
+		TimeNanos: uint64(time.Since(fetchStart).Nanoseconds()),
+		Hits:      uint64(len(docList)),
+	})
+	profile.TimeNanos = uint64(time.Since(start).Nanoseconds())
+	return docList, docIds, profile
+}
+
+// 内部方法，与 search 相同，同时记录每个子句的耗时和命中数
+func (seg *Segment) profileQuery(query *types.TermQuery) (*roaring64.Bitmap, *types.ProfileEntry) {
+	start := time.Now()
+	entry := &types.ProfileEntry{Type: "query"}
+	var result *roaring64.Bitmap
+	if query.Keyword != nil {
+		entry.Name = query.Keyword.Field + ":" + query.Keyword.Word
+		result = seg.search(query)
+	} else {
+		children := query.Must
+		entry.Name = "must"
+		if len(query.Must) == 0 {
+			children = query.Should
+			entry.Name = "should"
+		}
+		results := make([]*roaring64.Bitmap, 0, len(children))
+		for _, child := range children {
+			bitmap, childEntry := seg.profileQuery(child)
+			results = append(results, bitmap)
+			entry.Children = append(entry.Children, childEntry)
+		}
+		if entry.Name == "must" {
+			result = types.IntersectBitmaps(results)
+		} else {
+			result = types.UnionBitmaps(results)
+		}
+	}
+	if result == nil {
+		result = roaring64.NewBitmap()
+	}
+	entry.TimeNanos = uint64(time.Since(start).Nanoseconds())
+	entry.Hits = result.GetCardinality()
+	return result, entry
+}
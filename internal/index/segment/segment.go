@@ -13,11 +13,17 @@ import (
 )
 
 type Segment struct {
-	StartDocId  uint64            `json:"startDocId"`  // 段内docId的最小值
-	MaxDocId    uint64            `json:"maxDocId"`    // 段内docId的最大值
-	SegmentName string            `json:"segmentName"` // 段的名称，序列化时文件名的一部分
-	FieldInfos  map[string]uint64 `json:"fields"`      // 记录段内字段的类型信息
-	fields      map[string]*Field // 段内字段的
+	StartDocId  uint64                  `json:"startDocId"`  // 段内docId的最小值
+	MaxDocId    uint64                  `json:"maxDocId"`    // 段内docId的最大值
+	SegmentName string                  `json:"segmentName"` // 段的名称，序列化时文件名的一部分
+	FieldInfos  map[string]uint64       `json:"fields"`      // 记录段内字段的类型信息
+	SuggestInfo map[string]string       `json:"suggest"`     // 需要建立补全词典的字段，value 为权重字段
+	VectorInfo  map[string]VectorOption `json:"vector"`      // 向量字段的配置
+	DateInfo    map[string]DateOption   `json:"date"`        // 毫秒日期字段的配置
+	Version     uint64                  `json:"version"`     // 段文件格式版本，没有记录的旧段为 0
+	fields      map[string]*Field       // 段内字段的
+	suggesters  map[string]*Suggester
+	vectors     map[string]*VectorField
 	pfl         *Profile
 	isMemory    bool          // 标识段是否在内存中
 	btdb        *tree.BTreeDB // 段的数据库
@@ -29,22 +35,46 @@ type Segment struct {
 // @Param segmentName  段名
 // @Param start  文档起始Id
 // @Param fields  字段信息
+// @Param suggest  需要建立补全词典的字段及其权重字段
+// @Param vectors  向量字段的配置
+// @Param dates  毫秒日期字段的配置
 // @Return 新建的段
-func NewEmptySegmentByFieldsInfo(segmentName string, start uint64, fields map[string]uint64, logger *utils.Log) *Segment {
+func NewEmptySegmentByFieldsInfo(segmentName string, start uint64, fields map[string]uint64, suggest map[string]string, vectors map[string]VectorOption, dates map[string]DateOption, logger *utils.Log) *Segment {
 	seg := &Segment{
 		StartDocId:  start,
 		MaxDocId:    start,
 		SegmentName: segmentName,
 		FieldInfos:  fields,
+		SuggestInfo: make(map[string]string),
+		VectorInfo:  make(map[string]VectorOption),
+		DateInfo:    make(map[string]DateOption),
+		Version:     utils.SEGMENT_FORMAT_VERSION,
 		fields:      make(map[string]*Field),
+		suggesters:  make(map[string]*Suggester),
+		vectors:     make(map[string]*VectorField),
 		isMemory:    true,
 		btdb:        nil,
 		Logger:      logger,
 	}
 	for fieldName, fieldType := range fields {
+		if fieldType == utils.IDX_TYPE_VECTOR {
+			seg.VectorInfo[fieldName] = vectors[fieldName]
+			seg.vectors[fieldName] = NewEmptyVectorField(fieldName, vectors[fieldName], seg.Logger)
+			continue
+		}
 		f := NewEmptyField(fieldName, start, fieldType, seg.Logger)
+		if fieldType == utils.IDX_TYPE_DATETIME {
+			seg.DateInfo[fieldName] = dates[fieldName]
+			f.SetDateOption(dates[fieldName])
+		}
 		seg.fields[fieldName] = f
 	}
+	for fieldName, weightField := range suggest {
+		if fieldType, ok := fields[fieldName]; ok {
+			seg.SuggestInfo[fieldName] = weightField
+			seg.suggesters[fieldName] = NewEmptySuggester(fieldName, fieldType, weightField, seg.Logger)
+		}
+	}
 	seg.pfl = NewEmptyProfile(segmentName, start, seg.Logger)
 	return seg
 }
@@ -67,7 +97,12 @@ func NewSegmentFromLocalFile(segmentName string, flag bool, logger *utils.Log) *
 		MaxDocId:    0,
 		SegmentName: segmentName,
 		FieldInfos:  make(map[string]uint64),
+		SuggestInfo: make(map[string]string),
+		VectorInfo:  make(map[string]VectorOption),
+		DateInfo:    make(map[string]DateOption),
 		fields:      make(map[string]*Field),
+		suggesters:  make(map[string]*Suggester),
+		vectors:     make(map[string]*VectorField),
 		isMemory:    false,
 		btdb:        nil,
 		Logger:      logger,
@@ -87,9 +122,28 @@ func NewSegmentFromLocalFile(segmentName string, flag bool, logger *utils.Log) *
 		seg.btdb = tree.NewBTreeDB(btdbName)
 	}
 	for name := range seg.FieldInfos {
+		if seg.FieldInfos[name] == utils.IDX_TYPE_VECTOR {
+			if seg.MaxDocId-seg.StartDocId < utils.MAX_SEGMENT_SIZE && flag {
+				seg.vectors[name] = NewEmptyVectorField(name, seg.VectorInfo[name], seg.Logger)
+			} else {
+				seg.vectors[name] = NewVectorFieldFromLocalFile(name, segmentName, seg.VectorInfo[name], seg.Logger)
+			}
+			continue
+		}
 		nowField := NewFieldFromLocalFile(name, segmentName, seg.StartDocId, seg.MaxDocId, seg.FieldInfos[name], seg.btdb, flag, seg.Logger)
+		nowField.SetDateOption(seg.DateInfo[name])
+		if seg.Version < utils.SEGMENT_FORMAT_VERSION {
+			nowField.SetLegacyKeys()
+		}
 		seg.fields[name] = nowField
 	}
+	for name, weightField := range seg.SuggestInfo {
+		if seg.MaxDocId-seg.StartDocId < utils.MAX_SEGMENT_SIZE && flag {
+			seg.suggesters[name] = NewEmptySuggester(name, seg.FieldInfos[name], weightField, seg.Logger)
+		} else {
+			seg.suggesters[name] = NewSuggester(name, seg.FieldInfos[name], weightField, seg.btdb, seg.Logger)
+		}
+	}
 	mmap, err := utils.NewMmap(fmt.Sprintf("%v_profile.dtl", segmentName), utils.ModeAppend)
 	if err != nil {
 		fmt.Printf("[ERROR] Mmap error : %v\n", err)
@@ -103,13 +157,9 @@ func (seg *Segment) ReLoadSegment() error {
 	for i := seg.StartDocId; i < seg.MaxDocId; i++ {
 		document, exits := seg.GetDocument(i)
 		if exits {
-			for name, _ := range seg.fields {
-				if _, ok := document.Content[name]; ok {
-					if err := seg.fields[name].AddDocument(i, document.Content[name]); err != nil {
-						fmt.Printf("[ERROR] Segment AddDocument :: field[%v] value[%v] error[%v]\n", name, document.Content[name], err)
-					}
-				}
-			}
+			seg.addFields(i, document)
+			seg.addSuggest(document)
+			seg.addVectors(i, document)
 		}
 	}
 	seg.SetMemory()
@@ -127,8 +177,19 @@ func (seg *Segment) AddField(newField SimpleFieldInfo) error {
 	if seg.isMemory && !seg.IsEmpty() {
 		return errors.New("segment can't add field")
 	}
-	f := NewEmptyField(newField.FieldName, seg.StartDocId, newField.FieldType, seg.Logger)
 	seg.FieldInfos[newField.FieldName] = newField.FieldType
+	if newField.FieldType == utils.IDX_TYPE_VECTOR {
+		option := VectorOption{Dims: newField.Dims, Metric: newField.Metric}
+		seg.VectorInfo[newField.FieldName] = option
+		seg.vectors[newField.FieldName] = NewEmptyVectorField(newField.FieldName, option, seg.Logger)
+		return nil
+	}
+	f := NewEmptyField(newField.FieldName, seg.StartDocId, newField.FieldType, seg.Logger)
+	if newField.FieldType == utils.IDX_TYPE_DATETIME {
+		option := DateOption{Formats: newField.DateFormats, TimeZone: newField.TimeZone}
+		seg.DateInfo[newField.FieldName] = option
+		f.SetDateOption(option)
+	}
 	seg.fields[newField.FieldName] = f
 	return nil
 }
@@ -153,8 +214,15 @@ func (seg *Segment) DeleteField(fieldName string) error {
 	if seg.isMemory && !seg.IsEmpty() {
 		return errors.New("segment can't delete field")
 	}
-	seg.fields[fieldName].destroy()
+	if vf, ok := seg.vectors[fieldName]; ok {
+		vf.destroy()
+		delete(seg.VectorInfo, fieldName)
+		delete(seg.vectors, fieldName)
+	} else {
+		seg.fields[fieldName].destroy()
+	}
 	delete(seg.FieldInfos, fieldName)
+	delete(seg.DateInfo, fieldName)
 	delete(seg.fields, fieldName)
 	return nil
 }
@@ -168,13 +236,17 @@ func (seg *Segment) AddDocument(docId uint64, d *doc.Document) error {
 	if docId != seg.MaxDocId {
 		return errors.New("segment Maximum ID Mismatch")
 	}
-	for name, _ := range seg.fields {
-		if _, ok := d.Content[name]; ok {
-			if err := seg.fields[name].AddDocument(docId, d.Content[name]); err != nil {
-				fmt.Printf("[ERROR] Segment AddDocument :: field[%v] value[%v] error[%v]\n", name, d.Content[name], err)
+	// 向量维度不对时整篇文档都不写入，避免向量和文档对不上
+	for name, vf := range seg.vectors {
+		if vector, ok := d.Vectors[name]; ok {
+			if err := vf.Check(vector.Values); err != nil {
+				return err
 			}
 		}
 	}
+	seg.addFields(docId, d)
+	seg.addSuggest(d)
+	seg.addVectors(docId, d)
 	err := seg.pfl.AddDocument(docId, d)
 	if err != nil {
 		return err
@@ -183,6 +255,67 @@ func (seg *Segment) AddDocument(docId uint64, d *doc.Document) error {
 	return nil
 }
 
+// 内部方法，把文档的每个字段加入倒排。文档没有某个字段时也要调用，保证各个字段的 docId 连续
+func (seg *Segment) addFields(docId uint64, d *doc.Document) {
+	for name, field := range seg.fields {
+		values := d.FieldValues(name)
+		if err := field.AddValues(docId, values); err != nil {
+			fmt.Printf("[ERROR] Segment AddDocument :: field[%v] value[%v] error[%v]\n", name, values, err)
+		}
+	}
+}
+
+func (seg *Segment) addSuggest(d *doc.Document) {
+	for name, sg := range seg.suggesters {
+		// 权重可以是 Content 中的字符串，也可以是带类型的值，多值时取第一个
+		var weight string
+		if values := d.FieldValues(sg.weightField); len(values) > 0 {
+			weight = values[0]
+		}
+		for _, content := range d.FieldValues(name) {
+			sg.AddDocument(content, weight)
+		}
+	}
+}
+
+func (seg *Segment) addVectors(docId uint64, d *doc.Document) {
+	for name, vf := range seg.vectors {
+		if vector, ok := d.Vectors[name]; ok {
+			if err := vf.AddDocument(docId, vector.Values); err != nil {
+				fmt.Printf("[ERROR] Segment AddDocument :: vector[%v] error[%v]\n", name, err)
+			}
+		}
+	}
+}
+
+// Suggest
+// @Description 返回段内某个字段以 prefix 开头的补全词
+// @Param fieldName 字段名
+// @Param prefix 前缀
+// @Param size 最多返回多少条
+// @Return 补全结果，字段没有补全词典时返回 false
+func (seg *Segment) Suggest(fieldName, prefix string, size uint64) ([]*types.Suggestion, bool) {
+	sg, ok := seg.suggesters[fieldName]
+	if !ok {
+		return nil, false
+	}
+	return sg.Suggest(prefix, size), true
+}
+
+// FuzzyTerms
+// @Description 返回段内某个字段中与 word 相近的词项及其文档频率
+// @Param fieldName 字段名
+// @Param word 原词
+// @Param maxEdits 最大编辑距离
+// @Return map[词项]文档频率，字段不是文本类型时返回 false
+func (seg *Segment) FuzzyTerms(fieldName, word string, maxEdits int) (map[string]uint64, bool) {
+	field, ok := seg.fields[fieldName]
+	if !ok {
+		return nil, false
+	}
+	return field.FuzzyTerms(word, maxEdits)
+}
+
 // Serialization
 // @Description 序列化段
 // @Return 任何error
@@ -195,8 +328,18 @@ func (seg *Segment) Serialization() error {
 	if seg.btdb == nil {
 		seg.btdb = tree.NewBTreeDB(btdbName)
 	}
-	for fieldName := range seg.FieldInfos {
-		if err := seg.fields[fieldName].Serialization(seg.SegmentName, seg.btdb); err != nil {
+	for _, field := range seg.fields {
+		if err := field.Serialization(seg.SegmentName, seg.btdb); err != nil {
+			return err
+		}
+	}
+	for _, vf := range seg.vectors {
+		if err := vf.Serialization(seg.SegmentName); err != nil {
+			return err
+		}
+	}
+	for _, sg := range seg.suggesters {
+		if err := sg.Serialization(seg.btdb); err != nil {
 			return err
 		}
 	}
@@ -204,6 +347,7 @@ func (seg *Segment) Serialization() error {
 	if err != nil {
 		return err
 	}
+	seg.Version = utils.SEGMENT_FORMAT_VERSION
 	if err := seg.storeSegment(); err != nil {
 		return err
 	}
@@ -236,9 +380,16 @@ func (seg *Segment) GetDocument(docId uint64) (*doc.Document, bool) {
 // @Description 将段从内存中回收
 // @Return 任何error
 func (seg *Segment) Close() error {
+	DefaultFilterCache.InvalidateSegment(seg.SegmentName)
 	for _, field := range seg.fields {
 		field.destroy()
 	}
+	for _, sg := range seg.suggesters {
+		sg.destroy()
+	}
+	for _, vf := range seg.vectors {
+		vf.destroy()
+	}
 	if seg.btdb != nil {
 		err := seg.btdb.Close()
 		if err != nil {
@@ -269,38 +420,82 @@ func (seg *Segment) searchFilter(filters []*types.SearchFilters) (*roaring64.Bit
 	if filters == nil || len(filters) == 0 {
 		return nil, false
 	}
-	// Check if the first filter's field is present
-	firstField, ok := seg.fields[filters[0].FieldName]
-	if !ok {
-		return nil, false
-	}
-	// Apply the first filter
-	firstBitmap, exits := firstField.QueryFilter(filters[0])
-	if !exits {
-		return nil, false
+	return seg.filterAll(filters), true
+}
+
+// 内部方法，返回段内所有文档
+func (seg *Segment) allDocs() *roaring64.Bitmap {
+	bitmap := roaring64.New()
+	bitmap.AddRange(seg.StartDocId, seg.MaxDocId)
+	return bitmap
+}
+
+// 内部方法，所有条件都满足的文档，没有条件时为段内所有文档
+func (seg *Segment) filterAll(filters []*types.SearchFilters) *roaring64.Bitmap {
+	result := seg.allDocs()
+	for _, filter := range filters {
+		if result.IsEmpty() {
+			break
+		}
+		result.And(seg.cachedFilterNode(filter))
 	}
-	// Apply remaining filters
-	for _, filter := range filters[1:] {
-		field, ok := seg.fields[filter.FieldName]
-		if !ok {
-			return nil, false
+	return result
+}
+
+// 内部方法，已经序列化的段内容不会再变化，可以缓存的过滤条件从 DefaultFilterCache 中取结果
+func (seg *Segment) cachedFilterNode(filter *types.SearchFilters) *roaring64.Bitmap {
+	if seg.isMemory || !filter.Cacheable() {
+		return seg.filterNode(filter)
+	}
+	key := filter.CacheKey()
+	if bitmap, ok := DefaultFilterCache.Get(seg.SegmentName, key); ok {
+		return bitmap
+	}
+	bitmap := seg.filterNode(filter)
+	DefaultFilterCache.Put(seg.SegmentName, key, bitmap)
+	return bitmap
+}
+
+// 内部方法，计算过滤树中一个节点命中的文档。
+// 段中没有该字段（字段在段创建之后才加入索引）时，除了 FILT_MISSING 命中所有文档，其他条件都不命中
+func (seg *Segment) filterNode(filter *types.SearchFilters) *roaring64.Bitmap {
+	switch filter.Type {
+	case utils.FILT_AND:
+		return seg.filterAll(filter.Filters)
+	case utils.FILT_OR:
+		result := roaring64.New()
+		for _, child := range filter.Filters {
+			result.Or(seg.cachedFilterNode(child))
 		}
-		tempBitmap, exits := field.QueryFilter(filter)
-		if !exits {
-			return nil, false
+		return result
+	case utils.FILT_NOT:
+		result := seg.allDocs()
+		result.AndNot(seg.filterAll(filter.Filters))
+		return result
+	}
+	field, ok := seg.fields[filter.FieldName]
+	if !ok {
+		if filter.Type == utils.FILT_MISSING {
+			return seg.allDocs()
 		}
-		firstBitmap.And(tempBitmap)
+		return roaring64.New()
 	}
-
-	return firstBitmap, true
+	bitmap, ok := field.QueryFilter(filter)
+	if !ok {
+		return roaring64.New()
+	}
+	return bitmap
 }
 
 func (seg *Segment) search(query *types.TermQuery) *roaring64.Bitmap {
-
+	if query.Empty() {
+		return roaring64.NewBitmap()
+	}
 	if query.Keyword != nil {
-		bitMap, exits := seg.fields[query.Keyword.Field].Query(query.Keyword.Word)
-		if exits {
-			return bitMap
+		if field, ok := seg.fields[query.Keyword.Field]; ok {
+			if bitMap, exits := field.Query(query.Keyword.Word); exits && bitMap != nil {
+				return bitMap.Clone() // 内存段返回的是倒排列表本身，复制一份避免后续的 And 修改倒排
+			}
 		}
 		return roaring64.NewBitmap()
 	} else if len(query.Must) > 0 {
@@ -321,14 +516,7 @@ func (seg *Segment) search(query *types.TermQuery) *roaring64.Bitmap {
 
 func (seg *Segment) Search(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap) []*doc.Document {
 	docList := make([]*doc.Document, 0)
-	result := seg.search(query)
-
-	filterResult, exits := seg.searchFilter(filters)
-
-	if exits {
-		result.And(filterResult)
-	}
-	docIds := result.ToArray()
+	docIds := seg.match(query, filters).ToArray()
 	for _, docId := range docIds {
 		document, exits := seg.GetDocument(docId)
 		if exits && !deleteBitmap.Contains(docId) {
@@ -338,6 +526,90 @@ func (seg *Segment) Search(query *types.TermQuery, filters []*types.SearchFilter
 	return docList
 }
 
+// Count
+// @Description 只统计命中的文档数，完全由位图计算，不读取文档内容
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param deleteBitmap 已删除的文档
+// @Return 命中的文档数
+func (seg *Segment) Count(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap) uint64 {
+	return seg.Match(query, filters, deleteBitmap).GetCardinality()
+}
+
+// Match
+// @Description 返回满足关键词查询和过滤条件并且没有被删除的文档，关键词查询为空时只按过滤条件
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param deleteBitmap 已删除的文档，可以为空
+// @Return 命中的 docId，调用方可以修改
+func (seg *Segment) Match(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap) *roaring64.Bitmap {
+	result := seg.match(query, filters)
+	if deleteBitmap != nil {
+		result.AndNot(deleteBitmap)
+	}
+	return result
+}
+
+// MatchUncached
+// @Description 只按一个过滤条件匹配，不读写过滤结果缓存，用于每次条件都不同的内部查询，例如按当前时间清理过期文档
+// @Param filter 过滤条件
+// @Param deleteBitmap 已删除的文档，为 nil 时不排除
+// @Return 命中的文档
+func (seg *Segment) MatchUncached(filter *types.SearchFilters, deleteBitmap *roaring64.Bitmap) *roaring64.Bitmap {
+	result := seg.allDocs()
+	result.And(seg.filterNode(filter))
+	if deleteBitmap != nil {
+		result.AndNot(deleteBitmap)
+	}
+	return result
+}
+
+// SearchAfter
+// @Description 按 docId 升序分页返回命中的文档，只读取这一页的文档内容
+// @Param query 关键词查询
+// @Param filters 过滤条件
+// @Param deleteBitmap 已删除的文档
+// @Param after 只返回 docId 不小于 after 的文档
+// @Param size 最多返回多少条，为 0 时返回全部
+// @Return 文档以及对应的 docId
+func (seg *Segment) SearchAfter(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap, after, size uint64) ([]*doc.Document, []uint64) {
+	return seg.fetchAfter(seg.match(query, filters), deleteBitmap, after, size)
+}
+
+// 内部方法，去掉已删除的文档和 docId 小于 after 的文档，按 docId 升序读取最多 size 条文档
+func (seg *Segment) fetchAfter(result, deleteBitmap *roaring64.Bitmap, after, size uint64) ([]*doc.Document, []uint64) {
+	if deleteBitmap != nil {
+		result.AndNot(deleteBitmap)
+	}
+	if after > 0 {
+		result.RemoveRange(0, after)
+	}
+	docList := make([]*doc.Document, 0)
+	docIds := make([]uint64, 0)
+	iterator := result.Iterator()
+	for iterator.HasNext() && (size == 0 || uint64(len(docList)) < size) {
+		docId := iterator.Next()
+		if document, exits := seg.GetDocument(docId); exits {
+			docList = append(docList, document)
+			docIds = append(docIds, docId)
+		}
+	}
+	return docList, docIds
+}
+
+// 内部方法，关键词查询和过滤条件都满足的文档
+func (seg *Segment) match(query *types.TermQuery, filters []*types.SearchFilters) *roaring64.Bitmap {
+	result := seg.search(query)
+	filterResult, exits := seg.searchFilter(filters)
+	// 没有关键词查询时只按过滤条件检索，例如只按地理范围查找
+	if exits && query.Empty() {
+		result = filterResult
+	} else if exits {
+		result.And(filterResult)
+	}
+	return result
+}
+
 func (seg *Segment) SearchDocId(query *types.TermQuery, filters []*types.SearchFilters, deleteBitmap *roaring64.Bitmap) []uint64 {
 	docIds := make([]uint64, 0)
 	result := seg.search(query)
@@ -352,3 +624,86 @@ func (seg *Segment) SearchDocId(query *types.TermQuery, filters []*types.SearchF
 	}
 	return docIds
 }
+
+// DocFreq
+// @Description 返回段内某个字段中包含 term 的文档数
+func (seg *Segment) DocFreq(fieldName, term string) uint64 {
+	field, ok := seg.fields[fieldName]
+	if !ok {
+		return 0
+	}
+	bitmap, exits := field.Query(term)
+	if !exits || bitmap == nil {
+		return 0
+	}
+	return bitmap.GetCardinality()
+}
+
+// SearchScored
+// @Description 执行查询并为命中的文档打分，得分为命中的 Keyword 的 Boost 之和
+// @Param query 查询
+// @Param filters 过滤条件
+// @Param exclude 需要排除的文档，例如删除位图
+// @Return 命中文档的打分信息
+func (seg *Segment) SearchScored(query *types.TermQuery, filters []*types.SearchFilters, exclude *roaring64.Bitmap) []*types.Hit {
+	result := seg.search(query)
+	filterResult, exits := seg.searchFilter(filters)
+	if exits {
+		result.And(filterResult)
+	}
+	if exclude != nil {
+		result.AndNot(exclude)
+	}
+	scores := make(map[uint64]float64, result.GetCardinality())
+	seg.score(query, result, 1, scores)
+	hits := make([]*types.Hit, 0, len(scores))
+	iter := result.Iterator()
+	for iter.HasNext() {
+		docId := iter.Next()
+		hits = append(hits, &types.Hit{DocId: docId, Score: scores[docId]})
+	}
+	return hits
+}
+
+// 内部方法，把 query 中命中 candidates 的 Keyword 的权重累加到 scores 上，嵌套查询的权重相乘
+func (seg *Segment) score(query *types.TermQuery, candidates *roaring64.Bitmap, factor float64, scores map[uint64]float64) {
+	boost := factor
+	if query.Boost != 0 {
+		boost *= float64(query.Boost)
+	}
+	if query.Keyword != nil {
+		if field, ok := seg.fields[query.Keyword.Field]; ok {
+			if bitmap, exits := field.Query(query.Keyword.Word); exits && bitmap != nil {
+				iter := roaring64.And(bitmap, candidates).Iterator()
+				for iter.HasNext() {
+					scores[iter.Next()] += boost
+				}
+			}
+		}
+	}
+	for _, q := range query.Must {
+		seg.score(q, candidates, boost, scores)
+	}
+	for _, q := range query.Should {
+		seg.score(q, candidates, boost, scores)
+	}
+}
+
+// SearchKnn
+// @Description 在段内的向量字段上做 kNN 检索，可以与过滤条件组合
+// @Param knn kNN 查询，K 和 NumCandidates 需要调用方补齐默认值
+// @Param filters 过滤条件
+// @Param exclude 需要排除的文档，例如删除位图
+// @Return 按得分降序排列的结果，字段不是向量字段时返回 false
+func (seg *Segment) SearchKnn(knn *types.KnnQuery, filters []*types.SearchFilters, exclude *roaring64.Bitmap) ([]*types.Hit, bool) {
+	vf, ok := seg.vectors[knn.Field]
+	if !ok {
+		return nil, false
+	}
+	// 没有过滤条件时不限制候选文档，段内没有过滤字段时按 filterNode 的语义不命中
+	allowed, exits := seg.searchFilter(filters)
+	if !exits {
+		allowed = nil
+	}
+	return vf.Search(knn.Vector, int(knn.K), int(knn.NumCandidates), knn.Metric, allowed, exclude), true
+}
@@ -0,0 +1,150 @@
+/*****************************************************************************
+ *  file name : suggest.go
+ *  author : cyl
+ *  email  : 2842871262@qq.com
+ *  file description : 补全词典（search-as-you-type）
+ *
+******************************************************************************/
+
+package segment
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/internal/index/tree"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/utils"
+	"strconv"
+	"strings"
+)
+
+/************************************************************************
+
+补全词典，内存段用 map 保存，序列化时写入 B+树
+B+树  [fieldName]_suggest  key 为词项，value 为该词项的权重
+bolt 的 key 天然有序，按前缀 Seek 即可完成前缀扫描
+
+************************************************************************/
+
+// Suggester 某个字段在段内的补全词典
+type Suggester struct {
+	fieldName     string
+	fieldType     uint64
+	weightField   string
+	isMemory      bool
+	memoryHashMap map[Term]uint64 // key 为词项，value 为包含该词项的文档中最大的权重
+	btree         *tree.BTreeDB
+	logger        *utils.Log
+}
+
+func NewEmptySuggester(fieldName string, fieldType uint64, weightField string, logger *utils.Log) *Suggester {
+	return &Suggester{
+		fieldName:     fieldName,
+		fieldType:     fieldType,
+		weightField:   weightField,
+		isMemory:      true,
+		memoryHashMap: make(map[Term]uint64),
+		logger:        logger,
+	}
+}
+
+func NewSuggester(fieldName string, fieldType uint64, weightField string, btree *tree.BTreeDB, logger *utils.Log) *Suggester {
+	return &Suggester{
+		fieldName:   fieldName,
+		fieldType:   fieldType,
+		weightField: weightField,
+		isMemory:    false,
+		btree:       btree,
+		logger:      logger,
+	}
+}
+
+func (sg *Suggester) SetMemory() {
+	sg.isMemory = true
+	if sg.memoryHashMap == nil {
+		sg.memoryHashMap = make(map[Term]uint64)
+	}
+}
+
+// AddDocument
+// @Description 把文档中该字段的词项加入补全词典
+// @Param content 字段内容
+// @Param weightStr 权重字段的内容，解析失败时权重记为 0
+func (sg *Suggester) AddDocument(content string, weightStr string) {
+	if !sg.isMemory {
+		return
+	}
+	var weight uint64
+	if w, err := strconv.ParseFloat(weightStr, 64); err == nil && w > 0 {
+		weight = uint64(w)
+	}
+	var terms []string
+	if sg.fieldType == utils.IDX_TYPE_STRING_SEG {
+		segmented := utils.GetGseSegmenter()
+		terms = segmented.CutSearch(content, false)
+	} else {
+		terms = []string{content}
+	}
+	for _, term := range terms {
+		if len(strings.TrimSpace(term)) == 0 {
+			continue
+		}
+		if old, ok := sg.memoryHashMap[Term(term)]; !ok || weight > old {
+			sg.memoryHashMap[Term(term)] = weight
+		}
+	}
+}
+
+func (sg *Suggester) Serialization(btree *tree.BTreeDB) error {
+	btName := fmt.Sprintf("%v_suggest", sg.fieldName)
+	tx, err := btree.BeginTx()
+	if err != nil {
+		return err
+	}
+	b, err := tx.CreateBucketIfNotExists([]byte(btName))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for term, weight := range sg.memoryHashMap {
+		if err := btree.Put(b, term, weight); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := btree.Commit(tx); err != nil {
+		return err
+	}
+	sg.btree = btree
+	sg.memoryHashMap = nil
+	sg.isMemory = false
+	return nil
+}
+
+// Suggest
+// @Description 返回以 prefix 开头的词项
+// @Param prefix 前缀
+// @Param size 最多返回多少条，0 表示不限制
+// @Return 按权重降序排列的补全结果
+func (sg *Suggester) Suggest(prefix string, size uint64) []*types.Suggestion {
+	result := make([]*types.Suggestion, 0)
+	if sg.isMemory {
+		for term, weight := range sg.memoryHashMap {
+			if strings.HasPrefix(string(term), prefix) {
+				result = append(result, &types.Suggestion{Text: string(term), Weight: weight})
+			}
+		}
+	} else if sg.btree != nil {
+		btName := fmt.Sprintf("%v_suggest", sg.fieldName)
+		ok, terms, weights := sg.btree.SearchPrefix(btName, prefix)
+		if ok {
+			for i, term := range terms {
+				result = append(result, &types.Suggestion{Text: term, Weight: weights[i]})
+			}
+		}
+	}
+	return types.MergeSuggestions(size, result)
+}
+
+func (sg *Suggester) destroy() {
+	sg.memoryHashMap = nil
+}
@@ -0,0 +1,272 @@
+/*****************************************************************************
+ *  file name : vector.go
+ *  author : cyl
+ *  email  : 2842871262@qq.com
+ *  file description : 稠密向量字段
+ *
+******************************************************************************/
+
+package segment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/utils"
+	"math"
+	"os"
+)
+
+/************************************************************************
+
+向量字段，向量常驻内存
+[segmentName][fieldName]_vector.vec  向量文件，头部为维度，之后每条记录为 docId + dims 个 float32
+[segmentName][fieldName]_hnsw.idx    HNSW 图，段内向量数达到 HNSW_MIN_VECTORS 时才会生成
+
+************************************************************************/
+
+// VectorOption 向量字段的配置
+type VectorOption struct {
+	Dims   uint64 `json:"dims"`
+	Metric uint64 `json:"metric"`
+}
+
+type VectorField struct {
+	fieldName string
+	option    VectorOption
+	isMemory  bool
+	docIds    []uint64
+	vectors   [][]float32
+	graph     *hnswGraph
+	logger    *utils.Log
+}
+
+func NewEmptyVectorField(fieldName string, option VectorOption, logger *utils.Log) *VectorField {
+	if option.Metric == 0 {
+		option.Metric = utils.KNN_COSINE
+	}
+	return &VectorField{
+		fieldName: fieldName,
+		option:    option,
+		isMemory:  true,
+		docIds:    make([]uint64, 0),
+		vectors:   make([][]float32, 0),
+		logger:    logger,
+	}
+}
+
+func NewVectorFieldFromLocalFile(fieldName, segmentName string, option VectorOption, logger *utils.Log) *VectorField {
+	vf := NewEmptyVectorField(fieldName, option, logger)
+	vf.isMemory = false
+	vecFileName := fmt.Sprintf("%v%v_vector.vec", segmentName, fieldName)
+	if !utils.FileExist(vecFileName) {
+		return vf
+	}
+	mmap, err := utils.NewMmap(vecFileName, utils.ModeAppend)
+	if err != nil {
+		fmt.Printf("[ERROR] Mmap error : %v\n", err)
+		return vf
+	}
+	defer mmap.Unmap()
+	dims := mmap.ReadUInt64(8)
+	var pos uint64 = 16
+	for pos+8+dims*4 <= uint64(mmap.FilePointer) {
+		docId := mmap.ReadUInt64(pos)
+		pos += 8
+		vector := make([]float32, dims)
+		for i := range vector {
+			vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(mmap.MmapBytes[pos : pos+4]))
+			pos += 4
+		}
+		vf.docIds = append(vf.docIds, docId)
+		vf.vectors = append(vf.vectors, vector)
+	}
+	hnswFileName := fmt.Sprintf("%v%v_hnsw.idx", segmentName, fieldName)
+	if utils.FileExist(hnswFileName) {
+		buf, err := os.ReadFile(hnswFileName)
+		if err != nil {
+			fmt.Printf("[ERROR] read hnsw error : %v\n", err)
+			return vf
+		}
+		graph := &hnswGraph{}
+		if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(graph); err != nil {
+			fmt.Printf("[ERROR] decode hnsw error : %v\n", err)
+			return vf
+		}
+		graph.attach(vf.vectors, vf.option.Metric)
+		vf.graph = graph
+	}
+	return vf
+}
+
+// Check 检查向量的维度是否与字段定义一致
+func (vf *VectorField) Check(values []float32) error {
+	if len(values) == 0 {
+		return fmt.Errorf("vector field [%v] is empty", vf.fieldName)
+	}
+	if vf.option.Dims > 0 && uint64(len(values)) != vf.option.Dims {
+		return fmt.Errorf("vector field [%v] dims mismatch, want %v got %v", vf.fieldName, vf.option.Dims, len(values))
+	}
+	if vf.option.Dims == 0 && len(vf.vectors) > 0 && len(values) != len(vf.vectors[0]) {
+		return fmt.Errorf("vector field [%v] dims mismatch, want %v got %v", vf.fieldName, len(vf.vectors[0]), len(values))
+	}
+	return nil
+}
+
+func (vf *VectorField) AddDocument(docId uint64, values []float32) error {
+	if !vf.isMemory {
+		return fmt.Errorf("vector field [%v] is not in memory", vf.fieldName)
+	}
+	if err := vf.Check(values); err != nil {
+		return err
+	}
+	vector := make([]float32, len(values))
+	copy(vector, values)
+	vf.docIds = append(vf.docIds, docId)
+	vf.vectors = append(vf.vectors, vector)
+	return nil
+}
+
+func (vf *VectorField) Serialization(segmentName string) error {
+	vecFileName := fmt.Sprintf("%v%v_vector.vec", segmentName, vf.fieldName)
+	mmap, err := utils.NewMmap(vecFileName, utils.ModeCreate)
+	if err != nil {
+		return err
+	}
+	defer mmap.Unmap()
+	var dims uint64
+	if len(vf.vectors) > 0 {
+		dims = uint64(len(vf.vectors[0]))
+	}
+	if err := mmap.AppendUInt64(dims); err != nil {
+		return err
+	}
+	buf := make([]byte, dims*4)
+	for i, vector := range vf.vectors {
+		if err := mmap.AppendUInt64(vf.docIds[i]); err != nil {
+			return err
+		}
+		for j, v := range vector {
+			binary.LittleEndian.PutUint32(buf[j*4:], math.Float32bits(v))
+		}
+		if err := mmap.AppendBytes(buf); err != nil {
+			return err
+		}
+	}
+	if len(vf.vectors) >= utils.HNSW_MIN_VECTORS {
+		vf.graph = newHnswGraph(vf.vectors, vf.option.Metric)
+		var graphBuf bytes.Buffer
+		if err := gob.NewEncoder(&graphBuf).Encode(vf.graph); err != nil {
+			return err
+		}
+		hnswFileName := fmt.Sprintf("%v%v_hnsw.idx", segmentName, vf.fieldName)
+		if err := os.WriteFile(hnswFileName, graphBuf.Bytes(), 0664); err != nil {
+			return err
+		}
+	}
+	vf.isMemory = false
+	return nil
+}
+
+// Search
+// @Description kNN 检索，有 HNSW 图且相似度一致时走 HNSW，否则暴力检索
+// @Param query 查询向量
+// @Param k 返回的文档数
+// @Param numCandidates HNSW 的候选集大小
+// @Param metric 相似度，为 0 时使用字段定义的相似度
+// @Param allowed 允许返回的文档，为空时不限制
+// @Param exclude 需要排除的文档
+// @Return 按得分降序排列的结果
+func (vf *VectorField) Search(query []float32, k, numCandidates int, metric uint64, allowed, exclude *roaring64.Bitmap) []*types.Hit {
+	if metric == 0 {
+		metric = vf.option.Metric
+	}
+	accept := func(docId uint64) bool {
+		return (allowed == nil || allowed.Contains(docId)) && (exclude == nil || !exclude.Contains(docId))
+	}
+	// 过滤后剩余的文档很少时，HNSW 需要遍历大量被过滤的节点，不如直接暴力检索
+	useGraph := vf.graph != nil && metric == vf.option.Metric &&
+		(allowed == nil || allowed.GetCardinality() >= uint64(numCandidates))
+	hits := make([]*types.Hit, 0, k)
+	if useGraph {
+		items := vf.graph.Search(query, k, numCandidates, func(node int) bool {
+			return accept(vf.docIds[node])
+		})
+		for _, item := range items {
+			hits = append(hits, &types.Hit{DocId: vf.docIds[item.node], Score: vectorScore(metric, query, vf.vectors[item.node])})
+		}
+		if len(hits) >= k || allowed == nil {
+			return hits
+		}
+		hits = hits[:0]
+	}
+	for i, vector := range vf.vectors {
+		if !accept(vf.docIds[i]) {
+			continue
+		}
+		hits = append(hits, &types.Hit{DocId: vf.docIds[i], Score: vectorScore(metric, query, vector)})
+	}
+	types.SortHits(hits)
+	if len(hits) > k {
+		hits = hits[:k]
+	}
+	return hits
+}
+
+func (vf *VectorField) destroy() {
+	vf.docIds = nil
+	vf.vectors = nil
+	vf.graph = nil
+}
+
+// vectorDistance 返回 HNSW 使用的距离函数，距离越小越相似
+func vectorDistance(metric uint64) func(a, b []float32) float32 {
+	switch metric {
+	case utils.KNN_DOT:
+		return func(a, b []float32) float32 { return -dot(a, b) }
+	case utils.KNN_L2:
+		return squaredL2
+	default:
+		return func(a, b []float32) float32 { return 1 - cosine(a, b) }
+	}
+}
+
+// vectorScore 返回相似度得分，得分越大越相似
+func vectorScore(metric uint64, a, b []float32) float64 {
+	switch metric {
+	case utils.KNN_DOT:
+		return float64(dot(a, b))
+	case utils.KNN_L2:
+		return 1 / (1 + float64(squaredL2(a, b)))
+	default:
+		return float64(cosine(a, b))
+	}
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := 0; i < len(a) && i < len(b); i++ {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func squaredL2(a, b []float32) float32 {
+	var sum float32
+	for i := 0; i < len(a) && i < len(b); i++ {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func cosine(a, b []float32) float32 {
+	na, nb := dot(a, a), dot(b, b)
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot(a, b) / float32(math.Sqrt(float64(na)*float64(nb)))
+}
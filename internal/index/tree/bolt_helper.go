@@ -139,12 +139,8 @@ func (bh *BoltHelper) GetNextKV(btName string, key int64) ([]byte, string, error
 	var bkey []byte
 	bh.db.View(func(tx *bolt.Tx) error {
 
-		var s = make([]byte, 0)
-		buf := bytes.NewBuffer(s)
-		binary.Write(buf, binary.BigEndian, key)
-
 		b := tx.Bucket([]byte(btName)).Cursor()
-		b.Seek(buf.Bytes())
+		b.Seek(EncodeInt64Key(key))
 		bkey, value = b.Next()
 
 		return nil
@@ -176,20 +172,17 @@ func (bh *BoltHelper) GetFirstKV(btName string) ([]byte, string, error) {
 	return key, string(value), nil
 }
 func (bh *BoltHelper) GetRange(btName string, keyMin int64, keyMax int64) ([]string, error) {
-
-	var s1 = make([]byte, 0)
-	min := bytes.NewBuffer(s1)
-	binary.Write(min, binary.BigEndian, keyMin)
-
-	var s2 = make([]byte, 0)
-	max := bytes.NewBuffer(s2)
-	binary.Write(max, binary.BigEndian, keyMax)
+	min := EncodeInt64Key(keyMin)
+	max := EncodeInt64Key(keyMax)
 	res := make([]string, 0)
 	bh.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(btName))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
 
-		c := tx.Bucket([]byte(btName)).Cursor()
-
-		for k, v := c.Seek(min.Bytes()); k != nil && bytes.Compare(k, max.Bytes()) <= 0; k, v = c.Next() {
+		for k, v := c.Seek(min); k != nil && bytes.Compare(k, max) <= 0; k, v = c.Next() {
 			res = append(res, string(v))
 		}
 
@@ -199,6 +192,52 @@ func (bh *BoltHelper) GetRange(btName string, keyMin int64, keyMax int64) ([]str
 	return res, nil
 }
 
+// GetRangeKV 与 GetRange 相同，同时返回 key
+func (bh *BoltHelper) GetRangeKV(btName string, keyMin int64, keyMax int64) ([]int64, []string, error) {
+	min := EncodeInt64Key(keyMin)
+	max := EncodeInt64Key(keyMax)
+	keys := make([]int64, 0)
+	values := make([]string, 0)
+	err := bh.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(btName))
+		if b == nil {
+			return fmt.Errorf("table-name[%v] not found", btName)
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(min); k != nil && bytes.Compare(k, max) <= 0; k, v = c.Next() {
+			keys = append(keys, DecodeInt64Key(k))
+			values = append(values, string(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// GetPrefix 按前缀扫描，返回所有以 prefix 开头的 key 及其 value
+func (bh *BoltHelper) GetPrefix(btName string, prefix []byte) ([][]byte, []string, error) {
+	keys := make([][]byte, 0)
+	values := make([]string, 0)
+	err := bh.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(btName))
+		if b == nil {
+			return fmt.Errorf("table-name[%v] not found", btName)
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+			values = append(values, string(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
 func (bh *BoltHelper) Close() error {
 	return bh.db.Close()
 }
@@ -1,8 +1,6 @@
 package tree
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
 	"github.com/boltdb/bolt"
 	"strconv"
@@ -76,6 +74,45 @@ func (db *BTreeDB) SearchRange(btName string, keyMin, keyMax int64) (bool, []uin
 	return true, res
 }
 
+// SearchRangeKV 返回 B+树中 key 在 [keyMin, keyMax] 内的所有 key 以及对应的值
+func (db *BTreeDB) SearchRangeKV(btName string, keyMin, keyMax int64) (bool, []int64, []uint64) {
+	if keyMin > keyMax {
+		return false, nil, nil
+	}
+	keys, vstr, err := db.dbHelper.GetRangeKV(btName, keyMin, keyMax)
+	if err != nil {
+		return false, nil, nil
+	}
+	res := make([]uint64, 0, len(vstr))
+	for _, v := range vstr {
+		u, e := strconv.ParseUint(v, 10, 64)
+		if e != nil {
+			return false, nil, nil
+		}
+		res = append(res, u)
+	}
+	return len(res) > 0, keys, res
+}
+
+// SearchPrefix 返回 B+树中所有以 prefix 开头的 key 以及对应的值
+func (db *BTreeDB) SearchPrefix(btName string, prefix string) (bool, []string, []uint64) {
+	keys, vstr, err := db.dbHelper.GetPrefix(btName, []byte(prefix))
+	if err != nil || len(keys) == 0 {
+		return false, nil, nil
+	}
+	terms := make([]string, 0, len(keys))
+	res := make([]uint64, 0, len(keys))
+	for i, v := range vstr {
+		u, e := strconv.ParseUint(v, 10, 64)
+		if e != nil {
+			return false, nil, nil
+		}
+		terms = append(terms, string(keys[i]))
+		res = append(res, u)
+	}
+	return true, terms, res
+}
+
 func (db *BTreeDB) GetFirstKV(btName string) (int64, uint64, bool) {
 	key, vstr, err := db.dbHelper.GetFirstKV(btName)
 	if err != nil {
@@ -87,10 +124,7 @@ func (db *BTreeDB) GetFirstKV(btName string) (int64, uint64, bool) {
 		fmt.Printf("err: %v", err)
 		return -1, 0, false
 	}
-	buf := bytes.NewBuffer(key)
-	var kv int64
-	binary.Read(buf, binary.BigEndian, &kv)
-	return kv, u, true
+	return DecodeInt64Key(key), u, true
 }
 
 func (db *BTreeDB) GetNextKV(btname string, key int64) (int64, uint64, bool) {
@@ -103,10 +137,7 @@ func (db *BTreeDB) GetNextKV(btname string, key int64) (int64, uint64, bool) {
 		return -1, 0, false
 	}
 
-	buf := bytes.NewBuffer(vkey)
-	var kv int64
-	binary.Read(buf, binary.BigEndian, &kv)
-	return kv, u, true
+	return DecodeInt64Key(vkey), u, true
 }
 
 func (db *BTreeDB) Close() error {
@@ -0,0 +1,18 @@
+package tree
+
+import "encoding/binary"
+
+// EncodeInt64Key 把 int64 编码成可以按字节序比较的 key：大端序并翻转符号位，负数排在正数前面
+func EncodeInt64Key(n int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(n)^(1<<63))
+	return buf
+}
+
+// DecodeInt64Key EncodeInt64Key 的逆操作
+func DecodeInt64Key(buf []byte) int64 {
+	if len(buf) < 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(buf) ^ (1 << 63))
+}
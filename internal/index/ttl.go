@@ -0,0 +1,81 @@
+package index
+
+import (
+	"fmt"
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+	"time"
+)
+
+// SetTTL
+// @Description 设置文档的过期规则。只给出 defaultTTL 时自动加入 TTL_TIMESTAMP_FIELD 字段记录写入时间
+// @Param field 判断过期的日期字段，需要是 IDX_TYPE_DATE 或者 IDX_TYPE_DATETIME 类型
+// @Param defaultTTL 有效期，单位秒，为 0 时字段值即过期时间
+// @Return 字段不合法时返回错误
+func (idx *Index) SetTTL(field string, defaultTTL int64) error {
+	if defaultTTL < 0 {
+		return fmt.Errorf("default ttl of index [%v] can not be negative", idx.Name)
+	}
+	if field == "" && defaultTTL > 0 {
+		field = utils.TTL_TIMESTAMP_FIELD
+		if _, ok := idx.Fields[field]; !ok {
+			if err := idx.AddField(segment.SimpleFieldInfo{FieldName: field, FieldType: utils.IDX_TYPE_DATETIME}); err != nil {
+				return err
+			}
+		}
+	}
+	if field != "" {
+		fieldType, ok := idx.Fields[field]
+		if !ok {
+			return fmt.Errorf("ttl field [%v] not found in index [%v]", field, idx.Name)
+		}
+		if fieldType != utils.IDX_TYPE_DATE && fieldType != utils.IDX_TYPE_DATETIME {
+			return fmt.Errorf("ttl needs a date field, field [%v] is type %v", field, fieldType)
+		}
+	}
+	idx.TTLField = field
+	idx.DefaultTTL = defaultTTL
+	return idx.storeIndex()
+}
+
+// ExpireDocuments
+// @Description 在各个段中按日期范围找出已经过期的文档，加入删除位图并持久化。没有 TTLField 的文档不会过期
+// @Param now 当前时间
+// @Return 本次过期的文档数、错误
+func (idx *Index) ExpireDocuments(now time.Time) (uint64, error) {
+	if idx.TTLField == "" {
+		return 0, nil
+	}
+	cutoff := now.Add(-time.Duration(idx.DefaultTTL) * time.Second)
+	// IDX_TYPE_DATE 按秒存储，IDX_TYPE_DATETIME 按毫秒存储
+	filter := &types.SearchFilters{FieldName: idx.TTLField, Type: utils.FILT_LESS, Start: cutoff.UnixMilli()}
+	if idx.Fields[idx.TTLField] == utils.IDX_TYPE_DATE {
+		filter.Start = cutoff.Unix()
+	}
+	// 截止时间每次都不同，不经过过滤结果缓存，避免挤掉查询的缓存
+	expired, events := idx.deleteMatched(func(seg *segment.Segment) *roaring64.Bitmap {
+		return seg.MatchUncached(filter, idx.bitmap)
+	})
+	if expired == 0 {
+		return 0, nil
+	}
+	idx.recordChanges(events...)
+	idx.ExpiredDocNum += expired
+	return expired, idx.storeIndex()
+}
+
+// 内部方法，索引使用写入时间判断过期时，给没有写入时间的文档加上当前时间，不修改调用方的文档
+func (idx *Index) stampTimestamp(d *doc.Document) *doc.Document {
+	if idx.TTLField != utils.TTL_TIMESTAMP_FIELD || len(d.TypedValues(utils.TTL_TIMESTAMP_FIELD)) > 0 {
+		return d
+	}
+	fields := make(map[string]*doc.Value, len(d.Fields)+1)
+	for name, value := range d.Fields {
+		fields[name] = value
+	}
+	fields[utils.TTL_TIMESTAMP_FIELD] = &doc.Value{Kind: &doc.Value_Timestamp{Timestamp: time.Now().Unix()}}
+	return &doc.Document{Id: d.Id, Keywords: d.Keywords, Content: d.Content, Vectors: d.Vectors, Arrays: d.Arrays, Fields: fields}
+}
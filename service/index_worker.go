@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 var service *engine.IndexServiceWorker //IndexWorker，是一个grpc server
@@ -34,6 +35,9 @@ func Init() {
 		config.Config.Service.WorkerPort,
 		logger,
 	)
+	if interval := config.Config.Service.TTLReapInterval; interval > 0 {
+		service.StartTTLReaper(time.Duration(interval) * time.Second)
+	}
 }
 
 func StartWorker() {
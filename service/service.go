@@ -6,6 +6,7 @@ import (
 	"github.com/cylScripter/NexusFind/engine"
 	"google.golang.org/grpc"
 	"net"
+	"time"
 )
 
 var serviceApi *engine.Service
@@ -13,6 +14,9 @@ var serviceApi *engine.Service
 func StartService() {
 	server := grpc.NewServer()
 	serviceApi = engine.NewService("localhost", 50001, config.Config.Service.Etcd, logger)
+	if size := config.Config.Service.ResultCacheSize; size > 0 {
+		serviceApi.EnableResultCache(time.Duration(config.Config.Service.ResultCacheTTL)*time.Second, size)
+	}
 	engine.RegisterIndexServiceServer(server, serviceApi)
 	ls, err := net.Listen("tcp", fmt.Sprintf("%v:%v", service.LocalIP, 50001))
 	if err != nil {
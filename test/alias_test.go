@@ -0,0 +1,145 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+// 内部方法，创建别名测试使用的索引
+func createAliasTestIndex(t *testing.T, worker *engine.IndexServiceWorker, indexName string) {
+	t.Helper()
+	createTestIndex(t, worker, indexName,
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "title", FieldType: utils.IDX_TYPE_STRING, Suggest: true, SuggestWeight: "likes"},
+		&engine.SimpleFieldInfo{FieldName: "likes", FieldType: utils.IDX_TYPE_NUMBER},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+}
+
+func TestAliasReads(t *testing.T) {
+	worker := newTestWorker(t)
+	ctx := context.Background()
+	createAliasTestIndex(t, worker, "v1")
+	createAliasTestIndex(t, worker, "v2")
+	addTestDocs(t, worker, "v1", &doc.Document{Id: "1", Content: map[string]string{"title": "golang tutorial", "likes": "10", "tag": "search"}})
+	addTestDocs(t, worker, "v2", &doc.Document{Id: "2", Content: map[string]string{"title": "go concurrency", "likes": "50", "tag": "search"}})
+
+	code, err := worker.SwitchAlias(ctx, &engine.SwitchAliasRequest{Alias: "books", Indexes: []string{"v1", "v2"}, WriteIndex: "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code.PreviousIndexes) != 0 || code.PreviousWriteIndex != "" {
+		t.Fatalf("want no previous state for a new alias, got %v %v", code.PreviousIndexes, code.PreviousWriteIndex)
+	}
+
+	// 补全和纠错是读请求，覆盖别名指向的全部索引
+	suggest, err := worker.Suggest(ctx, &engine.SuggestRequest{IndexName: "books", Field: "title", Prefix: "go", Size: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suggest.Suggestions) != 2 || suggest.Suggestions[0].Text != "go concurrency" {
+		t.Fatalf("want suggestions from both indexes, got %v", suggest.Suggestions)
+	}
+	spell, err := worker.SpellCheck(ctx, &engine.SpellCheckRequest{IndexName: "books", Field: "tag", Text: "serch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spell.CorrectedText != "search" || len(spell.Corrections) != 1 || spell.Corrections[0].Candidates[0].DocFreq != 2 {
+		t.Fatalf("want search found in both indexes, got %q (%v)", spell.CorrectedText, spell.Corrections)
+	}
+
+	code, err = worker.SwitchAlias(ctx, &engine.SwitchAliasRequest{Alias: "books", Indexes: []string{"v1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(code.PreviousIndexes, []string{"v1", "v2"}) || code.PreviousWriteIndex != "v2" {
+		t.Fatalf("want previous state v1,v2 writing v2, got %v %v", code.PreviousIndexes, code.PreviousWriteIndex)
+	}
+	if _, err := worker.SwitchAlias(ctx, &engine.SwitchAliasRequest{Alias: "v2", Indexes: []string{"v1"}}); err == nil {
+		t.Fatal("want alias named after an index to be rejected")
+	}
+	if _, err := worker.CreateIndex(ctx, &engine.CreateIndexRequest{IndexName: "books"}); err == nil {
+		t.Fatal("want index named after an alias to be rejected")
+	}
+}
+
+func TestAliasConcurrentDrop(t *testing.T) {
+	worker := newTestWorker(t)
+	ctx := context.Background()
+	createAliasTestIndex(t, worker, "v1")
+	for i := 0; i < 50; i++ {
+		createAliasTestIndex(t, worker, "v2")
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			worker.DropIndex(ctx, &engine.DropIndexRequest{IndexName: "v2"})
+		}()
+		go func() {
+			defer wg.Done()
+			worker.SwitchAlias(ctx, &engine.SwitchAliasRequest{Alias: "books", Indexes: []string{"v1", "v2"}, WriteIndex: "v1"})
+		}()
+		wg.Wait()
+		// 别名指向的索引不能已经被删除
+		code, err := worker.SwitchAlias(ctx, &engine.SwitchAliasRequest{Alias: "books", Indexes: []string{"v1"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, genErr := worker.Generation(ctx, &engine.GenerationRequest{IndexName: "v2"})
+		if slices.Contains(code.PreviousIndexes, "v2") && genErr != nil {
+			t.Fatalf("round %v: alias points to dropped index v2", i)
+		}
+		if genErr == nil {
+			if _, err := worker.DropIndex(ctx, &engine.DropIndexRequest{IndexName: "v2"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestReindexJobPrune(t *testing.T) {
+	worker := newTestWorker(t)
+	ctx := context.Background()
+	createAliasTestIndex(t, worker, "src")
+	createAliasTestIndex(t, worker, "dst")
+	jobs := utils.REINDEX_MAX_FINISHED_JOBS + 2
+	for i := 0; i < jobs; i++ {
+		jobId := fmt.Sprintf("job-%v", i)
+		if _, err := worker.Reindex(ctx, &engine.ReindexRequest{JobId: jobId, Source: "src", Dest: "dst"}); err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			status, err := worker.GetReindexStatus(ctx, &engine.ReindexStatusRequest{JobId: jobId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Done {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("reindex job [%v] does not finish", jobId)
+			}
+			time.Sleep(time.Millisecond)
+		}
+		// 结束时间是毫秒，间隔开才能确定最早结束的任务
+		time.Sleep(2 * time.Millisecond)
+	}
+	// 启动最后一个任务前已经结束的任务超过上限，最早结束的任务被清理
+	if _, err := worker.GetReindexStatus(ctx, &engine.ReindexStatusRequest{JobId: "job-0"}); err == nil {
+		t.Fatal("want the earliest finished job to be pruned")
+	}
+	for i := 1; i < jobs; i++ {
+		if _, err := worker.GetReindexStatus(ctx, &engine.ReindexStatusRequest{JobId: fmt.Sprintf("job-%v", i)}); err != nil {
+			t.Fatalf("want job-%v to be kept, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestChangeLog(t *testing.T) {
+	fileName := t.TempDir() + "/test.changes"
+	cl, err := index.OpenChangeLog(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.SetSyncInterval(10 * time.Millisecond)
+	for i := 0; i < 3000; i++ {
+		if _, err := cl.Append(&types.ChangeEvent{Type: utils.CHANGE_ADD, DocId: uint64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := cl.ReadSince(2500, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 10 || events[0].Seq != 2500 || events[9].Seq != 2509 {
+		t.Fatalf("want seq 2500 to 2509, got %v events", len(events))
+	}
+
+	// 超过保留条数时截掉较早的一半，序号继续递增
+	cl.SetMaxEvents(1000)
+	if _, err := cl.Append(&types.ChangeEvent{Type: utils.CHANGE_DELETE}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ReadSince(10, 0); !errors.Is(err, index.ErrChangeLogTruncated) {
+		t.Fatalf("want truncated error, got %v", err)
+	}
+	events, err = cl.ReadSince(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 500 || events[0].Seq != 2502 || events[499].Seq != 3001 {
+		t.Fatalf("want the newest 500 events, got %v", len(events))
+	}
+	if err := cl.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err = index.OpenChangeLog(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Close()
+	if seq, err := cl.Append(&types.ChangeEvent{Type: utils.CHANGE_ADD}); err != nil || seq != 3002 {
+		t.Fatalf("want seq 3002 after reopen, got %v %v", seq, err)
+	}
+	events, err = cl.ReadSince(3000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[0].Seq != 3000 || events[2].Type != utils.CHANGE_ADD {
+		t.Fatalf("want seq 3000 to 3002, got %v", events)
+	}
+}
+
+func TestWriteWithoutChangeLog(t *testing.T) {
+	idx := index.NewEmptyIndex("nolog", t.TempDir()+"/", utils.NewLogger("test"))
+	defer idx.Close()
+	idx.SetFields([]segment.SimpleFieldInfo{{FieldName: "id", FieldType: utils.IDX_TYPE_PK}, {FieldName: "tag", FieldType: utils.IDX_TYPE_STRING}})
+	// 日志关闭之后文档仍然可以写入和删除
+	if err := idx.ChangeLog().Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.AddDocument(&doc.Document{Id: "1", Content: map[string]string{"tag": "go"}}); err != nil {
+		t.Fatalf("want add to succeed without change log, got %v", err)
+	}
+	if err := idx.DeleteDocument("1"); err != nil {
+		t.Fatalf("want delete to succeed without change log, got %v", err)
+	}
+	if count := idx.Count(types.NewTermQuery("tag", "go"), nil, 0); count != 0 {
+		t.Fatalf("want document deleted, got %v", count)
+	}
+}
+
+func TestConcurrentDeletes(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "deletes",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+	const docs = 400
+	for i := 0; i < docs; i++ {
+		addTestDocs(t, worker, "deletes", &doc.Document{Id: fmt.Sprintf("%v", i), Content: map[string]string{"tag": "all"}})
+	}
+	// 并发删除修改同一个删除位图和变更日志
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < docs; i += 8 {
+				if _, err := worker.Delete(context.Background(), &engine.DocIdRequest{IndexName: "deletes", DocId: fmt.Sprintf("%v", i)}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	count, err := worker.Count(context.Background(), &engine.SearchRequest{IndexName: "deletes", Query: types.NewTermQuery("tag", "all")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count.Count != 0 {
+		t.Fatalf("want every document deleted, got %v left", count.Count)
+	}
+}
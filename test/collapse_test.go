@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestCollapse(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "collapse",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+		&engine.SimpleFieldInfo{FieldName: "author", FieldType: utils.IDX_TYPE_STRING},
+	)
+	addTestDocs(t, worker, "collapse",
+		&doc.Document{Id: "src", Content: map[string]string{"content": "golang channel goroutine select", "author": "src"}},
+		&doc.Document{Id: "a1", Content: map[string]string{"content": "golang channel goroutine select", "author": "alice"}},
+		&doc.Document{Id: "a2", Content: map[string]string{"content": "golang channel goroutine", "author": "alice"}},
+		&doc.Document{Id: "a3", Content: map[string]string{"content": "golang channel", "author": "alice"}},
+		&doc.Document{Id: "b1", Content: map[string]string{"content": "golang", "author": "bob"}},
+	)
+	// 得分最高的两篇都来自 alice，先截断再折叠只会剩下一条
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "collapse",
+		MoreLikeThis: &types.MoreLikeThis{DocId: "src", Size: 2, Fields: []string{"content"}},
+		Collapse:     &types.Collapse{Field: "author"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 2 || result.DocResult[0].Id != "a1" || result.DocResult[1].Id != "b1" {
+		t.Fatalf("want a full page of a1 then b1, got %v", result.DocResult)
+	}
+	if len(result.Hits) != 2 || result.CollapseCounts["alice"] != 3 || result.CollapseCounts["bob"] != 1 {
+		t.Fatalf("want aligned hits and counts per author, got %v hits, counts %v", len(result.Hits), result.CollapseCounts)
+	}
+
+	result, err = worker.Search(context.Background(), &engine.SearchRequest{IndexName: "collapse",
+		MoreLikeThis: &types.MoreLikeThis{DocId: "src", Size: 2, Fields: []string{"content"}},
+		Collapse:     &types.Collapse{Field: "author", Size: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 2 || result.DocResult[0].Id != "a1" || result.DocResult[1].Id != "a2" {
+		t.Fatalf("want two documents per author truncated to the page, got %v", result.DocResult)
+	}
+}
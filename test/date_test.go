@@ -0,0 +1,65 @@
+package test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestDateFilter(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 秒级日期字段按本地时区解析，换成有夏令时的时区
+	local := time.Local
+	time.Local = newYork
+	defer func() { time.Local = local }()
+
+	idx := index.NewEmptyIndex("date", t.TempDir()+"/", utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "day", FieldType: utils.IDX_TYPE_DATE},
+		{FieldName: "at", FieldType: utils.IDX_TYPE_DATETIME, TimeZone: "Asia/Shanghai"},
+	})
+	defer idx.Close()
+	docs := []*doc.Document{
+		// 2024-03-10 是纽约夏令时开始的日子，只有 23 小时
+		{Id: "late", Content: map[string]string{"day": "2024-03-10 23:30:00", "at": "2024-03-10 23:30:00"}},
+		{Id: "next", Content: map[string]string{"day": "2024-03-11 00:30:00", "at": "2024-03-11 00:30:00"}},
+	}
+	for _, d := range docs {
+		if _, err := idx.AddDocument(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	midnight := time.Date(2024, 3, 10, 0, 0, 0, 0, newYork).Unix()
+	cases := []struct {
+		name   string
+		filter *types.SearchFilters
+		want   uint64
+	}{
+		{"dst day", &types.SearchFilters{FieldName: "day", Type: utils.FILT_EQ, Start: midnight}, 1},
+		{"day from noon", &types.SearchFilters{FieldName: "day", Type: utils.FILT_EQ, Start: midnight + 12*3600}, 1},
+		{"datetime day", &types.SearchFilters{FieldName: "at", Type: utils.FILT_EQ, StartDate: "2024-03-11"}, 1},
+		// 毫秒时间戳不在零点时也匹配字段时区内的整个自然日
+		{"datetime from timestamp", &types.SearchFilters{FieldName: "at", Type: utils.FILT_EQ, Start: time.Date(2024, 3, 11, 15, 4, 5, 6e6, shanghai).UnixMilli()}, 1},
+		{"datetime timestamp in other zone", &types.SearchFilters{FieldName: "at", Type: utils.FILT_EQ, Start: time.Date(2024, 3, 10, 12, 0, 0, 0, newYork).UnixMilli()}, 1},
+		{"datetime month", &types.SearchFilters{FieldName: "at", Type: utils.FILT_EQ, StartDate: "2024-03-01||/M"}, 2},
+	}
+	for _, c := range cases {
+		if count := idx.Count(nil, []*types.SearchFilters{c.filter}, 0); count != c.want {
+			t.Fatalf("%v: want %v documents, got %v", c.name, c.want, count)
+		}
+	}
+}
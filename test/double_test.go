@@ -0,0 +1,58 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestDoubleFilter(t *testing.T) {
+	idx := index.NewEmptyIndex("double", t.TempDir()+"/", utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "price", FieldType: utils.IDX_TYPE_DOUBLE},
+		{FieldName: "score", FieldType: utils.IDX_TYPE_FLOAT},
+	})
+	defer idx.Close()
+	prices := map[string]float64{"tiny": 0.000000001, "eighth": 0.125, "cheap": 19.99, "refund": -3.5}
+	for id, price := range prices {
+		d := &doc.Document{Id: id, Fields: map[string]*doc.Value{"price": {Kind: &doc.Value_Float{Float: price}}}}
+		if id == "cheap" {
+			d.Content = map[string]string{"score": "19.99"}
+		}
+		if _, err := idx.AddDocument(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name   string
+		filter *types.SearchFilters
+		want   uint64
+	}{
+		{"range", &types.SearchFilters{FieldName: "price", Type: utils.FILT_RANGE, StartFloat: 0.1, EndFloat: 20}, 2},
+		{"equal", &types.SearchFilters{FieldName: "price", Type: utils.FILT_EQ, StartFloat: 0.125}, 1},
+		{"negative", &types.SearchFilters{FieldName: "price", Type: utils.FILT_LESS, StartFloat: 0}, 1},
+		{"negative equal", &types.SearchFilters{FieldName: "price", Type: utils.FILT_EQ, StartFloat: -3.5}, 1},
+		{"across zero", &types.SearchFilters{FieldName: "price", Type: utils.FILT_RANGE, StartFloat: -10, EndFloat: 0.2}, 3},
+		{"negative range", &types.SearchFilters{FieldName: "price", Type: utils.FILT_RANGE, StartFloat: -3.6, EndFloat: -3.4}, 1},
+		{"precision", &types.SearchFilters{FieldName: "price", Type: utils.FILT_RANGE, StartFloat: 0, EndFloat: 0.00000001}, 1},
+		{"over", &types.SearchFilters{FieldName: "price", Type: utils.FILT_OVER, StartFloat: 0.000000001}, 3},
+		{"legacy float", &types.SearchFilters{FieldName: "score", Type: utils.FILT_EQ, Start: 1999}, 1},
+	}
+	check := func(stage string) {
+		for _, c := range cases {
+			if count := idx.Count(nil, []*types.SearchFilters{c.filter}, 0); count != c.want {
+				t.Fatalf("%v %v: want %v documents, got %v", stage, c.name, c.want, count)
+			}
+		}
+	}
+	check("memory segment")
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	check("disk segment")
+}
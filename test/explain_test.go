@@ -0,0 +1,58 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestExplainMustAndShould(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "explain",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "a", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "b", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "c", FieldType: utils.IDX_TYPE_STRING},
+	)
+	addTestDocs(t, worker, "explain",
+		&doc.Document{Id: "all", Content: map[string]string{"a": "x", "b": "x", "c": "x"}},
+		&doc.Document{Id: "must", Content: map[string]string{"a": "x", "b": "x"}},
+		&doc.Document{Id: "should", Content: map[string]string{"c": "x"}},
+	)
+	// 有 Must 时 Should 不决定是否命中，但命中的文档仍会累加 Should 的得分
+	query := &types.TermQuery{
+		Must:   []*types.TermQuery{types.NewTermQuery("a", "x"), types.NewTermQuery("b", "x")},
+		Should: []*types.TermQuery{types.NewTermQuery("c", "x")},
+	}
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "explain", Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[string]bool)
+	for _, d := range result.DocResult {
+		found[d.Id] = true
+	}
+	if len(found) != 2 || !found["all"] || !found["must"] {
+		t.Fatalf("want all and must from search, got %v", found)
+	}
+	for id, want := range map[string]struct {
+		matched bool
+		score   float64
+	}{"all": {true, 3}, "must": {true, 2}, "should": {false, 0}} {
+		explained, err := worker.Explain(context.Background(), &engine.ExplainRequest{IndexName: "explain", DocId: id, Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		explanation := explained.Explanation
+		if explanation.Matched != want.matched || explanation.Score != want.score || explanation.Matched != found[id] {
+			t.Fatalf("%v: want matched %v with score %v, got %v", id, want.matched, want.score, explanation)
+		}
+		if len(explanation.Query.Details) != 2 || explanation.Query.Details[0].Clause != "must" || explanation.Query.Details[1].Clause != "should" {
+			t.Fatalf("%v: want must and should clauses explained, got %v", id, explanation.Query)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestFilterTree(t *testing.T) {
+	idx := index.NewEmptyIndex("tree", t.TempDir()+"/", utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "year", FieldType: utils.IDX_TYPE_NUMBER},
+		{FieldName: "price", FieldType: utils.IDX_TYPE_NUMBER},
+	})
+	defer idx.Close()
+	for _, d := range []*doc.Document{
+		{Id: "a", Content: map[string]string{"year": "2020", "price": "10"}},
+		{Id: "b", Content: map[string]string{"year": "2021", "price": "20"}},
+		{Id: "c", Content: map[string]string{"year": "2022", "price": "30"}},
+	} {
+		if _, err := idx.AddDocument(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 落盘之后再新增字段，旧段中没有 tag 字段
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.AddField(segment.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_NUMBER}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.AddDocument(&doc.Document{Id: "d", Content: map[string]string{"year": "2023", "tag": "1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	eq := func(field string, value int64) *types.SearchFilters {
+		return &types.SearchFilters{FieldName: field, Type: utils.FILT_EQ, Start: value}
+	}
+	or := &types.SearchFilters{Type: utils.FILT_OR, Filters: []*types.SearchFilters{eq("year", 2020), eq("price", 30)}}
+	cases := []struct {
+		name   string
+		filter *types.SearchFilters
+		want   uint64
+	}{
+		{"or", or, 2},
+		{"and not", &types.SearchFilters{Type: utils.FILT_AND, Filters: []*types.SearchFilters{
+			{FieldName: "year", Type: utils.FILT_RANGE, Start: 2021, End: 2030},
+			{Type: utils.FILT_NOT, Filters: []*types.SearchFilters{eq("price", 20)}},
+		}}, 2},
+		{"not or", &types.SearchFilters{Type: utils.FILT_NOT, Filters: []*types.SearchFilters{or}}, 2},
+		{"field missing from old segment", eq("tag", 1), 1},
+		{"not on field missing from old segment", &types.SearchFilters{Type: utils.FILT_NOT, Filters: []*types.SearchFilters{eq("tag", 1)}}, 3},
+		{"missing", &types.SearchFilters{FieldName: "tag", Type: utils.FILT_MISSING}, 3},
+	}
+	for _, c := range cases {
+		if count := idx.Count(nil, []*types.SearchFilters{c.filter}, 0); count != c.want {
+			t.Fatalf("%v: want %v documents, got %v", c.name, c.want, count)
+		}
+	}
+	unknown := &types.SearchFilters{Type: utils.FILT_OR, Filters: []*types.SearchFilters{eq("year", 2020), eq("color", 1)}}
+	if err := idx.ValidateFilters([]*types.SearchFilters{unknown}); err == nil {
+		t.Fatal("want error for nested filter on unknown field")
+	}
+}
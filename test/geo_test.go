@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestGeoFilter(t *testing.T) {
+	dir := t.TempDir() + "/"
+	idx := index.NewEmptyIndex("geo", dir, utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "location", FieldType: utils.IDX_TYPE_GEO},
+		{FieldName: "price", FieldType: utils.IDX_TYPE_NUMBER},
+	})
+	defer idx.Close()
+	places := map[string]string{"tiananmen": "39.9087,116.3975", "wangfujing": "39.9150,116.4110", "shanghai": "31.2304,121.4737"}
+	for id, location := range places {
+		if _, err := idx.AddDocument(&doc.Document{Id: id, Content: map[string]string{"location": location, "price": "-5"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	distance := []*types.SearchFilters{{FieldName: "location", Type: utils.FILT_GEO_DISTANCE,
+		GeoDistance: &types.GeoDistance{Center: &types.GeoPoint{Lat: 39.9087, Lon: 116.3975}, Distance: 5000}}}
+	bbox := []*types.SearchFilters{{FieldName: "location", Type: utils.FILT_GEO_BBOX,
+		GeoBoundingBox: &types.GeoBoundingBox{TopLeft: &types.GeoPoint{Lat: 32, Lon: 121}, BottomRight: &types.GeoPoint{Lat: 31, Lon: 122}}}}
+	negative := []*types.SearchFilters{{FieldName: "price", Type: utils.FILT_EQ, Start: -5}}
+	check := func(stage string) {
+		if count := idx.Count(nil, distance, 0); count != 2 {
+			t.Fatalf("%v: want 2 documents within 5km, got %v", stage, count)
+		}
+		if count := idx.Count(nil, bbox, 0); count != 1 {
+			t.Fatalf("%v: want 1 document in bounding box, got %v", stage, count)
+		}
+		if count := idx.Count(nil, negative, 0); count != 3 {
+			t.Fatalf("%v: want 3 documents with negative price, got %v", stage, count)
+		}
+	}
+	check("memory segment")
+	// 落盘后走磁盘上按字节序 Seek 的范围查询
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	check("disk segment")
+}
@@ -0,0 +1,44 @@
+package test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+// 内部方法，在 IDX_ROOT_PATH 下启动一个不注册到 etcd 的 worker，测试结束后删除索引文件
+func newTestWorker(t *testing.T) *engine.IndexServiceWorker {
+	t.Helper()
+	if err := os.RemoveAll(utils.IDX_ROOT_PATH); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(utils.IDX_ROOT_PATH, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(utils.IDX_ROOT_PATH) })
+	worker := new(engine.IndexServiceWorker)
+	worker.Init([]string{"localhost:2379"}, 2, "127.0.0.1", 0, utils.NewLogger("test"))
+	return worker
+}
+
+// 内部方法，创建索引，失败时结束测试
+func createTestIndex(t *testing.T, worker *engine.IndexServiceWorker, indexName string, fields ...*engine.SimpleFieldInfo) {
+	t.Helper()
+	if _, err := worker.CreateIndex(context.Background(), &engine.CreateIndexRequest{IndexName: indexName, FieldInfo: fields}); err != nil {
+		t.Fatalf("create index [%v] failed: %v", indexName, err)
+	}
+}
+
+// 内部方法，写入文档，失败时结束测试
+func addTestDocs(t *testing.T, worker *engine.IndexServiceWorker, indexName string, docs ...*doc.Document) {
+	t.Helper()
+	for _, d := range docs {
+		if _, err := worker.Add(context.Background(), &engine.AddRequest{IndexName: indexName, Doc: d}); err != nil {
+			t.Fatalf("add document [%v] failed: %v", d.Id, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestHybridSearch(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "hybrid",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "emb", FieldType: utils.IDX_TYPE_VECTOR, Dims: 2, Metric: utils.KNN_L2},
+	)
+	addTestDocs(t, worker, "hybrid",
+		&doc.Document{Id: "both", Content: map[string]string{"tag": "go"}, Vectors: map[string]*doc.Vector{"emb": {Values: []float32{0, 0}}}},
+		&doc.Document{Id: "lexical", Content: map[string]string{"tag": "go"}, Vectors: map[string]*doc.Vector{"emb": {Values: []float32{9, 9}}}},
+		&doc.Document{Id: "vector", Content: map[string]string{"tag": "rust"}, Vectors: map[string]*doc.Vector{"emb": {Values: []float32{0.1, 0}}}},
+	)
+	request := &engine.SearchRequest{
+		IndexName: "hybrid",
+		Query:     types.NewTermQuery("tag", "go"),
+		Knn:       &types.KnnQuery{Field: "emb", Vector: []float32{0, 0}, K: 2},
+		Fusion:    &types.Fusion{Method: utils.FUSION_RRF, Size: 2},
+	}
+	result, err := worker.Search(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 2 || result.DocResult[0].Id != "both" {
+		t.Fatalf("want the document ranked by both lists first and Size results, got %v", result.DocResult)
+	}
+	hit := result.Hits[0]
+	if hit.LexicalRank != 1 || hit.VectorRank != 1 {
+		t.Fatalf("want both ranks recorded on the fused hit, got %v", hit)
+	}
+}
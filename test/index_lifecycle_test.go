@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestConcurrentCreateAndDrop(t *testing.T) {
+	worker := newTestWorker(t)
+	fields := []*engine.SimpleFieldInfo{{FieldName: "id", FieldType: utils.IDX_TYPE_PK}, {FieldName: "tag", FieldType: utils.IDX_TYPE_STRING}}
+	// 不停地读取正在被创建、删除的索引
+	stop := make(chan struct{})
+	readers := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					worker.Count(context.Background(), &engine.SearchRequest{IndexName: "life_0", Query: types.NewTermQuery("tag", "go")})
+				}
+			}
+		}()
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("life_%v", i)
+			for j := 0; j < 20; j++ {
+				// 其他协程同时创建、删除索引时，各自的索引仍然可以正常读写
+				if _, err := worker.CreateIndex(context.Background(), &engine.CreateIndexRequest{IndexName: name, FieldInfo: fields}); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := worker.Add(context.Background(), &engine.AddRequest{IndexName: name, Doc: &doc.Document{Id: "1", Content: map[string]string{"tag": "go"}}}); err != nil {
+					t.Error(err)
+					return
+				}
+				count, err := worker.Count(context.Background(), &engine.SearchRequest{IndexName: name, Query: types.NewTermQuery("tag", "go")})
+				if err != nil || count.Count != 1 {
+					t.Errorf("want the only document of the recreated index, got %v %v", count, err)
+					return
+				}
+				// 内存段中还没有落盘的文档随索引一起删除
+				if _, err := worker.DropIndex(context.Background(), &engine.DropIndexRequest{IndexName: name}); err != nil {
+					t.Error(err)
+					return
+				}
+				// 删除之后的请求返回错误而不是使用已经关闭的索引
+				if _, err := worker.Count(context.Background(), &engine.SearchRequest{IndexName: name, Query: types.NewTermQuery("tag", "go")}); err == nil {
+					t.Error("want an error after drop")
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+}
+
+func TestDeleteByQueryBatchesChanges(t *testing.T) {
+	idx := index.NewEmptyIndex("batch", t.TempDir()+"/", utils.NewLogger("test"))
+	defer idx.Close()
+	idx.SetFields([]segment.SimpleFieldInfo{{FieldName: "id", FieldType: utils.IDX_TYPE_PK}, {FieldName: "tag", FieldType: utils.IDX_TYPE_STRING}})
+	for i := 0; i < 5; i++ {
+		tag := "go"
+		if i == 4 {
+			tag = "py"
+		}
+		if _, err := idx.AddDocument(&doc.Document{Id: fmt.Sprint(i), Content: map[string]string{"tag": tag}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleted, err := idx.DeleteByQuery(types.NewTermQuery("tag", "go"), nil)
+	if err != nil || deleted != 4 || idx.DelDocNum != 4 {
+		t.Fatalf("want 4 deleted, got %v %v, DelDocNum %v", deleted, err, idx.DelDocNum)
+	}
+	events, err := idx.ChangeLog().ReadSince(6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 一次删除的变更序号连续、时间相同，主键与删除的文档对应
+	if len(events) != 4 {
+		t.Fatalf("want 4 delete events, got %v", len(events))
+	}
+	for i, event := range events {
+		if event.Type != utils.CHANGE_DELETE || event.Seq != uint64(6+i) || event.PrimaryKey != fmt.Sprint(i) || event.Timestamp != events[0].Timestamp {
+			t.Fatalf("unexpected delete event %v", event)
+		}
+	}
+	if count := idx.Count(types.NewTermQuery("tag", "py"), nil, 0); count != 1 {
+		t.Fatalf("want the py document kept, got %v", count)
+	}
+}
+
+func TestReopenAndClose(t *testing.T) {
+	path := t.TempDir() + "/"
+	idx := index.NewEmptyIndex("reopen", path, utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := idx.AddDocument(&doc.Document{Id: fmt.Sprintf("%v", i), Content: map[string]string{"tag": "go"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// 重新打开后未写满的段同时是内存段，关闭时只能关闭一次
+	idx = index.NewIndexFromLocalFile("reopen", path, utils.NewLogger("test"))
+	if docs := idx.Search(types.NewTermQuery("tag", "go"), nil, nil); len(docs) != 3 {
+		t.Fatalf("want 3 documents after reopen, got %v", len(docs))
+	}
+	if err := idx.Close(); err != nil {
+		t.Fatalf("want reopened index to close cleanly, got %v", err)
+	}
+}
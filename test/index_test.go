@@ -24,13 +24,13 @@ var path string = "./data/"
 var indexName string = "test"
 
 var FieldInfo []segment.SimpleFieldInfo = []segment.SimpleFieldInfo{
-	{"id", utils.IDX_TYPE_PK},
-	{"times", utils.IDX_TYPE_DATE},
-	{"likeCount", utils.IDX_TYPE_NUMBER},
-	{"content", utils.IDX_TYPE_STRING_SEG},
-	{"category", utils.IDX_TYPE_STRING_SEG},
-	{"url", utils.IDX_TYPE_DESC},
-	{"author", utils.IDX_TYPE_STRING},
+	{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+	{FieldName: "times", FieldType: utils.IDX_TYPE_DATE},
+	{FieldName: "likeCount", FieldType: utils.IDX_TYPE_NUMBER},
+	{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+	{FieldName: "category", FieldType: utils.IDX_TYPE_STRING_SEG},
+	{FieldName: "url", FieldType: utils.IDX_TYPE_DESC},
+	{FieldName: "author", FieldType: utils.IDX_TYPE_STRING},
 }
 
 func TestEmptyIndex(t *testing.T) {
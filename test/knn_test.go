@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestKnn(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "knn",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "emb", FieldType: utils.IDX_TYPE_VECTOR, Dims: 2, Metric: utils.KNN_L2},
+	)
+	vectors := map[string][]float32{"a": {0, 0}, "b": {1, 0}, "c": {5, 5}, "d": {0.1, 0.1}}
+	for id, values := range vectors {
+		addTestDocs(t, worker, "knn", &doc.Document{Id: id, Vectors: map[string]*doc.Vector{"emb": {Values: values}}})
+	}
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "knn", Knn: &types.KnnQuery{Field: "emb", Vector: []float32{0, 0}, K: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 2 || result.DocResult[0].Id != "a" || result.DocResult[1].Id != "d" {
+		t.Fatalf("want nearest a then d, got %v", result.DocResult)
+	}
+	if result.Hits[0].Score < result.Hits[1].Score {
+		t.Fatalf("want hits ordered by score, got %v", result.Hits)
+	}
+	if _, err := worker.CreateIndex(context.Background(), &engine.CreateIndexRequest{IndexName: "bad", FieldInfo: []*engine.SimpleFieldInfo{{FieldName: "emb", FieldType: utils.IDX_TYPE_VECTOR}}}); err == nil {
+		t.Fatal("want error for vector field without dims")
+	}
+}
+
+func TestKnnEdgeCases(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "knn",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "group", FieldType: utils.IDX_TYPE_NUMBER},
+		&engine.SimpleFieldInfo{FieldName: "emb", FieldType: utils.IDX_TYPE_VECTOR, Dims: 2, Metric: utils.KNN_L2},
+	)
+	addTestDocs(t, worker, "knn",
+		&doc.Document{Id: "a", Content: map[string]string{"group": "1"}, Vectors: map[string]*doc.Vector{"emb": {Values: []float32{0, 0}}}},
+		&doc.Document{Id: "b", Content: map[string]string{"group": "2"}, Vectors: map[string]*doc.Vector{"emb": {Values: []float32{1, 0}}}},
+		&doc.Document{Id: "novec", Content: map[string]string{"group": "2"}},
+	)
+	search := func(knn *types.KnnQuery, filters ...*types.SearchFilters) (*engine.Result, error) {
+		return worker.Search(context.Background(), &engine.SearchRequest{IndexName: "knn", Knn: knn, Filter: filters})
+	}
+	if _, err := search(&types.KnnQuery{Field: "missing", Vector: []float32{0, 0}}); err == nil {
+		t.Fatal("want error for a missing vector field")
+	}
+	if _, err := search(&types.KnnQuery{Field: "group", Vector: []float32{0, 0}}); err == nil {
+		t.Fatal("want error for a field that is not a vector")
+	}
+	if _, err := search(&types.KnnQuery{Field: "emb", Vector: []float32{0, 0, 0}}); err == nil {
+		t.Fatal("want error for a query vector with the wrong dims")
+	}
+	// 过滤之后只剩下 b，没有向量的文档不参与
+	result, err := search(&types.KnnQuery{Field: "emb", Vector: []float32{0, 0}, K: 5}, &types.SearchFilters{FieldName: "group", Type: utils.FILT_EQ, Start: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 1 || result.DocResult[0].Id != "b" {
+		t.Fatalf("want only b after filtering, got %v", result.DocResult)
+	}
+	if _, err := worker.Add(context.Background(), &engine.AddRequest{IndexName: "knn", Doc: &doc.Document{Id: "bad", Vectors: map[string]*doc.Vector{"emb": {Values: []float32{1}}}}}); err == nil {
+		t.Fatal("want error for a document vector with the wrong dims")
+	}
+}
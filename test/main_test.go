@@ -24,7 +24,7 @@ func TestConn(t *testing.T) {
 		grpc.WithBlock(),
 	)
 	if err != nil {
-		fmt.Println(conn)
+		t.Skipf("index service is not running: %v", err) // 需要先启动 service 才能连接
 	}
 	var fields []*engine.SimpleFieldInfo
 	fields = make([]*engine.SimpleFieldInfo, 0)
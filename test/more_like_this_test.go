@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestMoreLikeThis(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "mlt",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+	)
+	addTestDocs(t, worker, "mlt",
+		&doc.Document{Id: "go1", Content: map[string]string{"content": "golang channel goroutine scheduler"}},
+		&doc.Document{Id: "go2", Content: map[string]string{"content": "golang goroutine channel select"}},
+		&doc.Document{Id: "py1", Content: map[string]string{"content": "python django template"}},
+	)
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "mlt", MoreLikeThis: &types.MoreLikeThis{DocId: "go1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) == 0 || len(result.Hits) != len(result.DocResult) {
+		t.Fatalf("want scored similar documents, got %v docs and %v hits", len(result.DocResult), len(result.Hits))
+	}
+	for _, d := range result.DocResult {
+		if d.Id == "go1" {
+			t.Fatal("source document should not be returned")
+		}
+		if d.Id == "py1" {
+			t.Fatal("unrelated document should not be returned")
+		}
+	}
+	if result.DocResult[0].Id != "go2" || result.Hits[0].Score <= 0 {
+		t.Fatalf("want go2 with a positive score first, got %v %v", result.DocResult[0].Id, result.Hits[0])
+	}
+}
+
+func TestMoreLikeThisEdgeCases(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "mlt",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+	)
+	addTestDocs(t, worker, "mlt",
+		&doc.Document{Id: "go1", Content: map[string]string{"content": "golang channel goroutine scheduler"}},
+		&doc.Document{Id: "py1", Content: map[string]string{"content": "python django template"}},
+	)
+	search := func(mlt *types.MoreLikeThis) (*engine.Result, error) {
+		return worker.Search(context.Background(), &engine.SearchRequest{IndexName: "mlt", MoreLikeThis: mlt})
+	}
+	// 临时文档不在索引中，不排除任何文档
+	result, err := search(&types.MoreLikeThis{Like: &doc.Document{Content: map[string]string{"content": "django template"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 1 || result.DocResult[0].Id != "py1" {
+		t.Fatalf("want py1 for an ad-hoc document, got %v", result.DocResult)
+	}
+	// 临时文档没有可选的词时返回空结果
+	result, err = search(&types.MoreLikeThis{Like: &doc.Document{Content: map[string]string{"content": ""}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 0 {
+		t.Fatalf("want no result for empty like text, got %v", result.DocResult)
+	}
+	if _, err := search(&types.MoreLikeThis{DocId: "missing"}); err == nil {
+		t.Fatal("want error for a missing source document")
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+// 内部方法，创建数组测试使用的索引并写入文档，落盘之后检索的是磁盘段
+func newArrayTestIndex(t *testing.T, docs ...*doc.Document) *index.Index {
+	t.Helper()
+	idx := index.NewEmptyIndex("arrays", t.TempDir()+"/", utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "tags", FieldType: utils.IDX_TYPE_STRING, Suggest: true},
+		{FieldName: "years", FieldType: utils.IDX_TYPE_NUMBER},
+	})
+	t.Cleanup(func() { idx.Close() })
+	for _, d := range docs {
+		if _, err := idx.AddDocument(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	return idx
+}
+
+// 内部方法，返回排序后的主键
+func searchIds(idx *index.Index, query *types.TermQuery, filters ...*types.SearchFilters) []string {
+	ids := make([]string, 0)
+	for _, d := range idx.Search(query, filters, nil) {
+		ids = append(ids, d.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestMultiValueFields(t *testing.T) {
+	idx := newArrayTestIndex(t,
+		&doc.Document{Id: "1", Arrays: map[string]*doc.Values{
+			"tags":  {Values: []string{"科技", "计算机技术", "并发"}},
+			"years": {Values: []string{"2019", "2023"}},
+		}},
+		&doc.Document{Id: "2", Content: map[string]string{"tags": "计算机技术"}, Arrays: map[string]*doc.Values{
+			"years": {Typed: []*doc.Value{{Kind: &doc.Value_Int{Int: 2021}}}},
+		}},
+	)
+	if ids := searchIds(idx, types.NewTermQuery("tags", "并发")); len(ids) != 1 || ids[0] != "1" {
+		t.Fatalf("want any array element to match exactly, got %v", ids)
+	}
+	if ids := searchIds(idx, types.NewTermQuery("tags", "计算机技术")); len(ids) != 2 {
+		t.Fatalf("want content and array values to be indexed together, got %v", ids)
+	}
+	if ids := searchIds(idx, types.NewTermQuery("tags", "计算机")); len(ids) != 0 {
+		t.Fatalf("want no partial match on a string field, got %v", ids)
+	}
+	if ids := searchIds(idx, nil, &types.SearchFilters{FieldName: "years", Type: utils.FILT_EQ, Start: 2023}); len(ids) != 1 || ids[0] != "1" {
+		t.Fatalf("want filter to match any numeric element, got %v", ids)
+	}
+	if ids := searchIds(idx, nil, &types.SearchFilters{FieldName: "years", Type: utils.FILT_EQ, Start: 2021}); len(ids) != 1 || ids[0] != "2" {
+		t.Fatalf("want filter to match typed array element, got %v", ids)
+	}
+}
+
+func TestMultiValueEdgeCases(t *testing.T) {
+	idx := newArrayTestIndex(t,
+		&doc.Document{Id: "1", Arrays: map[string]*doc.Values{
+			"tags":  {Values: []string{"golang", "gopher"}},
+			"years": {Values: []string{"2019", "2023"}},
+		}},
+		&doc.Document{Id: "empty", Arrays: map[string]*doc.Values{"tags": {}, "years": {}}},
+	)
+	// 多个元素都满足范围时文档只返回一次
+	if ids := searchIds(idx, nil, &types.SearchFilters{FieldName: "years", Type: utils.FILT_RANGE, Start: 2000, End: 2030}); len(ids) != 1 || ids[0] != "1" {
+		t.Fatalf("want document 1 once, got %v", ids)
+	}
+	// 空数组不命中任何值，也不影响其他文档
+	if ids := searchIds(idx, types.NewTermQuery("tags", "")); len(ids) != 0 {
+		t.Fatalf("want empty arrays to index nothing, got %v", ids)
+	}
+	// 每个元素都进入补全词典
+	suggestions, err := idx.Suggest("tags", "go", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("want every element suggested, got %v", suggestions)
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestPercolatorOnAdd(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "percolate",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+	register := &engine.PercolatorRequest{IndexName: "percolate", Query: &types.PercolateQuery{Id: "go", Query: types.NewTermQuery("tag", "go")}}
+	if _, err := worker.RegisterPercolator(context.Background(), register); err != nil {
+		t.Fatal(err)
+	}
+	add := func(id, tag string) *engine.Code {
+		code, err := worker.Add(context.Background(), &engine.AddRequest{IndexName: "percolate", Doc: &doc.Document{Id: id, Content: map[string]string{"tag": tag}}})
+		if err != nil {
+			t.Fatalf("want add to succeed, got %v", err)
+		}
+		if code.PercolateError != "" {
+			t.Fatalf("want no percolate error, got %v", code.PercolateError)
+		}
+		return code
+	}
+	if code := add("1", "go"); fmt.Sprint(code.Percolated) != "[go]" {
+		t.Fatalf("want query go to be matched, got %v", code.Percolated)
+	}
+	if code := add("2", "py"); len(code.Percolated) != 0 {
+		t.Fatalf("want no query to be matched, got %v", code.Percolated)
+	}
+	if _, err := worker.DeletePercolator(context.Background(), register); err != nil {
+		t.Fatal(err)
+	}
+	if code := add("3", "go"); len(code.Percolated) != 0 {
+		t.Fatalf("want no query after delete, got %v", code.Percolated)
+	}
+	count, err := worker.Count(context.Background(), &engine.SearchRequest{IndexName: "percolate", Query: types.NewTermQuery("tag", "go")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count.Count != 2 {
+		t.Fatalf("want both go documents stored, got %v", count.Count)
+	}
+}
+
+func TestPercolatorRejectsBadQueryFields(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "percolate",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "year", FieldType: utils.IDX_TYPE_NUMBER},
+	)
+	for _, query := range []*types.TermQuery{
+		types.NewTermQuery("missing", "go"),
+		types.NewTermQuery("year", "2024"),
+		types.NewTermQuery("tag", "go").And(types.NewTermQuery("missing", "go")),
+	} {
+		register := &engine.PercolatorRequest{IndexName: "percolate", Query: &types.PercolateQuery{Id: "bad", Query: query}}
+		if _, err := worker.RegisterPercolator(context.Background(), register); err == nil {
+			t.Fatalf("want query %v to be rejected", query)
+		}
+	}
+	// 没有注册成功的查询，后续写入不受影响
+	addTestDocs(t, worker, "percolate", &doc.Document{Id: "1", Content: map[string]string{"tag": "go", "year": "2024"}})
+
+	// 检索不存在的字段不命中任何文档，也不会使 worker 崩溃
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "percolate", Query: types.NewTermQuery("missing", "go")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 0 {
+		t.Fatalf("want no document for a missing field, got %v", result.DocResult)
+	}
+	if _, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "percolate",
+		MoreLikeThis: &types.MoreLikeThis{DocId: "1", Fields: []string{"missing"}}}); err == nil {
+		t.Fatal("want more like this on a missing field to be rejected")
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestProfile(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "profile",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+	)
+	addTestDocs(t, worker, "profile",
+		&doc.Document{Id: "1", Content: map[string]string{"tag": "go", "content": "golang channel goroutine"}},
+		&doc.Document{Id: "2", Content: map[string]string{"tag": "go", "content": "golang goroutine select"}},
+		&doc.Document{Id: "3", Content: map[string]string{"tag": "py", "content": "python django"}},
+	)
+	ids := func(result *engine.Result) string {
+		out := make([]string, 0, len(result.DocResult))
+		for _, d := range result.DocResult {
+			out = append(out, d.Id)
+		}
+		return fmt.Sprint(out, len(result.Hits), result.TotalHits)
+	}
+	for name, request := range map[string]*engine.SearchRequest{
+		"search":         {Query: types.NewTermQuery("tag", "go"), TrackTotalHits: true},
+		"paging":         {Query: types.NewTermQuery("tag", "go"), Size: 1},
+		"more_like_this": {MoreLikeThis: &types.MoreLikeThis{DocId: "1", Fields: []string{"content"}}, TrackTotalHits: true},
+	} {
+		request.IndexName = "profile"
+		plain, err := worker.Search(context.Background(), request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Profile = true
+		profiled, err := worker.Search(context.Background(), request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 记录耗时不改变返回的结果
+		if ids(plain) != ids(profiled) || plain.Profile != nil {
+			t.Fatalf("%v: want the same results with and without profile, got %v and %v", name, ids(plain), ids(profiled))
+		}
+		shards := profiled.Profile.GetShards()
+		if len(shards) != 1 || len(shards[0].Segments) == 0 || len(shards[0].Segments[0].Entries) == 0 {
+			t.Fatalf("%v: want segment timings, got %v", name, profiled.Profile)
+		}
+		if name == "more_like_this" && shards[0].Segments[0].Entries[0].Name != "more_like_this" {
+			t.Fatalf("want scored query timings, got %v", shards[0].Segments[0].Entries)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestProjection(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "project",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "title", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "url", FieldType: utils.IDX_TYPE_DESC},
+		&engine.SimpleFieldInfo{FieldName: "tags", FieldType: utils.IDX_TYPE_STRING},
+	)
+	addTestDocs(t, worker, "project", &doc.Document{
+		Id:       "1",
+		Keywords: []*doc.KeyWord{{Word: "go", WordTF: 1}},
+		Content:  map[string]string{"title": "go", "url": "http://example.com"},
+		Arrays:   map[string]*doc.Values{"tags": {Values: []string{"a", "b"}}},
+	})
+	search := func(request *engine.SearchRequest) *doc.Document {
+		request.IndexName = "project"
+		request.Query = types.NewTermQuery("title", "go")
+		request.Size = 10
+		result, err := worker.Search(context.Background(), request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.DocResult) != 1 || result.DocResult[0].Id != "1" {
+			t.Fatalf("want document 1 with its id kept, got %v", result.DocResult)
+		}
+		return result.DocResult[0]
+	}
+	d := search(&engine.SearchRequest{IncludeFields: []string{"title"}})
+	if d.Content["title"] != "go" || len(d.Content) != 1 || len(d.Arrays) != 0 {
+		t.Fatalf("want only title, got %v", d)
+	}
+	d = search(&engine.SearchRequest{IncludeFields: []string{"title", "tags"}, ExcludeFields: []string{"title"}})
+	if _, ok := d.Content["title"]; ok || len(d.Arrays["tags"].GetValues()) != 2 {
+		t.Fatalf("want exclude to win over include and arrays to be kept, got %v", d)
+	}
+	d = search(&engine.SearchRequest{ExcludeKeywords: true})
+	if len(d.Keywords) != 0 || d.Content["url"] == "" {
+		t.Fatalf("want keywords dropped and other fields kept, got %v", d)
+	}
+	// 裁剪的是返回的副本，不影响存储的文档
+	d = search(&engine.SearchRequest{})
+	if len(d.Keywords) != 1 || len(d.Content) != 2 {
+		t.Fatalf("want stored document unchanged, got %v", d)
+	}
+}
+
+func TestProjectionEdgeCases(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "project",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "title", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "url", FieldType: utils.IDX_TYPE_DESC},
+	)
+	addTestDocs(t, worker, "project", &doc.Document{
+		Id:       "1",
+		Keywords: []*doc.KeyWord{{Word: "go", WordTF: 1}},
+		Content:  map[string]string{"title": "go", "url": "http://example.com"},
+	})
+	get := func(request *engine.DocIdRequest) *doc.Document {
+		request.IndexName = "project"
+		request.DocId = "1"
+		result, err := worker.Get(context.Background(), request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Doc.Id != "1" {
+			t.Fatalf("want id kept, got %v", result.Doc)
+		}
+		return result.Doc
+	}
+	if d := get(&engine.DocIdRequest{IncludeFields: []string{"url"}, ExcludeKeywords: true}); len(d.Content) != 1 || d.Content["url"] == "" || len(d.Keywords) != 0 {
+		t.Fatalf("want only url from get, got %v", d)
+	}
+	// 不存在的字段不报错，只保留主键
+	if d := get(&engine.DocIdRequest{IncludeFields: []string{"missing"}}); len(d.Content) != 0 {
+		t.Fatalf("want no content for an unknown include field, got %v", d)
+	}
+	if d := get(&engine.DocIdRequest{ExcludeFields: []string{"title", "url"}}); len(d.Content) != 0 || len(d.Keywords) != 1 {
+		t.Fatalf("want all content excluded and keywords kept, got %v", d)
+	}
+}
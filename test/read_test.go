@@ -24,7 +24,7 @@ func randString(n int) string {
 }
 
 func TestMn(t *testing.T) {
-	result := make([]doc2.Document, 0)
+	result := make([]*doc2.Document, 0)
 	// 打开CSV文件
 	file, err := os.Open("bili_video.csv")
 	if err != nil {
@@ -38,7 +38,6 @@ func TestMn(t *testing.T) {
 	// 逐行读取
 	for {
 		data := make(map[string]string)
-		var document doc2.Document
 		record, err := reader.Read()
 		if err != nil {
 			if err.Error() == "EOF" {
@@ -61,7 +60,7 @@ func TestMn(t *testing.T) {
 		data["author"] = record[3]
 		data["url"] = record[0]
 		data["category"] = record[9]
-		document = doc2.Document{
+		document := &doc2.Document{
 			Id:       data["id"],
 			Keywords: make([]*doc2.KeyWord, 0),
 			Content:  data,
@@ -75,8 +74,8 @@ func TestMn(t *testing.T) {
 	}
 }
 
-func ReadData() []doc2.Document {
-	result := make([]doc2.Document, 0)
+func ReadData() []*doc2.Document {
+	result := make([]*doc2.Document, 0)
 	segg := utils.GetGseSegmenter()
 	// 打开CSV文件
 	file, err := os.Open("bili_video.csv")
@@ -91,7 +90,6 @@ func ReadData() []doc2.Document {
 	// 逐行读取
 	for {
 		data := make(map[string]string)
-		var document doc2.Document
 		record, err := reader.Read()
 		if err != nil {
 			if err.Error() == "EOF" {
@@ -115,7 +113,7 @@ func ReadData() []doc2.Document {
 		data["author"] = record[3]
 		data["url"] = record[0]
 		data["category"] = record[9]
-		document = doc2.Document{
+		document := &doc2.Document{
 			Id:       data["id"],
 			Keywords: doc2.TF(keys),
 			Content:  data,
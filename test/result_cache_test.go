@@ -0,0 +1,71 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types/doc"
+)
+
+func TestResultCache(t *testing.T) {
+	cache := engine.NewResultCache(time.Minute, 2)
+	loads := 0
+	load := func() (string, bool) {
+		loads++
+		return "w1=1", true
+	}
+	endpoints := []string{"w2", "w1"}
+	for i := 0; i < 3; i++ {
+		if fingerprint, ok := cache.Fingerprint("books", endpoints, load); !ok || fingerprint != "w1=1" {
+			t.Fatalf("want fingerprint w1=1, got %v %v", fingerprint, ok)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("want worker generations to be fetched once within ttl, got %v", loads)
+	}
+	// worker 列表顺序不同也是同一组 worker，worker 增减时重新取得
+	cache.Fingerprint("books", []string{"w1", "w2"}, load)
+	cache.Fingerprint("books", []string{"w1"}, load)
+	if loads != 2 {
+		t.Fatalf("want a new fetch only when workers change, got %v", loads)
+	}
+	cache.InvalidateGenerations()
+	cache.Fingerprint("books", endpoints, load)
+	if loads != 3 {
+		t.Fatalf("want a new fetch after invalidation, got %v", loads)
+	}
+	cache.SetGenerationTTL(0)
+	cache.Fingerprint("books", endpoints, load)
+	cache.Fingerprint("books", endpoints, load)
+	if loads != 5 {
+		t.Fatalf("want every lookup to fetch without generation ttl, got %v", loads)
+	}
+
+	result := &engine.Result{DocResult: []*doc.Document{{Id: "1"}}}
+	cache.Put("q", "w1=1", result)
+	if cached, hit := cache.Get("q", "w1=1"); !hit || cached.DocResult[0].Id != "1" {
+		t.Fatalf("want cached result, got %v %v", cached, hit)
+	}
+	if _, hit := cache.Get("q", "w1=2"); hit {
+		t.Fatal("want result to be dropped when generation changes")
+	}
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 0 || stats.Capacity != 2 {
+		t.Fatalf("unexpected result cache stats %v", stats)
+	}
+	if stats.GenerationHits != 3 || stats.GenerationMisses != 5 {
+		t.Fatalf("unexpected generation stats %v", stats)
+	}
+
+	// 直接访问 worker 时没有 Sentinel 的结果缓存
+	worker := newTestWorker(t)
+	workerResult, err := worker.Stats(context.Background(), &engine.StatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workerResult.ResultCache != nil {
+		t.Fatalf("want no result cache stats from a worker, got %v", workerResult.ResultCache)
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestSearchAfter(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "paging",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+	for i := 0; i < 7; i++ {
+		tag := "even"
+		if i%2 == 1 {
+			tag = "odd"
+		}
+		addTestDocs(t, worker, "paging", &doc.Document{Id: fmt.Sprintf("%v", i), Content: map[string]string{"tag": tag}})
+	}
+	query := types.NewTermQuery("tag", "even")
+	var cursor *types.Cursor
+	seen := make([]string, 0)
+	pages := 0
+	for {
+		result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "paging", Query: query, Size: 2, SearchAfter: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.DocResult) == 0 {
+			break
+		}
+		pages++
+		if len(result.DocResult) > 2 || len(result.Hits) != len(result.DocResult) {
+			t.Fatalf("want at most 2 aligned results per page, got %v docs and %v hits", len(result.DocResult), len(result.Hits))
+		}
+		for _, d := range result.DocResult {
+			seen = append(seen, d.Id)
+		}
+		// 游标为本页最后一条结果，与 Sentinel 返回的 NextCursor 相同
+		last := result.Hits[len(result.Hits)-1]
+		cursor = &types.Cursor{Sort: types.CursorSortKey(last, false, nil), DocId: last.DocId, Shard: result.Shard}
+		if pages > 4 {
+			t.Fatal("paging does not terminate")
+		}
+	}
+	want := []string{"0", "2", "4", "6"}
+	if pages != 2 || fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Fatalf("want %v in 2 pages, got %v in %v pages", want, seen, pages)
+	}
+}
+
+func TestSearchAfterEdgeCases(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "paging",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+	for i := 0; i < 4; i++ {
+		addTestDocs(t, worker, "paging", &doc.Document{Id: fmt.Sprintf("%v", i), Content: map[string]string{"tag": "all"}})
+	}
+	query := types.NewTermQuery("tag", "all")
+	page := func(cursor *types.Cursor) *engine.Result {
+		result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "paging", Query: query, Size: 2, SearchAfter: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	next := func(result *engine.Result) *types.Cursor {
+		last := result.Hits[len(result.Hits)-1]
+		return &types.Cursor{Sort: types.CursorSortKey(last, false, nil), DocId: last.DocId, Shard: result.Shard}
+	}
+	first := page(nil)
+	if len(first.DocResult) != 2 || first.DocResult[0].Id != "0" {
+		t.Fatalf("want 0 and 1 on the first page, got %v", first.DocResult)
+	}
+	// 翻页之间删除已经返回的文档，下一页不跳过也不重复
+	if _, err := worker.Delete(context.Background(), &engine.DocIdRequest{IndexName: "paging", DocId: "0"}); err != nil {
+		t.Fatal(err)
+	}
+	second := page(next(first))
+	if len(second.DocResult) != 2 || second.DocResult[0].Id != "2" || second.DocResult[1].Id != "3" {
+		t.Fatalf("want 2 and 3 on the second page, got %v", second.DocResult)
+	}
+	// 游标已经在最后一条时返回空页
+	if last := page(next(second)); len(last.DocResult) != 0 {
+		t.Fatalf("want an empty page after the last hit, got %v", last.DocResult)
+	}
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestSetFilters(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "sets",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "author", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "year", FieldType: utils.IDX_TYPE_NUMBER},
+		&engine.SimpleFieldInfo{FieldName: "price", FieldType: utils.IDX_TYPE_DOUBLE},
+	)
+	addTestDocs(t, worker, "sets",
+		&doc.Document{Id: "1", Content: map[string]string{"author": "alice", "year": "2020", "price": "9.99"}},
+		&doc.Document{Id: "2", Content: map[string]string{"author": "bob", "year": "2021", "price": "10"}},
+		&doc.Document{Id: "3", Content: map[string]string{"author": "carol", "price": "0.5"}},
+		&doc.Document{Id: "4", Content: map[string]string{"author": "dave", "year": "2022", "price": "-3.5"}},
+	)
+	search := func(filter *types.SearchFilters) []string {
+		result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "sets", Filter: []*types.SearchFilters{filter}, Size: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]string, 0)
+		for _, d := range result.DocResult {
+			ids = append(ids, d.Id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	cases := []struct {
+		name   string
+		filter *types.SearchFilters
+		want   []string
+	}{
+		{"terms", &types.SearchFilters{FieldName: "author", Type: utils.FILT_TERMS, Terms: []string{"carol", "alice", "erin"}}, []string{"1", "3"}},
+		{"in", &types.SearchFilters{FieldName: "year", Type: utils.FILT_IN, Range: []int64{2021, 1999}}, []string{"2"}},
+		{"in float", &types.SearchFilters{FieldName: "price", Type: utils.FILT_IN, RangeFloat: []float64{9.99, 0.5}}, []string{"1", "3"}},
+		{"in negative float", &types.SearchFilters{FieldName: "price", Type: utils.FILT_IN, RangeFloat: []float64{-3.5, 3.5}}, []string{"4"}},
+		{"in negative integer", &types.SearchFilters{FieldName: "price", Type: utils.FILT_IN, Range: []int64{-3, -4}}, []string{}},
+		{"in float integer", &types.SearchFilters{FieldName: "price", Type: utils.FILT_IN, Range: []int64{10}}, []string{"2"}},
+		{"exists", &types.SearchFilters{FieldName: "year", Type: utils.FILT_EXISTS}, []string{"1", "2", "4"}},
+		{"missing", &types.SearchFilters{FieldName: "year", Type: utils.FILT_MISSING}, []string{"3"}},
+	}
+	for _, c := range cases {
+		// 第二次查询命中过滤缓存，结果要和第一次一样
+		for round := 0; round < 2; round++ {
+			if ids := search(c.filter); len(ids) != len(c.want) || (len(ids) > 0 && ids[0] != c.want[0]) {
+				t.Fatalf("%v round %v: want %v, got %v", c.name, round, c.want, ids)
+			}
+		}
+	}
+	_, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "sets",
+		Filter: []*types.SearchFilters{{FieldName: "year", Type: utils.FILT_IN, RangeFloat: []float64{2020.5}}}})
+	if err == nil {
+		t.Fatal("want error for RangeFloat on an integer field")
+	}
+}
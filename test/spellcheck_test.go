@@ -0,0 +1,40 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestSpellCheck(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "spell",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+	)
+	addTestDocs(t, worker, "spell",
+		&doc.Document{Id: "1", Content: map[string]string{"tag": "search"}},
+		&doc.Document{Id: "2", Content: map[string]string{"tag": "search"}},
+		&doc.Document{Id: "3", Content: map[string]string{"tag": "seared"}},
+	)
+	result, err := worker.SpellCheck(context.Background(), &engine.SpellCheckRequest{IndexName: "spell", Field: "tag", Text: "serch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.CorrectedText != "search" {
+		t.Fatalf("want serch corrected to search, got %q (%v)", result.CorrectedText, result.Corrections)
+	}
+	// 候选词必须与原词的首字符相同
+	result, err = worker.SpellCheck(context.Background(), &engine.SpellCheckRequest{IndexName: "spell", Field: "tag", Text: "xearch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, correction := range result.Corrections {
+		if len(correction.Candidates) > 0 {
+			t.Fatalf("want no candidate outside the fuzzy prefix, got %v", correction.Candidates)
+		}
+	}
+}
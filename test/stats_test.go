@@ -0,0 +1,59 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+// 内部方法，取得 worker 的统计信息，worker 只返回自己的一条
+func workerStats(t *testing.T, worker *engine.IndexServiceWorker) *engine.WorkerStats {
+	t.Helper()
+	result, err := worker.Stats(context.Background(), &engine.StatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Workers) != 1 || result.Workers[0].Shard != "127.0.0.1:0" {
+		t.Fatalf("want stats of one worker, got %v", result.Workers)
+	}
+	return result.Workers[0]
+}
+
+func TestFilterCacheStats(t *testing.T) {
+	worker := newTestWorker(t)
+	before := workerStats(t, worker).FilterCache
+
+	// 过滤结果缓存只缓存已经落盘的段
+	idx := index.NewEmptyIndex("stats", t.TempDir()+"/", utils.NewLogger("test"))
+	idx.SetFields([]segment.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "year", FieldType: utils.IDX_TYPE_NUMBER},
+	})
+	defer idx.Close()
+	if _, err := idx.AddDocument(&doc.Document{Id: "1", Content: map[string]string{"year": "2024"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	filters := []*types.SearchFilters{{FieldName: "year", Type: utils.FILT_EQ, Start: 2024}}
+	for i := 0; i < 2; i++ {
+		if count := idx.Count(nil, filters, 0); count != 1 {
+			t.Fatalf("want 1 document, got %v", count)
+		}
+	}
+
+	after := workerStats(t, worker).FilterCache
+	if after.Misses != before.Misses+1 || after.Hits != before.Hits+1 {
+		t.Fatalf("want one miss then one hit, before %v after %v", before, after)
+	}
+	if after.Entries == 0 || after.Bytes == 0 || after.Capacity == 0 {
+		t.Fatalf("want cached entries to be reported, got %v", after)
+	}
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestSuggest(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "suggest",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "title", FieldType: utils.IDX_TYPE_STRING, Suggest: true, SuggestWeight: "likes"},
+		&engine.SimpleFieldInfo{FieldName: "likes", FieldType: utils.IDX_TYPE_NUMBER},
+	)
+	addTestDocs(t, worker, "suggest",
+		&doc.Document{Id: "1", Content: map[string]string{"title": "golang tutorial", "likes": "10"}},
+		&doc.Document{Id: "2", Content: map[string]string{"title": "go concurrency", "likes": "50"}},
+		&doc.Document{Id: "3", Content: map[string]string{"title": "python", "likes": "90"}},
+	)
+	result, err := worker.Suggest(context.Background(), &engine.SuggestRequest{IndexName: "suggest", Field: "title", Prefix: "go", Size: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Suggestions) != 2 {
+		t.Fatalf("want 2 suggestions for prefix go, got %v", result.Suggestions)
+	}
+	if result.Suggestions[0].Text != "go concurrency" || result.Suggestions[0].Weight != 50 {
+		t.Fatalf("want heaviest suggestion first, got %v", result.Suggestions)
+	}
+	if _, err := worker.Suggest(context.Background(), &engine.SuggestRequest{IndexName: "suggest", Field: "likes", Prefix: "1"}); err == nil {
+		t.Fatal("want error for field without suggester")
+	}
+}
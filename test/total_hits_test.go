@@ -0,0 +1,49 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestTotalHits(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "total",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "tag", FieldType: utils.IDX_TYPE_STRING},
+		&engine.SimpleFieldInfo{FieldName: "content", FieldType: utils.IDX_TYPE_STRING_SEG},
+	)
+	addTestDocs(t, worker, "total",
+		&doc.Document{Id: "1", Content: map[string]string{"tag": "go", "content": "golang channel goroutine"}},
+		&doc.Document{Id: "2", Content: map[string]string{"tag": "go", "content": "golang goroutine select"}},
+		&doc.Document{Id: "3", Content: map[string]string{"tag": "go", "content": "golang channel select"}},
+		&doc.Document{Id: "4", Content: map[string]string{"tag": "py", "content": "python django"}},
+	)
+	// 没有分页时也要检索还没有落盘的内存段，命中数与 Count 一致
+	query := types.NewTermQuery("tag", "go")
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "total", Query: query, TrackTotalHits: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := worker.Count(context.Background(), &engine.SearchRequest{IndexName: "total", Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 3 || result.TotalHits != 3 || count.Count != 3 {
+		t.Fatalf("want search, total hits and count to agree on 3, got %v docs, total %v, count %v", len(result.DocResult), result.TotalHits, count.Count)
+	}
+
+	// 打分查询只返回 Size 条，命中总数是截断之前的文档数
+	result, err = worker.Search(context.Background(), &engine.SearchRequest{IndexName: "total", TrackTotalHits: true,
+		MoreLikeThis: &types.MoreLikeThis{DocId: "1", Size: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 1 || result.TotalHits != 2 {
+		t.Fatalf("want 1 similar document out of 2, got %v docs, total %v", len(result.DocResult), result.TotalHits)
+	}
+}
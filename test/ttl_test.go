@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/internal/index"
+	"github.com/cylScripter/NexusFind/internal/index/segment"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestTTLReaperStats(t *testing.T) {
+	worker := newTestWorker(t)
+	if _, err := worker.CreateIndex(context.Background(), &engine.CreateIndexRequest{IndexName: "ttl", TTLField: "expire", FieldInfo: []*engine.SimpleFieldInfo{
+		{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		{FieldName: "expire", FieldType: utils.IDX_TYPE_DATE},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	addTestDocs(t, worker, "ttl",
+		&doc.Document{Id: "old", Content: map[string]string{"expire": "2000-01-01 00:00:00"}},
+		&doc.Document{Id: "new", Content: map[string]string{"expire": "2999-01-01 00:00:00"}},
+	)
+	worker.StartTTLReaper(20 * time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		result, err := worker.Stats(context.Background(), &engine.StatsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 清理统计信息通过 Stats 返回
+		if stats := result.Workers[0].TTL["ttl"]; stats != nil {
+			if stats.Expired != 1 || stats.LastRun == 0 || stats.LastError != "" {
+				t.Fatalf("want one expired document, got %v", stats)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("ttl reaper did not run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestExpireBypassesFilterCache(t *testing.T) {
+	idx := index.NewEmptyIndex("expire", t.TempDir()+"/", utils.NewLogger("test"))
+	defer idx.Close()
+	idx.SetFields([]segment.SimpleFieldInfo{{FieldName: "id", FieldType: utils.IDX_TYPE_PK}, {FieldName: "expire", FieldType: utils.IDX_TYPE_DATE}})
+	if err := idx.SetTTL("expire", 0); err != nil {
+		t.Fatal(err)
+	}
+	for id, expire := range map[string]string{"old": "2000-01-01 00:00:00", "new": "2999-01-01 00:00:00"} {
+		if _, err := idx.AddDocument(&doc.Document{Id: id, Content: map[string]string{"expire": expire}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := idx.SyncMemorySegment(); err != nil {
+		t.Fatal(err)
+	}
+	before := segment.DefaultFilterCache.Stats()
+	expired, err := idx.ExpireDocuments(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 截止时间每次都不同，清理不应该往过滤结果缓存中放入永远不会再命中的条目
+	after := segment.DefaultFilterCache.Stats()
+	if expired != 1 || after.Entries != before.Entries || after.Misses != before.Misses {
+		t.Fatalf("want one expired document without touching the filter cache, got %v expired, cache %v -> %v", expired, before, after)
+	}
+}
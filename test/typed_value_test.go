@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cylScripter/NexusFind/engine"
+	"github.com/cylScripter/NexusFind/types"
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+func TestTypedValues(t *testing.T) {
+	worker := newTestWorker(t)
+	createTestIndex(t, worker, "typed",
+		&engine.SimpleFieldInfo{FieldName: "id", FieldType: utils.IDX_TYPE_PK},
+		&engine.SimpleFieldInfo{FieldName: "title", FieldType: utils.IDX_TYPE_STRING, Suggest: true, SuggestWeight: "likes"},
+		&engine.SimpleFieldInfo{FieldName: "likes", FieldType: utils.IDX_TYPE_NUMBER},
+	)
+	addTestDocs(t, worker, "typed",
+		&doc.Document{Id: "1", Content: map[string]string{"title": "rust"}, Fields: map[string]*doc.Value{"likes": {Kind: &doc.Value_Int{Int: 70}}}},
+		&doc.Document{Id: "2", Content: map[string]string{"title": "ruby", "likes": "20"}},
+	)
+	suggestions, err := worker.Suggest(context.Background(), &engine.SuggestRequest{IndexName: "typed", Field: "title", Prefix: "ru", Size: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suggestions.Suggestions) != 2 || suggestions.Suggestions[0].Text != "rust" || suggestions.Suggestions[0].Weight != 70 {
+		t.Fatalf("want typed weight to rank rust first, got %v", suggestions.Suggestions)
+	}
+	result, err := worker.Search(context.Background(), &engine.SearchRequest{IndexName: "typed", Size: 10,
+		Filter: []*types.SearchFilters{{FieldName: "likes", Type: utils.FILT_EQ, Start: 70}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 1 || result.DocResult[0].Id != "1" {
+		t.Fatalf("want typed int to be indexed, got %v", result.DocResult)
+	}
+	bad := []*doc.Document{
+		{Id: "3", Content: map[string]string{"likes": "many"}},
+		{Id: "4", Fields: map[string]*doc.Value{"likes": {Kind: &doc.Value_Bool{Bool: true}}}},
+	}
+	for _, d := range bad {
+		if _, err := worker.Add(context.Background(), &engine.AddRequest{IndexName: "typed", Doc: d}); err == nil {
+			t.Fatalf("want document [%v] with invalid value to be rejected", d.Id)
+		}
+	}
+	result, err = worker.Search(context.Background(), &engine.SearchRequest{IndexName: "typed", Size: 10,
+		Filter: []*types.SearchFilters{{FieldName: "likes", Type: utils.FILT_EQ, Start: -1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DocResult) != 0 {
+		t.Fatalf("want rejected values not to be coerced to -1, got %v", result.DocResult)
+	}
+}
@@ -0,0 +1,38 @@
+package types
+
+import "github.com/cylScripter/NexusFind/types/doc"
+
+// CollapseKey 返回文档在折叠字段上的值，多值字段取第一个值，没有该字段时返回 false
+func CollapseKey(d *doc.Document, field string) (string, bool) {
+	values := d.FieldValues(field)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// CollapseDocs
+// @Description 按字段值折叠结果，每个值只保留排在最前面的几条，没有该字段的文档不折叠
+// @Param docs 已经排好序的文档
+// @Param collapse 折叠字段及每个值保留的条数
+// @Return 保留的文档在 docs 中的下标、每个值命中的文档数
+func CollapseDocs(docs []*doc.Document, collapse *Collapse) ([]int, map[string]uint64) {
+	size := collapse.GetSize()
+	if size == 0 {
+		size = 1
+	}
+	kept := make([]int, 0, len(docs))
+	counts := make(map[string]uint64)
+	for i, d := range docs {
+		key, ok := CollapseKey(d, collapse.GetField())
+		if !ok {
+			kept = append(kept, i)
+			continue
+		}
+		counts[key]++
+		if counts[key] <= size {
+			kept = append(kept, i)
+		}
+	}
+	return kept, counts
+}
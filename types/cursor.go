@@ -0,0 +1,36 @@
+package types
+
+import "math"
+
+// CursorSortKey 返回分页时文档的排序值，分页结果按 (排序值, docId, worker) 升序排列。
+// 打分查询按得分降序，排序值取得分的相反数；不打分的查询按距离排序时取距离，降序时取相反数，
+// 没有坐标的文档排在最后；其他查询的排序值都为 0，即按 docId 排列
+func CursorSortKey(hit *Hit, scored bool, geoSort *GeoSort) float64 {
+	switch {
+	case scored:
+		return -hit.GetScore()
+	case geoSort != nil:
+		if hit.GetDistance() < 0 {
+			return math.MaxFloat64
+		}
+		if geoSort.Desc {
+			return -hit.GetDistance()
+		}
+		return hit.GetDistance()
+	}
+	return 0
+}
+
+// After 判断排序值为 sort 的文档是否排在游标之后，游标为空时总是返回 true
+func (c *Cursor) After(sort float64, docId uint64, shard string) bool {
+	if c == nil {
+		return true
+	}
+	if sort != c.Sort {
+		return sort > c.Sort
+	}
+	if docId != c.DocId {
+		return docId > c.DocId
+	}
+	return shard > c.Shard
+}
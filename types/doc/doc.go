@@ -24,3 +24,81 @@ func TF(listWords []string) []*KeyWord {
 	}
 	return result
 }
+
+// FieldValues
+// @Description: 返回文档中某个字段所有值的文本形式，顺序与 TypedValues 一致
+// @param name 字段名
+// @return []string
+func (d *Document) FieldValues(name string) []string {
+	typed := d.TypedValues(name)
+	values := make([]string, 0, len(typed))
+	for _, value := range typed {
+		values = append(values, value.Text())
+	}
+	return values
+}
+
+// Project
+// @Description: 按字段名裁剪文档，返回新的文档，原文档不变，Id 总是保留
+// @param include 只保留这些字段，为空时保留全部字段
+// @param exclude 去掉这些字段，优先于 include
+// @param dropKeywords 为 true 时去掉 Keywords 词频列表
+// @return *Document
+func (d *Document) Project(include, exclude []string, dropKeywords bool) *Document {
+	if len(include) == 0 && len(exclude) == 0 && !dropKeywords {
+		return d
+	}
+	keep := func(name string) bool {
+		for _, field := range exclude {
+			if field == name {
+				return false
+			}
+		}
+		if len(include) == 0 {
+			return true
+		}
+		for _, field := range include {
+			if field == name {
+				return true
+			}
+		}
+		return false
+	}
+	result := &Document{Id: d.Id}
+	if !dropKeywords {
+		result.Keywords = d.Keywords
+	}
+	for name, value := range d.Content {
+		if keep(name) {
+			if result.Content == nil {
+				result.Content = make(map[string]string)
+			}
+			result.Content[name] = value
+		}
+	}
+	for name, value := range d.Vectors {
+		if keep(name) {
+			if result.Vectors == nil {
+				result.Vectors = make(map[string]*Vector)
+			}
+			result.Vectors[name] = value
+		}
+	}
+	for name, value := range d.Arrays {
+		if keep(name) {
+			if result.Arrays == nil {
+				result.Arrays = make(map[string]*Values)
+			}
+			result.Arrays[name] = value
+		}
+	}
+	for name, value := range d.Fields {
+		if keep(name) {
+			if result.Fields == nil {
+				result.Fields = make(map[string]*Value)
+			}
+			result.Fields[name] = value
+		}
+	}
+	return result
+}
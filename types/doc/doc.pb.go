@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.33.0
 // 	protoc        v4.25.3
-// source: internal/doc/query.proto
+// source: types/doc/doc.proto
 
 package doc
 
@@ -32,7 +32,7 @@ type KeyWord struct {
 func (x *KeyWord) Reset() {
 	*x = KeyWord{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_doc_doc_proto_msgTypes[0]
+		mi := &file_types_doc_doc_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -45,7 +45,7 @@ func (x *KeyWord) String() string {
 func (*KeyWord) ProtoMessage() {}
 
 func (x *KeyWord) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_doc_doc_proto_msgTypes[0]
+	mi := &file_types_doc_doc_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +58,7 @@ func (x *KeyWord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyWord.ProtoReflect.Descriptor instead.
 func (*KeyWord) Descriptor() ([]byte, []int) {
-	return file_internal_doc_doc_proto_rawDescGZIP(), []int{0}
+	return file_types_doc_doc_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *KeyWord) GetWord() string {
@@ -75,19 +75,262 @@ func (x *KeyWord) GetWordTF() float32 {
 	return 0
 }
 
+type Vector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []float32 `protobuf:"fixed32,1,rep,packed,name=Values,proto3" json:"Values,omitempty"`
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_doc_doc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_types_doc_doc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_types_doc_doc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Vector) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Value 带类型的字段值
+type Value struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Kind:
+	//	*Value_Int
+	//	*Value_Float
+	//	*Value_Bool
+	//	*Value_Timestamp
+	//	*Value_Str
+	//	*Value_Bytes
+	Kind isValue_Kind `protobuf_oneof:"Kind"`
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_doc_doc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_types_doc_doc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_types_doc_doc_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *Value) GetKind() isValue_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetInt() int64 {
+	if x, ok := x.GetKind().(*Value_Int); ok {
+		return x.Int
+	}
+	return 0
+}
+
+func (x *Value) GetFloat() float64 {
+	if x, ok := x.GetKind().(*Value_Float); ok {
+		return x.Float
+	}
+	return 0
+}
+
+func (x *Value) GetBool() bool {
+	if x, ok := x.GetKind().(*Value_Bool); ok {
+		return x.Bool
+	}
+	return false
+}
+
+func (x *Value) GetTimestamp() int64 {
+	if x, ok := x.GetKind().(*Value_Timestamp); ok {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Value) GetStr() string {
+	if x, ok := x.GetKind().(*Value_Str); ok {
+		return x.Str
+	}
+	return ""
+}
+
+func (x *Value) GetBytes() []byte {
+	if x, ok := x.GetKind().(*Value_Bytes); ok {
+		return x.Bytes
+	}
+	return nil
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_Int struct {
+	Int int64 `protobuf:"varint,1,opt,name=Int,proto3,oneof"`
+}
+
+type Value_Float struct {
+	Float float64 `protobuf:"fixed64,2,opt,name=Float,proto3,oneof"`
+}
+
+type Value_Bool struct {
+	Bool bool `protobuf:"varint,3,opt,name=Bool,proto3,oneof"`
+}
+
+type Value_Timestamp struct {
+	Timestamp int64 `protobuf:"varint,4,opt,name=Timestamp,proto3,oneof"` // Unix 时间戳，单位秒
+}
+
+type Value_Str struct {
+	Str string `protobuf:"bytes,5,opt,name=Str,proto3,oneof"`
+}
+
+type Value_Bytes struct {
+	Bytes []byte `protobuf:"bytes,6,opt,name=Bytes,proto3,oneof"`
+}
+
+func (*Value_Int) isValue_Kind() {}
+
+func (*Value_Float) isValue_Kind() {}
+
+func (*Value_Bool) isValue_Kind() {}
+
+func (*Value_Timestamp) isValue_Kind() {}
+
+func (*Value_Str) isValue_Kind() {}
+
+func (*Value_Bytes) isValue_Kind() {}
+
+type Values struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=Values,proto3" json:"Values,omitempty"`
+	Typed  []*Value `protobuf:"bytes,2,rep,name=Typed,proto3" json:"Typed,omitempty"` // 带类型的多值
+}
+
+func (x *Values) Reset() {
+	*x = Values{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_doc_doc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Values) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Values) ProtoMessage() {}
+
+func (x *Values) ProtoReflect() protoreflect.Message {
+	mi := &file_types_doc_doc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Values.ProtoReflect.Descriptor instead.
+func (*Values) Descriptor() ([]byte, []int) {
+	return file_types_doc_doc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Values) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Values) GetTyped() []*Value {
+	if x != nil {
+		return x.Typed
+	}
+	return nil
+}
+
 type Document struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-	Id       string            `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	Keywords []*KeyWord        `protobuf:"bytes,2,rep,name=Keywords,proto3" json:"Keywords,omitempty"`
-	Content  map[string]string `protobuf:"bytes,4,rep,name=Content,proto3" json:"Content,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+
+	Id       string             `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Keywords []*KeyWord         `protobuf:"bytes,2,rep,name=Keywords,proto3" json:"Keywords,omitempty"`
+	Content  map[string]string  `protobuf:"bytes,4,rep,name=Content,proto3" json:"Content,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Vectors  map[string]*Vector `protobuf:"bytes,5,rep,name=Vectors,proto3" json:"Vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 向量类型字段的内容
+	Arrays   map[string]*Values `protobuf:"bytes,6,rep,name=Arrays,proto3" json:"Arrays,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`   // 多值字段的内容，与 Content 中的同名字段一起建索引
+	Fields   map[string]*Value  `protobuf:"bytes,7,rep,name=Fields,proto3" json:"Fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`   // 带类型的字段内容，与 Content 中的同名字段一起建索引
 }
 
 func (x *Document) Reset() {
 	*x = Document{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_doc_doc_proto_msgTypes[1]
+		mi := &file_types_doc_doc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +343,7 @@ func (x *Document) String() string {
 func (*Document) ProtoMessage() {}
 
 func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_doc_doc_proto_msgTypes[1]
+	mi := &file_types_doc_doc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,7 +356,7 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Document.ProtoReflect.Descriptor instead.
 func (*Document) Descriptor() ([]byte, []int) {
-	return file_internal_doc_doc_proto_rawDescGZIP(), []int{1}
+	return file_types_doc_doc_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Document) GetId() string {
@@ -137,65 +380,138 @@ func (x *Document) GetContent() map[string]string {
 	return nil
 }
 
-var File_internal_doc_doc_proto protoreflect.FileDescriptor
-
-var file_internal_doc_doc_proto_rawDesc = []byte{
-	0x0a, 0x16, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x64,
-	0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x64, 0x6f, 0x63, 0x22, 0x35, 0x0a,
-	0x07, 0x4b, 0x65, 0x79, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x57, 0x6f, 0x72, 0x64, 0x54, 0x46, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x57, 0x6f,
-	0x72, 0x64, 0x54, 0x46, 0x22, 0xb6, 0x01, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
-	0x64, 0x12, 0x28, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x4b, 0x65, 0x79, 0x57, 0x6f, 0x72,
-	0x64, 0x52, 0x08, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64,
-	0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x11, 0x5a,
-	0x0f, 0x2e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x2f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Document) GetVectors() map[string]*Vector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *Document) GetArrays() map[string]*Values {
+	if x != nil {
+		return x.Arrays
+	}
+	return nil
+}
+
+func (x *Document) GetFields() map[string]*Value {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_types_doc_doc_proto protoreflect.FileDescriptor
+
+var file_types_doc_doc_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x64, 0x6f, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x64, 0x6f, 0x63, 0x22, 0x35, 0x0a, 0x07, 0x4b, 0x65,
+	0x79, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x6f, 0x72,
+	0x64, 0x54, 0x46, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x57, 0x6f, 0x72, 0x64, 0x54,
+	0x46, 0x22, 0x20, 0x0a, 0x06, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a,
+	0x03, 0x49, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x49, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x00, 0x52, 0x05, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x42, 0x6f, 0x6f,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x42, 0x6f, 0x6f, 0x6c, 0x12,
+	0x1e, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x12, 0x0a, 0x03, 0x53, 0x74, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x53, 0x74, 0x72, 0x12, 0x16, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x22, 0x42, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x05, 0x54, 0x79, 0x70, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x54, 0x79, 0x70, 0x65, 0x64, 0x22, 0xaa, 0x04, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x4b, 0x65, 0x79,
+	0x57, 0x6f, 0x72, 0x64, 0x52, 0x08, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x34,
+	0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6f, 0x63,
+	0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x12, 0x31, 0x0a,
+	0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x47, 0x0a, 0x0c,
+	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x64, 0x6f, 0x63, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x46, 0x0a, 0x0b, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x45, 0x0a,
+	0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x20,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x64, 0x6f, 0x63, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_internal_doc_doc_proto_rawDescOnce sync.Once
-	file_internal_doc_doc_proto_rawDescData = file_internal_doc_doc_proto_rawDesc
+	file_types_doc_doc_proto_rawDescOnce sync.Once
+	file_types_doc_doc_proto_rawDescData = file_types_doc_doc_proto_rawDesc
 )
 
-func file_internal_doc_doc_proto_rawDescGZIP() []byte {
-	file_internal_doc_doc_proto_rawDescOnce.Do(func() {
-		file_internal_doc_doc_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_doc_doc_proto_rawDescData)
+func file_types_doc_doc_proto_rawDescGZIP() []byte {
+	file_types_doc_doc_proto_rawDescOnce.Do(func() {
+		file_types_doc_doc_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_doc_doc_proto_rawDescData)
 	})
-	return file_internal_doc_doc_proto_rawDescData
+	return file_types_doc_doc_proto_rawDescData
 }
 
-var file_internal_doc_doc_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_internal_doc_doc_proto_goTypes = []interface{}{
+var file_types_doc_doc_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_types_doc_doc_proto_goTypes = []interface{}{
 	(*KeyWord)(nil),  // 0: doc.KeyWord
-	(*Document)(nil), // 1: doc.Document
-	nil,              // 2: doc.Document.ContentEntry
-}
-var file_internal_doc_doc_proto_depIdxs = []int32{
-	0, // 0: doc.Document.Keywords:type_name -> doc.KeyWord
-	2, // 1: doc.Document.Content:type_name -> doc.Document.ContentEntry
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
-}
-
-func init() { file_internal_doc_doc_proto_init() }
-func file_internal_doc_doc_proto_init() {
-	if File_internal_doc_doc_proto != nil {
+	(*Vector)(nil),   // 1: doc.Vector
+	(*Value)(nil),    // 2: doc.Value
+	(*Values)(nil),   // 3: doc.Values
+	(*Document)(nil), // 4: doc.Document
+	nil,              // 5: doc.Document.ContentEntry
+	nil,              // 6: doc.Document.VectorsEntry
+	nil,              // 7: doc.Document.ArraysEntry
+	nil,              // 8: doc.Document.FieldsEntry
+}
+var file_types_doc_doc_proto_depIdxs = []int32{
+	2, // 0: doc.Values.Typed:type_name -> doc.Value
+	0, // 1: doc.Document.Keywords:type_name -> doc.KeyWord
+	5, // 2: doc.Document.Content:type_name -> doc.Document.ContentEntry
+	6, // 3: doc.Document.Vectors:type_name -> doc.Document.VectorsEntry
+	7, // 4: doc.Document.Arrays:type_name -> doc.Document.ArraysEntry
+	8, // 5: doc.Document.Fields:type_name -> doc.Document.FieldsEntry
+	1, // 6: doc.Document.VectorsEntry.value:type_name -> doc.Vector
+	3, // 7: doc.Document.ArraysEntry.value:type_name -> doc.Values
+	2, // 8: doc.Document.FieldsEntry.value:type_name -> doc.Value
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_types_doc_doc_proto_init() }
+func file_types_doc_doc_proto_init() {
+	if File_types_doc_doc_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_internal_doc_doc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_types_doc_doc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KeyWord); i {
 			case 0:
 				return &v.state
@@ -207,7 +523,43 @@ func file_internal_doc_doc_proto_init() {
 				return nil
 			}
 		}
-		file_internal_doc_doc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_types_doc_doc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_doc_doc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_doc_doc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Values); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_doc_doc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Document); i {
 			case 0:
 				return &v.state
@@ -220,22 +572,30 @@ func file_internal_doc_doc_proto_init() {
 			}
 		}
 	}
+	file_types_doc_doc_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*Value_Int)(nil),
+		(*Value_Float)(nil),
+		(*Value_Bool)(nil),
+		(*Value_Timestamp)(nil),
+		(*Value_Str)(nil),
+		(*Value_Bytes)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_internal_doc_doc_proto_rawDesc,
+			RawDescriptor: file_types_doc_doc_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_internal_doc_doc_proto_goTypes,
-		DependencyIndexes: file_internal_doc_doc_proto_depIdxs,
-		MessageInfos:      file_internal_doc_doc_proto_msgTypes,
+		GoTypes:           file_types_doc_doc_proto_goTypes,
+		DependencyIndexes: file_types_doc_doc_proto_depIdxs,
+		MessageInfos:      file_types_doc_doc_proto_msgTypes,
 	}.Build()
-	File_internal_doc_doc_proto = out.File
-	file_internal_doc_doc_proto_rawDesc = nil
-	file_internal_doc_doc_proto_goTypes = nil
-	file_internal_doc_doc_proto_depIdxs = nil
+	File_types_doc_doc_proto = out.File
+	file_types_doc_doc_proto_rawDesc = nil
+	file_types_doc_doc_proto_goTypes = nil
+	file_types_doc_doc_proto_depIdxs = nil
 }
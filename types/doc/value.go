@@ -0,0 +1,63 @@
+package doc
+
+import (
+	"encoding/gob"
+	"strconv"
+	"time"
+)
+
+func init() {
+	// 正排用 gob 序列化文档，oneof 的具体类型需要注册
+	gob.Register(&Value_Int{})
+	gob.Register(&Value_Float{})
+	gob.Register(&Value_Bool{})
+	gob.Register(&Value_Timestamp{})
+	gob.Register(&Value_Str{})
+	gob.Register(&Value_Bytes{})
+}
+
+// Text
+// @Description: 把带类型的值转换成建索引时使用的文本，时间戳转换成带时区的 RFC3339
+// @return string
+func (v *Value) Text() string {
+	switch kind := v.GetKind().(type) {
+	case *Value_Int:
+		return strconv.FormatInt(kind.Int, 10)
+	case *Value_Float:
+		return strconv.FormatFloat(kind.Float, 'f', -1, 64)
+	case *Value_Bool:
+		return strconv.FormatBool(kind.Bool)
+	case *Value_Timestamp:
+		return time.Unix(kind.Timestamp, 0).In(time.Local).Format(time.RFC3339)
+	case *Value_Str:
+		return kind.Str
+	case *Value_Bytes:
+		return string(kind.Bytes)
+	}
+	return ""
+}
+
+// TypedValues
+// @Description: 返回文档中某个字段的所有值，Content 和 Arrays.Values 中的字符串作为 Str 类型返回
+// @param name 字段名
+// @return []*Value
+func (d *Document) TypedValues(name string) []*Value {
+	values := make([]*Value, 0, 1)
+	if content, ok := d.GetContent()[name]; ok {
+		values = append(values, &Value{Kind: &Value_Str{Str: content}})
+	}
+	if value, ok := d.GetFields()[name]; ok && value.GetKind() != nil {
+		values = append(values, value)
+	}
+	if array, ok := d.GetArrays()[name]; ok {
+		for _, content := range array.GetValues() {
+			values = append(values, &Value{Kind: &Value_Str{Str: content}})
+		}
+		for _, value := range array.GetTyped() {
+			if value.GetKind() != nil {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"fmt"
+	"github.com/cylScripter/NexusFind/utils"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strings"
+)
+
+// Normalize 对集合类过滤条件的取值排序去重，返回新的过滤条件，原条件不变。
+// 取值相同、顺序不同的过滤条件规范化之后完全一样，可以共用缓存
+func (f *SearchFilters) Normalize() *SearchFilters {
+	result := proto.Clone(f).(*SearchFilters)
+	if len(result.Terms) > 0 {
+		sort.Strings(result.Terms)
+		terms := result.Terms[:1]
+		for _, term := range result.Terms[1:] {
+			if term != terms[len(terms)-1] {
+				terms = append(terms, term)
+			}
+		}
+		result.Terms = terms
+	}
+	if len(result.Range) > 0 {
+		sort.Slice(result.Range, func(i, j int) bool { return result.Range[i] < result.Range[j] })
+		values := result.Range[:1]
+		for _, value := range result.Range[1:] {
+			if value != values[len(values)-1] {
+				values = append(values, value)
+			}
+		}
+		result.Range = values
+	}
+	if len(result.RangeFloat) > 0 {
+		sort.Float64s(result.RangeFloat)
+		values := result.RangeFloat[:1]
+		for _, value := range result.RangeFloat[1:] {
+			if value != values[len(values)-1] {
+				values = append(values, value)
+			}
+		}
+		result.RangeFloat = values
+	}
+	for i, child := range result.Filters {
+		result.Filters[i] = child.Normalize()
+	}
+	return result
+}
+
+// Cacheable 过滤条件只决定文档是否命中、不参与打分，结果只和段的内容有关，可以缓存。
+// 带 now 的日期表达式每次查询的结果不同，不能缓存，子条件中有不能缓存的条件时整个条件都不能缓存
+func (f *SearchFilters) Cacheable() bool {
+	if strings.Contains(f.GetStartDate(), "now") || strings.Contains(f.GetEndDate(), "now") {
+		return false
+	}
+	for _, child := range f.GetFilters() {
+		if !child.Cacheable() {
+			return false
+		}
+	}
+	return true
+}
+
+// CacheKey 返回规范化之后的过滤条件的唯一标识
+func (f *SearchFilters) CacheKey() string {
+	buf, err := proto.MarshalOptions{Deterministic: true}.Marshal(f.Normalize())
+	if err != nil {
+		return ""
+	}
+	return string(buf)
+}
+
+// IsSetFilter 判断过滤条件是否为 FILT_TERMS、FILT_IN、FILT_EXISTS、FILT_MISSING 这类不依赖数值区间的过滤
+func (f *SearchFilters) IsSetFilter() bool {
+	switch f.GetType() {
+	case utils.FILT_TERMS, utils.FILT_IN, utils.FILT_EXISTS, utils.FILT_MISSING:
+		return true
+	}
+	return false
+}
+
+// IsBoolFilter 判断过滤条件是否为 FILT_AND、FILT_OR、FILT_NOT 组合条件
+func (f *SearchFilters) IsBoolFilter() bool {
+	switch f.GetType() {
+	case utils.FILT_AND, utils.FILT_OR, utils.FILT_NOT:
+		return true
+	}
+	return false
+}
+
+// Describe 返回过滤条件的简短描述，组合条件只描述类型，子条件单独描述
+func (f *SearchFilters) Describe() string {
+	switch f.GetType() {
+	case utils.FILT_AND:
+		return "and"
+	case utils.FILT_OR:
+		return "or"
+	case utils.FILT_NOT:
+		return "not"
+	}
+	return fmt.Sprintf("%v type %v", f.GetFieldName(), f.GetType())
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"github.com/cylScripter/NexusFind/utils"
+)
+
+// Normalize 补齐融合参数的默认值，返回新的参数，原参数不变
+func (f *Fusion) Normalize() *Fusion {
+	result := &Fusion{Method: utils.FUSION_RRF, RankConstant: 60, LexicalWeight: 1, VectorWeight: 1, WindowSize: 100, Size: 10}
+	if f == nil {
+		return result
+	}
+	if f.Method != 0 {
+		result.Method = f.Method
+	}
+	if f.RankConstant != 0 {
+		result.RankConstant = f.RankConstant
+	}
+	if f.LexicalWeight != 0 {
+		result.LexicalWeight = f.LexicalWeight
+	}
+	if f.VectorWeight != 0 {
+		result.VectorWeight = f.VectorWeight
+	}
+	if f.WindowSize != 0 {
+		result.WindowSize = f.WindowSize
+	}
+	if f.Size != 0 {
+		result.Size = f.Size
+	}
+	return result
+}
+
+// FuseHits
+// @Description 融合关键词和向量两路结果。两路结果各自按得分排序后截断到 WindowSize，
+// RRF 按名次计算 weight/(k+rank)，加权融合按各路最高分归一化后加权求和
+// @Param fusion 融合参数，需要先调用 Normalize
+// @Param lexical 关键词结果，Score 为关键词得分
+// @Param vector 向量结果，Score 为向量得分
+// @Return 按融合得分降序排列的结果，截断到 Size，每条结果都带有两路的名次和得分
+func FuseHits(fusion *Fusion, lexical, vector []*Hit) []*Hit {
+	fused := make(map[uint64]*Hit)
+	get := func(docId uint64) *Hit {
+		hit, ok := fused[docId]
+		if !ok {
+			hit = &Hit{DocId: docId}
+			fused[docId] = hit
+		}
+		return hit
+	}
+	lexical = rankHits(lexical, fusion.WindowSize)
+	vector = rankHits(vector, fusion.WindowSize)
+	for i, h := range lexical {
+		hit := get(h.DocId)
+		hit.LexicalRank = uint64(i + 1)
+		hit.LexicalScore = h.Score
+	}
+	for i, h := range vector {
+		hit := get(h.DocId)
+		hit.VectorRank = uint64(i + 1)
+		hit.VectorScore = h.Score
+	}
+	lexicalMax, vectorMax := maxScore(lexical), maxScore(vector)
+	result := make([]*Hit, 0, len(fused))
+	for _, hit := range fused {
+		if fusion.Method == utils.FUSION_WEIGHTED {
+			if hit.LexicalRank > 0 && lexicalMax > 0 {
+				hit.Score += float64(fusion.LexicalWeight) * hit.LexicalScore / lexicalMax
+			}
+			if hit.VectorRank > 0 && vectorMax > 0 {
+				hit.Score += float64(fusion.VectorWeight) * hit.VectorScore / vectorMax
+			}
+		} else {
+			if hit.LexicalRank > 0 {
+				hit.Score += float64(fusion.LexicalWeight) / float64(fusion.RankConstant+hit.LexicalRank)
+			}
+			if hit.VectorRank > 0 {
+				hit.Score += float64(fusion.VectorWeight) / float64(fusion.RankConstant+hit.VectorRank)
+			}
+		}
+		result = append(result, hit)
+	}
+	return rankHits(result, fusion.Size)
+}
+
+// SplitFusedHits 把已经融合过的结果拆回关键词和向量两路，用于合并多个 worker 的结果后重新融合
+func SplitFusedHits(hits []*Hit) ([]*Hit, []*Hit) {
+	lexical := make([]*Hit, 0, len(hits))
+	vector := make([]*Hit, 0, len(hits))
+	for _, hit := range hits {
+		if hit.LexicalRank > 0 {
+			lexical = append(lexical, &Hit{DocId: hit.DocId, Score: hit.LexicalScore})
+		}
+		if hit.VectorRank > 0 {
+			vector = append(vector, &Hit{DocId: hit.DocId, Score: hit.VectorScore})
+		}
+	}
+	return lexical, vector
+}
+
+// 内部方法，排序并截断
+func rankHits(hits []*Hit, size uint64) []*Hit {
+	SortHits(hits)
+	if size > 0 && uint64(len(hits)) > size {
+		hits = hits[:size]
+	}
+	return hits
+}
+
+// 内部方法，返回得分的最大值，得分为负时（例如内积）按 0 处理
+func maxScore(hits []*Hit) float64 {
+	var result float64
+	for _, hit := range hits {
+		if hit.Score > result {
+			result = hit.Score
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/cylScripter/NexusFind/types/doc"
+	"github.com/cylScripter/NexusFind/utils"
+	"sort"
+)
+
+// SortByDistance
+// @Description 按文档中地理坐标字段到原点的距离排序，多值字段取最近的点，没有坐标的文档排在最后
+// @Param docs 待排序的文档
+// @Param hits 文档对应的打分信息，可以为空，不为空时需要和 docs 一一对应
+// @Param geoSort 排序字段及原点
+// @Return 排序后的文档以及带有距离信息的打分信息，没有坐标的文档距离记为 -1
+func SortByDistance(docs []*doc.Document, hits []*Hit, geoSort *GeoSort) ([]*doc.Document, []*Hit) {
+	type item struct {
+		doc *doc.Document
+		hit *Hit
+	}
+	items := make([]item, len(docs))
+	origin := geoSort.GetOrigin()
+	for i, d := range docs {
+		hit := &Hit{}
+		if i < len(hits) && hits[i] != nil {
+			hit = hits[i]
+		}
+		hit.Distance = -1
+		// 多值字段取最近的一个点
+		for _, value := range d.FieldValues(geoSort.Field) {
+			if lat, lon, err := utils.ParseGeoPoint(value); err == nil {
+				distance := utils.GeoDistance(origin.GetLat(), origin.GetLon(), lat, lon)
+				if hit.Distance < 0 || distance < hit.Distance {
+					hit.Distance = distance
+				}
+			}
+		}
+		items[i] = item{doc: d, hit: hit}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		di, dj := items[i].hit.Distance, items[j].hit.Distance
+		if di < 0 || dj < 0 {
+			return di >= 0 && dj < 0
+		}
+		if geoSort.Desc {
+			return di > dj
+		}
+		return di < dj
+	})
+	sortedDocs := make([]*doc.Document, len(items))
+	sortedHits := make([]*Hit, len(items))
+	for i, it := range items {
+		sortedDocs[i] = it.doc
+		sortedHits[i] = it.hit
+	}
+	return sortedDocs, sortedHits
+}
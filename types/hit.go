@@ -0,0 +1,13 @@
+package types
+
+import "sort"
+
+// SortHits 按得分降序排列，得分相同时按 docId 升序
+func SortHits(hits []*Hit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].DocId < hits[j].DocId
+	})
+}
@@ -2,11 +2,12 @@
 // versions:
 // 	protoc-gen-go v1.33.0
 // 	protoc        v4.25.3
-// source: query.proto
+// source: types/query.proto
 
 package types
 
 import (
+	doc "github.com/cylScripter/NexusFind/types/doc"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -25,17 +26,26 @@ type SearchFilters struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FieldName string  `protobuf:"bytes,1,opt,name=FieldName,proto3" json:"FieldName,omitempty"`
-	Start     int64   `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
-	End       int64   `protobuf:"varint,3,opt,name=End,proto3" json:"End,omitempty"`
-	Range     []int64 `protobuf:"varint,4,rep,packed,name=Range,proto3" json:"Range,omitempty"`
-	Type      uint64  `protobuf:"varint,5,opt,name=Type,proto3" json:"Type,omitempty"`
+	FieldName      string           `protobuf:"bytes,1,opt,name=FieldName,proto3" json:"FieldName,omitempty"`
+	Start          int64            `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	End            int64            `protobuf:"varint,3,opt,name=End,proto3" json:"End,omitempty"`
+	Range          []int64          `protobuf:"varint,4,rep,packed,name=Range,proto3" json:"Range,omitempty"` // FILT_IN 的取值集合，含义同 Start
+	Type           uint64           `protobuf:"varint,5,opt,name=Type,proto3" json:"Type,omitempty"`
+	GeoDistance    *GeoDistance     `protobuf:"bytes,6,opt,name=GeoDistance,proto3" json:"GeoDistance,omitempty"`         // Type 为 FILT_GEO_DISTANCE 时使用
+	GeoBoundingBox *GeoBoundingBox  `protobuf:"bytes,7,opt,name=GeoBoundingBox,proto3" json:"GeoBoundingBox,omitempty"`   // Type 为 FILT_GEO_BBOX 时使用
+	StartFloat     float64          `protobuf:"fixed64,8,opt,name=StartFloat,proto3" json:"StartFloat,omitempty"`         // IDX_TYPE_DOUBLE 字段的过滤下界，含义同 Start
+	EndFloat       float64          `protobuf:"fixed64,9,opt,name=EndFloat,proto3" json:"EndFloat,omitempty"`             // IDX_TYPE_DOUBLE 字段的过滤上界，含义同 End
+	StartDate      string           `protobuf:"bytes,10,opt,name=StartDate,proto3" json:"StartDate,omitempty"`            // IDX_TYPE_DATETIME 字段的日期表达式，例如 "now-7d/d"，为空时使用 Start（毫秒）
+	EndDate        string           `protobuf:"bytes,11,opt,name=EndDate,proto3" json:"EndDate,omitempty"`                // IDX_TYPE_DATETIME 字段 FILT_RANGE 的上界表达式，为空时使用 End（毫秒）
+	Terms          []string         `protobuf:"bytes,12,rep,name=Terms,proto3" json:"Terms,omitempty"`                    // FILT_TERMS 的取值集合，数值类字段按字段类型解析
+	Filters        []*SearchFilters `protobuf:"bytes,13,rep,name=Filters,proto3" json:"Filters,omitempty"`                // FILT_AND、FILT_OR、FILT_NOT 的子条件，可以嵌套
+	RangeFloat     []float64        `protobuf:"fixed64,14,rep,packed,name=RangeFloat,proto3" json:"RangeFloat,omitempty"` // IDX_TYPE_DOUBLE 字段 FILT_IN 的取值集合，含义同 StartFloat
 }
 
 func (x *SearchFilters) Reset() {
 	*x = SearchFilters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_query_proto_msgTypes[0]
+		mi := &file_types_query_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -48,7 +58,7 @@ func (x *SearchFilters) String() string {
 func (*SearchFilters) ProtoMessage() {}
 
 func (x *SearchFilters) ProtoReflect() protoreflect.Message {
-	mi := &file_query_proto_msgTypes[0]
+	mi := &file_types_query_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,7 +71,7 @@ func (x *SearchFilters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFilters.ProtoReflect.Descriptor instead.
 func (*SearchFilters) Descriptor() ([]byte, []int) {
-	return file_query_proto_rawDescGZIP(), []int{0}
+	return file_types_query_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *SearchFilters) GetFieldName() string {
@@ -99,6 +109,352 @@ func (x *SearchFilters) GetType() uint64 {
 	return 0
 }
 
+func (x *SearchFilters) GetGeoDistance() *GeoDistance {
+	if x != nil {
+		return x.GeoDistance
+	}
+	return nil
+}
+
+func (x *SearchFilters) GetGeoBoundingBox() *GeoBoundingBox {
+	if x != nil {
+		return x.GeoBoundingBox
+	}
+	return nil
+}
+
+func (x *SearchFilters) GetStartFloat() float64 {
+	if x != nil {
+		return x.StartFloat
+	}
+	return 0
+}
+
+func (x *SearchFilters) GetEndFloat() float64 {
+	if x != nil {
+		return x.EndFloat
+	}
+	return 0
+}
+
+func (x *SearchFilters) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *SearchFilters) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *SearchFilters) GetTerms() []string {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *SearchFilters) GetFilters() []*SearchFilters {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SearchFilters) GetRangeFloat() []float64 {
+	if x != nil {
+		return x.RangeFloat
+	}
+	return nil
+}
+
+type GeoPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=Lat,proto3" json:"Lat,omitempty"`
+	Lon float64 `protobuf:"fixed64,2,opt,name=Lon,proto3" json:"Lon,omitempty"`
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type GeoDistance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Center   *GeoPoint `protobuf:"bytes,1,opt,name=Center,proto3" json:"Center,omitempty"`
+	Distance float64   `protobuf:"fixed64,2,opt,name=Distance,proto3" json:"Distance,omitempty"` // 半径，单位米
+}
+
+func (x *GeoDistance) Reset() {
+	*x = GeoDistance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoDistance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoDistance) ProtoMessage() {}
+
+func (x *GeoDistance) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoDistance.ProtoReflect.Descriptor instead.
+func (*GeoDistance) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeoDistance) GetCenter() *GeoPoint {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *GeoDistance) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type GeoBoundingBox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopLeft     *GeoPoint `protobuf:"bytes,1,opt,name=TopLeft,proto3" json:"TopLeft,omitempty"`         // 左上角，即最大纬度、最小经度
+	BottomRight *GeoPoint `protobuf:"bytes,2,opt,name=BottomRight,proto3" json:"BottomRight,omitempty"` // 右下角，即最小纬度、最大经度。左上角经度大于右下角时表示跨越 180 度经线
+}
+
+func (x *GeoBoundingBox) Reset() {
+	*x = GeoBoundingBox{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoBoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoBoundingBox) ProtoMessage() {}
+
+func (x *GeoBoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoBoundingBox.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBox) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GeoBoundingBox) GetTopLeft() *GeoPoint {
+	if x != nil {
+		return x.TopLeft
+	}
+	return nil
+}
+
+func (x *GeoBoundingBox) GetBottomRight() *GeoPoint {
+	if x != nil {
+		return x.BottomRight
+	}
+	return nil
+}
+
+type GeoSort struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field  string    `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`   // 地理坐标类型的字段
+	Origin *GeoPoint `protobuf:"bytes,2,opt,name=Origin,proto3" json:"Origin,omitempty"` // 按到该点的距离排序
+	Desc   bool      `protobuf:"varint,3,opt,name=Desc,proto3" json:"Desc,omitempty"`    // 是否由远到近
+}
+
+func (x *GeoSort) Reset() {
+	*x = GeoSort{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoSort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoSort) ProtoMessage() {}
+
+func (x *GeoSort) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoSort.ProtoReflect.Descriptor instead.
+func (*GeoSort) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GeoSort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GeoSort) GetOrigin() *GeoPoint {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *GeoSort) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+type Collapse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"` // 按该字段的值折叠，需要是 IDX_TYPE_STRING 类型的字段
+	Size  uint64 `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`  // 每个值保留排在最前面的多少条，为 0 时保留 1 条
+}
+
+func (x *Collapse) Reset() {
+	*x = Collapse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Collapse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collapse) ProtoMessage() {}
+
+func (x *Collapse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collapse.ProtoReflect.Descriptor instead.
+func (*Collapse) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Collapse) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Collapse) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 type Keyword struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -111,7 +467,7 @@ type Keyword struct {
 func (x *Keyword) Reset() {
 	*x = Keyword{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_query_proto_msgTypes[1]
+		mi := &file_types_query_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -124,7 +480,7 @@ func (x *Keyword) String() string {
 func (*Keyword) ProtoMessage() {}
 
 func (x *Keyword) ProtoReflect() protoreflect.Message {
-	mi := &file_query_proto_msgTypes[1]
+	mi := &file_types_query_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +493,7 @@ func (x *Keyword) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Keyword.ProtoReflect.Descriptor instead.
 func (*Keyword) Descriptor() ([]byte, []int) {
-	return file_query_proto_rawDescGZIP(), []int{1}
+	return file_types_query_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Keyword) GetField() string {
@@ -154,8 +510,6 @@ func (x *Keyword) GetWord() string {
 	return ""
 }
 
-
-
 type TermQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -164,12 +518,13 @@ type TermQuery struct {
 	Keyword *Keyword     `protobuf:"bytes,1,opt,name=Keyword,proto3" json:"Keyword,omitempty"` //Keyword类型引用自doc.proto
 	Must    []*TermQuery `protobuf:"bytes,2,rep,name=Must,proto3" json:"Must,omitempty"`
 	Should  []*TermQuery `protobuf:"bytes,3,rep,name=Should,proto3" json:"Should,omitempty"`
+	Boost   float32      `protobuf:"fixed32,4,opt,name=Boost,proto3" json:"Boost,omitempty"` //打分时该查询的权重，0 表示 1
 }
 
 func (x *TermQuery) Reset() {
 	*x = TermQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_query_proto_msgTypes[2]
+		mi := &file_types_query_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -182,7 +537,7 @@ func (x *TermQuery) String() string {
 func (*TermQuery) ProtoMessage() {}
 
 func (x *TermQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_query_proto_msgTypes[2]
+	mi := &file_types_query_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +550,7 @@ func (x *TermQuery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TermQuery.ProtoReflect.Descriptor instead.
 func (*TermQuery) Descriptor() ([]byte, []int) {
-	return file_query_proto_rawDescGZIP(), []int{2}
+	return file_types_query_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TermQuery) GetKeyword() *Keyword {
@@ -219,70 +574,1594 @@ func (x *TermQuery) GetShould() []*TermQuery {
 	return nil
 }
 
-var File_query_proto protoreflect.FileDescriptor
-
-var file_query_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74,
-	0x79, 0x70, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x22, 0x33, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x54,
-	0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x77,
-	0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x77, 0x6f,
-	0x72, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x4d, 0x75, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x04, 0x4d, 0x75, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x53, 0x68, 0x6f, 0x75,
-	0x6c, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x06, 0x53, 0x68, 0x6f, 0x75,
-	0x6c, 0x64, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TermQuery) GetBoost() float32 {
+	if x != nil {
+		return x.Boost
+	}
+	return 0
 }
 
-var (
-	file_query_proto_rawDescOnce sync.Once
-	file_query_proto_rawDescData = file_query_proto_rawDesc
-)
-
-func file_query_proto_rawDescGZIP() []byte {
-	file_query_proto_rawDescOnce.Do(func() {
-		file_query_proto_rawDescData = protoimpl.X.CompressGZIP(file_query_proto_rawDescData)
-	})
-	return file_query_proto_rawDescData
-}
+type MoreLikeThis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_query_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_query_proto_goTypes = []interface{}{
-	(*SearchFilters)(nil), // 0: types.SearchFilters
-	(*Keyword)(nil),       // 1: types.Keyword
-	(*TermQuery)(nil),     // 2: types.TermQuery
-}
-var file_query_proto_depIdxs = []int32{
-	1, // 0: types.TermQuery.Keyword:type_name -> types.Keyword
-	2, // 1: types.TermQuery.Must:type_name -> types.TermQuery
-	2, // 2: types.TermQuery.Should:type_name -> types.TermQuery
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	DocId         string        `protobuf:"bytes,1,opt,name=DocId,proto3" json:"DocId,omitempty"`                  // 源文档的主键
+	Like          *doc.Document `protobuf:"bytes,2,opt,name=Like,proto3" json:"Like,omitempty"`                    // 临时文档，不为空时代替 DocId 指向的文档
+	Fields        []string      `protobuf:"bytes,3,rep,name=Fields,proto3" json:"Fields,omitempty"`                // 从哪些字段选词，默认为所有文本字段
+	MaxQueryTerms uint64        `protobuf:"varint,4,opt,name=MaxQueryTerms,proto3" json:"MaxQueryTerms,omitempty"` // 最多选多少个词，默认 25
+	Size          uint64        `protobuf:"varint,5,opt,name=Size,proto3" json:"Size,omitempty"`                   // 最多返回多少个文档，默认 10
 }
 
-func init() { file_query_proto_init() }
-func file_query_proto_init() {
-	if File_query_proto != nil {
+func (x *MoreLikeThis) Reset() {
+	*x = MoreLikeThis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoreLikeThis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoreLikeThis) ProtoMessage() {}
+
+func (x *MoreLikeThis) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoreLikeThis.ProtoReflect.Descriptor instead.
+func (*MoreLikeThis) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MoreLikeThis) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *MoreLikeThis) GetLike() *doc.Document {
+	if x != nil {
+		return x.Like
+	}
+	return nil
+}
+
+func (x *MoreLikeThis) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *MoreLikeThis) GetMaxQueryTerms() uint64 {
+	if x != nil {
+		return x.MaxQueryTerms
+	}
+	return 0
+}
+
+func (x *MoreLikeThis) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type KnnQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field         string    `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`                  // 向量类型的字段
+	Vector        []float32 `protobuf:"fixed32,2,rep,packed,name=Vector,proto3" json:"Vector,omitempty"`       // 查询向量
+	K             uint64    `protobuf:"varint,3,opt,name=K,proto3" json:"K,omitempty"`                         // 返回最相近的 K 个文档，默认 10
+	NumCandidates uint64    `protobuf:"varint,4,opt,name=NumCandidates,proto3" json:"NumCandidates,omitempty"` // HNSW 搜索时每个段的候选集大小，默认 max(K, 100)
+	Metric        uint64    `protobuf:"varint,5,opt,name=Metric,proto3" json:"Metric,omitempty"`               // 相似度，默认使用字段定义的相似度
+}
+
+func (x *KnnQuery) Reset() {
+	*x = KnnQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KnnQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnnQuery) ProtoMessage() {}
+
+func (x *KnnQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnnQuery.ProtoReflect.Descriptor instead.
+func (*KnnQuery) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KnnQuery) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *KnnQuery) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *KnnQuery) GetK() uint64 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *KnnQuery) GetNumCandidates() uint64 {
+	if x != nil {
+		return x.NumCandidates
+	}
+	return 0
+}
+
+func (x *KnnQuery) GetMetric() uint64 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+type Hit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DocId        uint64  `protobuf:"varint,1,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	Score        float64 `protobuf:"fixed64,2,opt,name=Score,proto3" json:"Score,omitempty"`
+	LexicalRank  uint64  `protobuf:"varint,3,opt,name=LexicalRank,proto3" json:"LexicalRank,omitempty"`    // 混合检索时在关键词结果中的名次，从 1 开始，0 表示未命中
+	LexicalScore float64 `protobuf:"fixed64,4,opt,name=LexicalScore,proto3" json:"LexicalScore,omitempty"` // 混合检索时的关键词得分
+	VectorRank   uint64  `protobuf:"varint,5,opt,name=VectorRank,proto3" json:"VectorRank,omitempty"`      // 混合检索时在向量结果中的名次，从 1 开始，0 表示未命中
+	VectorScore  float64 `protobuf:"fixed64,6,opt,name=VectorScore,proto3" json:"VectorScore,omitempty"`   // 混合检索时的向量得分
+	Distance     float64 `protobuf:"fixed64,7,opt,name=Distance,proto3" json:"Distance,omitempty"`         // 按距离排序时到原点的距离，单位米
+}
+
+func (x *Hit) Reset() {
+	*x = Hit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hit) ProtoMessage() {}
+
+func (x *Hit) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hit.ProtoReflect.Descriptor instead.
+func (*Hit) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Hit) GetDocId() uint64 {
+	if x != nil {
+		return x.DocId
+	}
+	return 0
+}
+
+func (x *Hit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Hit) GetLexicalRank() uint64 {
+	if x != nil {
+		return x.LexicalRank
+	}
+	return 0
+}
+
+func (x *Hit) GetLexicalScore() float64 {
+	if x != nil {
+		return x.LexicalScore
+	}
+	return 0
+}
+
+func (x *Hit) GetVectorRank() uint64 {
+	if x != nil {
+		return x.VectorRank
+	}
+	return 0
+}
+
+func (x *Hit) GetVectorScore() float64 {
+	if x != nil {
+		return x.VectorScore
+	}
+	return 0
+}
+
+func (x *Hit) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type Cursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sort  float64 `protobuf:"fixed64,1,opt,name=Sort,proto3" json:"Sort,omitempty"`  // 排序值，按 Sort、DocId、Shard 升序排列，见 CursorSortKey
+	DocId uint64  `protobuf:"varint,2,opt,name=DocId,proto3" json:"DocId,omitempty"` // 文档在所在 worker 上的 docId
+	Shard string  `protobuf:"bytes,3,opt,name=Shard,proto3" json:"Shard,omitempty"`  // 文档所在的 worker
+}
+
+func (x *Cursor) Reset() {
+	*x = Cursor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cursor) ProtoMessage() {}
+
+func (x *Cursor) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cursor.ProtoReflect.Descriptor instead.
+func (*Cursor) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Cursor) GetSort() float64 {
+	if x != nil {
+		return x.Sort
+	}
+	return 0
+}
+
+func (x *Cursor) GetDocId() uint64 {
+	if x != nil {
+		return x.DocId
+	}
+	return 0
+}
+
+func (x *Cursor) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+type Fusion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method        uint64  `protobuf:"varint,1,opt,name=Method,proto3" json:"Method,omitempty"`                // 融合方式，FUSION_RRF 或 FUSION_WEIGHTED，默认 FUSION_RRF
+	RankConstant  uint64  `protobuf:"varint,2,opt,name=RankConstant,proto3" json:"RankConstant,omitempty"`    // RRF 的平滑常数 k，默认 60
+	LexicalWeight float32 `protobuf:"fixed32,3,opt,name=LexicalWeight,proto3" json:"LexicalWeight,omitempty"` // 关键词结果的权重，默认 1
+	VectorWeight  float32 `protobuf:"fixed32,4,opt,name=VectorWeight,proto3" json:"VectorWeight,omitempty"`   // 向量结果的权重，默认 1
+	WindowSize    uint64  `protobuf:"varint,5,opt,name=WindowSize,proto3" json:"WindowSize,omitempty"`        // 每路参与融合的结果数，默认 100
+	Size          uint64  `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`                    // 融合后返回的结果数，默认 10
+}
+
+func (x *Fusion) Reset() {
+	*x = Fusion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fusion) ProtoMessage() {}
+
+func (x *Fusion) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fusion.ProtoReflect.Descriptor instead.
+func (*Fusion) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Fusion) GetMethod() uint64 {
+	if x != nil {
+		return x.Method
+	}
+	return 0
+}
+
+func (x *Fusion) GetRankConstant() uint64 {
+	if x != nil {
+		return x.RankConstant
+	}
+	return 0
+}
+
+func (x *Fusion) GetLexicalWeight() float32 {
+	if x != nil {
+		return x.LexicalWeight
+	}
+	return 0
+}
+
+func (x *Fusion) GetVectorWeight() float32 {
+	if x != nil {
+		return x.VectorWeight
+	}
+	return 0
+}
+
+func (x *Fusion) GetWindowSize() uint64 {
+	if x != nil {
+		return x.WindowSize
+	}
+	return 0
+}
+
+func (x *Fusion) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text   string `protobuf:"bytes,1,opt,name=Text,proto3" json:"Text,omitempty"`
+	Weight uint64 `protobuf:"varint,2,opt,name=Weight,proto3" json:"Weight,omitempty"`
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Candidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word     string  `protobuf:"bytes,1,opt,name=Word,proto3" json:"Word,omitempty"`
+	Distance uint64  `protobuf:"varint,2,opt,name=Distance,proto3" json:"Distance,omitempty"` // 与原词的编辑距离
+	DocFreq  uint64  `protobuf:"varint,3,opt,name=DocFreq,proto3" json:"DocFreq,omitempty"`   // 包含该词的文档数
+	Score    float64 `protobuf:"fixed64,4,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (x *Candidate) Reset() {
+	*x = Candidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candidate) ProtoMessage() {}
+
+func (x *Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Candidate) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Candidate) GetDistance() uint64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *Candidate) GetDocFreq() uint64 {
+	if x != nil {
+		return x.DocFreq
+	}
+	return 0
+}
+
+func (x *Candidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type TermCorrection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field      string       `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Word       string       `protobuf:"bytes,2,opt,name=Word,proto3" json:"Word,omitempty"`
+	DocFreq    uint64       `protobuf:"varint,3,opt,name=DocFreq,proto3" json:"DocFreq,omitempty"`
+	Candidates []*Candidate `protobuf:"bytes,4,rep,name=Candidates,proto3" json:"Candidates,omitempty"`
+}
+
+func (x *TermCorrection) Reset() {
+	*x = TermCorrection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TermCorrection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermCorrection) ProtoMessage() {}
+
+func (x *TermCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermCorrection.ProtoReflect.Descriptor instead.
+func (*TermCorrection) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TermCorrection) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TermCorrection) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *TermCorrection) GetDocFreq() uint64 {
+	if x != nil {
+		return x.DocFreq
+	}
+	return 0
+}
+
+func (x *TermCorrection) GetCandidates() []*Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type ClauseExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Clause  string               `protobuf:"bytes,1,opt,name=Clause,proto3" json:"Clause,omitempty"`    // Keyword 子句为 "字段:词"，组合子句为 must、should，两者都有时为 bool
+	Matched bool                 `protobuf:"varint,2,opt,name=Matched,proto3" json:"Matched,omitempty"` // 文档是否满足该子句
+	Score   float64              `protobuf:"fixed64,3,opt,name=Score,proto3" json:"Score,omitempty"`    // 文档命中时该子句贡献的得分
+	Details []*ClauseExplanation `protobuf:"bytes,4,rep,name=Details,proto3" json:"Details,omitempty"`  // must、should 的子句
+}
+
+func (x *ClauseExplanation) Reset() {
+	*x = ClauseExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClauseExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClauseExplanation) ProtoMessage() {}
+
+func (x *ClauseExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClauseExplanation.ProtoReflect.Descriptor instead.
+func (*ClauseExplanation) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClauseExplanation) GetClause() string {
+	if x != nil {
+		return x.Clause
+	}
+	return ""
+}
+
+func (x *ClauseExplanation) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *ClauseExplanation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ClauseExplanation) GetDetails() []*ClauseExplanation {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type FilterExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter  string               `protobuf:"bytes,1,opt,name=Filter,proto3" json:"Filter,omitempty"`    // 过滤条件的描述
+	Matched bool                 `protobuf:"varint,2,opt,name=Matched,proto3" json:"Matched,omitempty"` // 文档是否满足该条件，为 false 表示被该条件排除
+	Details []*FilterExplanation `protobuf:"bytes,3,rep,name=Details,proto3" json:"Details,omitempty"`  // FILT_AND、FILT_OR、FILT_NOT 的子条件
+}
+
+func (x *FilterExplanation) Reset() {
+	*x = FilterExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExplanation) ProtoMessage() {}
+
+func (x *FilterExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExplanation.ProtoReflect.Descriptor instead.
+func (*FilterExplanation) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FilterExplanation) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *FilterExplanation) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *FilterExplanation) GetDetails() []*FilterExplanation {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type Explanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Found   bool                 `protobuf:"varint,1,opt,name=Found,proto3" json:"Found,omitempty"` // 主键是否存在
+	DocId   uint64               `protobuf:"varint,2,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	Segment string               `protobuf:"bytes,3,opt,name=Segment,proto3" json:"Segment,omitempty"`  // 文档所在的段
+	Deleted bool                 `protobuf:"varint,4,opt,name=Deleted,proto3" json:"Deleted,omitempty"` // 文档是否在删除位图中
+	Matched bool                 `protobuf:"varint,5,opt,name=Matched,proto3" json:"Matched,omitempty"` // 文档最终是否会被查询返回
+	Score   float64              `protobuf:"fixed64,6,opt,name=Score,proto3" json:"Score,omitempty"`    // 文档的得分，未命中时为 0
+	Query   *ClauseExplanation   `protobuf:"bytes,7,opt,name=Query,proto3" json:"Query,omitempty"`
+	Filters []*FilterExplanation `protobuf:"bytes,8,rep,name=Filters,proto3" json:"Filters,omitempty"`
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Explanation) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *Explanation) GetDocId() uint64 {
+	if x != nil {
+		return x.DocId
+	}
+	return 0
+}
+
+func (x *Explanation) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *Explanation) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Explanation) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *Explanation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Explanation) GetQuery() *ClauseExplanation {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *Explanation) GetFilters() []*FilterExplanation {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type ProfileEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string          `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`            // 查询子句、过滤条件的描述，读取文档时为 fetch，打分查询时为 more_like_this、lexical 或者 knn
+	Type      string          `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`            // query、filter、fetch 或者 scored
+	TimeNanos uint64          `protobuf:"varint,3,opt,name=TimeNanos,proto3" json:"TimeNanos,omitempty"` // 耗时，包括子句的耗时
+	Hits      uint64          `protobuf:"varint,4,opt,name=Hits,proto3" json:"Hits,omitempty"`           // 该步骤得到的文档数
+	Children  []*ProfileEntry `protobuf:"bytes,5,rep,name=Children,proto3" json:"Children,omitempty"`
+}
+
+func (x *ProfileEntry) Reset() {
+	*x = ProfileEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEntry) ProtoMessage() {}
+
+func (x *ProfileEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEntry.ProtoReflect.Descriptor instead.
+func (*ProfileEntry) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ProfileEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProfileEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProfileEntry) GetTimeNanos() uint64 {
+	if x != nil {
+		return x.TimeNanos
+	}
+	return 0
+}
+
+func (x *ProfileEntry) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *ProfileEntry) GetChildren() []*ProfileEntry {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type SegmentProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Segment   string          `protobuf:"bytes,1,opt,name=Segment,proto3" json:"Segment,omitempty"`
+	TimeNanos uint64          `protobuf:"varint,2,opt,name=TimeNanos,proto3" json:"TimeNanos,omitempty"` // 该段的总耗时
+	Entries   []*ProfileEntry `protobuf:"bytes,3,rep,name=Entries,proto3" json:"Entries,omitempty"`      // 关键词查询、每个过滤条件以及读取文档的耗时
+}
+
+func (x *SegmentProfile) Reset() {
+	*x = SegmentProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentProfile) ProtoMessage() {}
+
+func (x *SegmentProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentProfile.ProtoReflect.Descriptor instead.
+func (*SegmentProfile) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SegmentProfile) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *SegmentProfile) GetTimeNanos() uint64 {
+	if x != nil {
+		return x.TimeNanos
+	}
+	return 0
+}
+
+func (x *SegmentProfile) GetEntries() []*ProfileEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ShardProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shard     string            `protobuf:"bytes,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	TimeNanos uint64            `protobuf:"varint,2,opt,name=TimeNanos,proto3" json:"TimeNanos,omitempty"` // worker 执行查询的总耗时
+	RpcNanos  uint64            `protobuf:"varint,3,opt,name=RpcNanos,proto3" json:"RpcNanos,omitempty"`   // Sentinel 看到的往返耗时，包括网络传输和序列化
+	Segments  []*SegmentProfile `protobuf:"bytes,4,rep,name=Segments,proto3" json:"Segments,omitempty"`
+}
+
+func (x *ShardProfile) Reset() {
+	*x = ShardProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardProfile) ProtoMessage() {}
+
+func (x *ShardProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardProfile.ProtoReflect.Descriptor instead.
+func (*ShardProfile) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ShardProfile) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+func (x *ShardProfile) GetTimeNanos() uint64 {
+	if x != nil {
+		return x.TimeNanos
+	}
+	return 0
+}
+
+func (x *ShardProfile) GetRpcNanos() uint64 {
+	if x != nil {
+		return x.RpcNanos
+	}
+	return 0
+}
+
+func (x *ShardProfile) GetSegments() []*SegmentProfile {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+type SearchProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shards      []*ShardProfile `protobuf:"bytes,1,rep,name=Shards,proto3" json:"Shards,omitempty"`
+	FanOutNanos uint64          `protobuf:"varint,2,opt,name=FanOutNanos,proto3" json:"FanOutNanos,omitempty"` // Sentinel 分发查询并等待所有 worker 返回的耗时
+	MergeNanos  uint64          `protobuf:"varint,3,opt,name=MergeNanos,proto3" json:"MergeNanos,omitempty"`   // Sentinel 合并结果的耗时
+}
+
+func (x *SearchProfile) Reset() {
+	*x = SearchProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProfile) ProtoMessage() {}
+
+func (x *SearchProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProfile.ProtoReflect.Descriptor instead.
+func (*SearchProfile) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchProfile) GetShards() []*ShardProfile {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+func (x *SearchProfile) GetFanOutNanos() uint64 {
+	if x != nil {
+		return x.FanOutNanos
+	}
+	return 0
+}
+
+func (x *SearchProfile) GetMergeNanos() uint64 {
+	if x != nil {
+		return x.MergeNanos
+	}
+	return 0
+}
+
+type PercolateQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string           `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"` // 注册的查询的唯一标识
+	Query  *TermQuery       `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
+	Filter []*SearchFilters `protobuf:"bytes,3,rep,name=Filter,proto3" json:"Filter,omitempty"` // 所有条件都要满足，Query 为空时只按过滤条件匹配
+}
+
+func (x *PercolateQuery) Reset() {
+	*x = PercolateQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PercolateQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PercolateQuery) ProtoMessage() {}
+
+func (x *PercolateQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PercolateQuery.ProtoReflect.Descriptor instead.
+func (*PercolateQuery) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PercolateQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PercolateQuery) GetQuery() *TermQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *PercolateQuery) GetFilter() []*SearchFilters {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq        uint64        `protobuf:"varint,1,opt,name=Seq,proto3" json:"Seq,omitempty"`   // worker 上该索引的变更序号，从 1 开始递增
+	Type       uint64        `protobuf:"varint,2,opt,name=Type,proto3" json:"Type,omitempty"` // CHANGE_ADD 或者 CHANGE_DELETE
+	PrimaryKey string        `protobuf:"bytes,3,opt,name=PrimaryKey,proto3" json:"PrimaryKey,omitempty"`
+	DocId      uint64        `protobuf:"varint,4,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	Doc        *doc.Document `protobuf:"bytes,5,opt,name=Doc,proto3" json:"Doc,omitempty"`              // 新增的文档，WatchChanges 时 IncludeDocument 为 false 不返回
+	Timestamp  int64         `protobuf:"varint,6,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"` // 变更时间，毫秒时间戳
+	Shard      string        `protobuf:"bytes,7,opt,name=Shard,proto3" json:"Shard,omitempty"`          // 发生变更的 worker，由 Sentinel 转发时填写
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_query_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_query_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_types_query_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChangeEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetType() uint64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetPrimaryKey() string {
+	if x != nil {
+		return x.PrimaryKey
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetDocId() uint64 {
+	if x != nil {
+		return x.DocId
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetDoc() *doc.Document {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+var File_types_query_proto protoreflect.FileDescriptor
+
+var file_types_query_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x13, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2f, 0x64, 0x6f, 0x63, 0x2f, 0x64, 0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xce, 0x03, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x34, 0x0a, 0x0b, 0x47, 0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47,
+	0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x47, 0x65, 0x6f, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x42, 0x6f,
+	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0e, 0x47, 0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x46,
+	0x6c, 0x6f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x46, 0x6c, 0x6f,
+	0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x45, 0x6e, 0x64, 0x46, 0x6c, 0x6f,
+	0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x45, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x65,
+	0x72, 0x6d, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x54, 0x65, 0x72, 0x6d, 0x73,
+	0x12, 0x2e, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x0e,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x6c, 0x6f, 0x61, 0x74,
+	0x22, 0x2e, 0x0a, 0x08, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x4c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4c, 0x61, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x4c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4c, 0x6f, 0x6e,
+	0x22, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x6f, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x27, 0x0a, 0x06, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x06, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x44, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x6e, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x42, 0x6f, 0x75, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x29, 0x0a, 0x07, 0x54, 0x6f, 0x70, 0x4c, 0x65, 0x66,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x07, 0x54, 0x6f, 0x70, 0x4c, 0x65, 0x66,
+	0x74, 0x12, 0x31, 0x0a, 0x0b, 0x42, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47,
+	0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x42, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x52,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0x5c, 0x0a, 0x07, 0x47, 0x65, 0x6f, 0x53, 0x6f, 0x72, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65,
+	0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x44, 0x65, 0x73, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x44, 0x65,
+	0x73, 0x63, 0x22, 0x34, 0x0a, 0x08, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x33, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x22, 0x9b, 0x01,
+	0x0a, 0x09, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x07, 0x4b,
+	0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x4b, 0x65,
+	0x79, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x4d, 0x75, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x04, 0x4d, 0x75, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x53,
+	0x68, 0x6f, 0x75, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x06, 0x53,
+	0x68, 0x6f, 0x75, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x22, 0x99, 0x01, 0x0a, 0x0c,
+	0x4d, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x68, 0x69, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x44, 0x6f, 0x63,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x4d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x4d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65,
+	0x72, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x08, 0x4b, 0x6e, 0x6e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x01, 0x4b,
+	0x12, 0x24, 0x0a, 0x0d, 0x4e, 0x75, 0x6d, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x4e, 0x75, 0x6d, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x22, 0xd5,
+	0x01, 0x0a, 0x03, 0x48, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61, 0x6e,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c,
+	0x52, 0x61, 0x6e, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x4c, 0x65, 0x78, 0x69,
+	0x63, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x44, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x48, 0x0a, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x53, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04,
+	0x53, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x68,
+	0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64,
+	0x22, 0xc2, 0x01, 0x0a, 0x06, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x43, 0x6f, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x4c, 0x65, 0x78, 0x69, 0x63,
+	0x61, 0x6c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d,
+	0x4c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x38, 0x0a, 0x0a, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x6b, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x57, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x44, 0x6f, 0x63, 0x46, 0x72, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x44,
+	0x6f, 0x63, 0x46, 0x72, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x86, 0x01, 0x0a,
+	0x0e, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x6f, 0x63,
+	0x46, 0x72, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x44, 0x6f, 0x63, 0x46,
+	0x72, 0x65, 0x71, 0x12, 0x30, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x43, 0x6c, 0x61, 0x75, 0x73, 0x65,
+	0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x43,
+	0x6c, 0x61, 0x75, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x43, 0x6c, 0x61,
+	0x75, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6c, 0x61,
+	0x75, 0x73, 0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x79, 0x0a, 0x11, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x32,
+	0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x22, 0x81, 0x02, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x6f, 0x63, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6c, 0x61, 0x75, 0x73, 0x65,
+	0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x32, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x48, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x69, 0x74,
+	0x73, 0x12, 0x2f, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x22, 0x77, 0x0a, 0x0e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x2d, 0x0a, 0x07,
+	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x0c,
+	0x53, 0x68, 0x61, 0x72, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x68, 0x61,
+	0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x70, 0x63, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x52, 0x70, 0x63, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x31, 0x0a, 0x08,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x7e, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x2b, 0x0a, 0x06, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x46, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x46, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x22,
+	0x76, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x63, 0x6f, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
+	0x64, 0x12, 0x26, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xbe, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x53, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x44, 0x6f, 0x63, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x44, 0x6f,
+	0x63, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x03, 0x44, 0x6f, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x64, 0x6f, 0x63, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x03, 0x44, 0x6f, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x53, 0x68, 0x61, 0x72, 0x64, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_query_proto_rawDescOnce sync.Once
+	file_types_query_proto_rawDescData = file_types_query_proto_rawDesc
+)
+
+func file_types_query_proto_rawDescGZIP() []byte {
+	file_types_query_proto_rawDescOnce.Do(func() {
+		file_types_query_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_query_proto_rawDescData)
+	})
+	return file_types_query_proto_rawDescData
+}
+
+var file_types_query_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_types_query_proto_goTypes = []interface{}{
+	(*SearchFilters)(nil),     // 0: types.SearchFilters
+	(*GeoPoint)(nil),          // 1: types.GeoPoint
+	(*GeoDistance)(nil),       // 2: types.GeoDistance
+	(*GeoBoundingBox)(nil),    // 3: types.GeoBoundingBox
+	(*GeoSort)(nil),           // 4: types.GeoSort
+	(*Collapse)(nil),          // 5: types.Collapse
+	(*Keyword)(nil),           // 6: types.Keyword
+	(*TermQuery)(nil),         // 7: types.TermQuery
+	(*MoreLikeThis)(nil),      // 8: types.MoreLikeThis
+	(*KnnQuery)(nil),          // 9: types.KnnQuery
+	(*Hit)(nil),               // 10: types.Hit
+	(*Cursor)(nil),            // 11: types.Cursor
+	(*Fusion)(nil),            // 12: types.Fusion
+	(*Suggestion)(nil),        // 13: types.Suggestion
+	(*Candidate)(nil),         // 14: types.Candidate
+	(*TermCorrection)(nil),    // 15: types.TermCorrection
+	(*ClauseExplanation)(nil), // 16: types.ClauseExplanation
+	(*FilterExplanation)(nil), // 17: types.FilterExplanation
+	(*Explanation)(nil),       // 18: types.Explanation
+	(*ProfileEntry)(nil),      // 19: types.ProfileEntry
+	(*SegmentProfile)(nil),    // 20: types.SegmentProfile
+	(*ShardProfile)(nil),      // 21: types.ShardProfile
+	(*SearchProfile)(nil),     // 22: types.SearchProfile
+	(*PercolateQuery)(nil),    // 23: types.PercolateQuery
+	(*ChangeEvent)(nil),       // 24: types.ChangeEvent
+	(*doc.Document)(nil),      // 25: doc.Document
+}
+var file_types_query_proto_depIdxs = []int32{
+	2,  // 0: types.SearchFilters.GeoDistance:type_name -> types.GeoDistance
+	3,  // 1: types.SearchFilters.GeoBoundingBox:type_name -> types.GeoBoundingBox
+	0,  // 2: types.SearchFilters.Filters:type_name -> types.SearchFilters
+	1,  // 3: types.GeoDistance.Center:type_name -> types.GeoPoint
+	1,  // 4: types.GeoBoundingBox.TopLeft:type_name -> types.GeoPoint
+	1,  // 5: types.GeoBoundingBox.BottomRight:type_name -> types.GeoPoint
+	1,  // 6: types.GeoSort.Origin:type_name -> types.GeoPoint
+	6,  // 7: types.TermQuery.Keyword:type_name -> types.Keyword
+	7,  // 8: types.TermQuery.Must:type_name -> types.TermQuery
+	7,  // 9: types.TermQuery.Should:type_name -> types.TermQuery
+	25, // 10: types.MoreLikeThis.Like:type_name -> doc.Document
+	14, // 11: types.TermCorrection.Candidates:type_name -> types.Candidate
+	16, // 12: types.ClauseExplanation.Details:type_name -> types.ClauseExplanation
+	17, // 13: types.FilterExplanation.Details:type_name -> types.FilterExplanation
+	16, // 14: types.Explanation.Query:type_name -> types.ClauseExplanation
+	17, // 15: types.Explanation.Filters:type_name -> types.FilterExplanation
+	19, // 16: types.ProfileEntry.Children:type_name -> types.ProfileEntry
+	19, // 17: types.SegmentProfile.Entries:type_name -> types.ProfileEntry
+	20, // 18: types.ShardProfile.Segments:type_name -> types.SegmentProfile
+	21, // 19: types.SearchProfile.Shards:type_name -> types.ShardProfile
+	7,  // 20: types.PercolateQuery.Query:type_name -> types.TermQuery
+	0,  // 21: types.PercolateQuery.Filter:type_name -> types.SearchFilters
+	25, // 22: types.ChangeEvent.Doc:type_name -> doc.Document
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_types_query_proto_init() }
+func file_types_query_proto_init() {
+	if File_types_query_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_query_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_types_query_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchFilters); i {
 			case 0:
 				return &v.state
@@ -294,7 +2173,67 @@ func file_query_proto_init() {
 				return nil
 			}
 		}
-		file_query_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_types_query_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoDistance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoBoundingBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoSort); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Collapse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Keyword); i {
 			case 0:
 				return &v.state
@@ -306,7 +2245,7 @@ func file_query_proto_init() {
 				return nil
 			}
 		}
-		file_query_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_types_query_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TermQuery); i {
 			case 0:
 				return &v.state
@@ -318,23 +2257,227 @@ func file_query_proto_init() {
 				return nil
 			}
 		}
+		file_types_query_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoreLikeThis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KnnQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cursor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fusion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Suggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Candidate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TermCorrection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClauseExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Explanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PercolateQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_query_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_query_proto_rawDesc,
+			RawDescriptor: file_types_query_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_query_proto_goTypes,
-		DependencyIndexes: file_query_proto_depIdxs,
-		MessageInfos:      file_query_proto_msgTypes,
+		GoTypes:           file_types_query_proto_goTypes,
+		DependencyIndexes: file_types_query_proto_depIdxs,
+		MessageInfos:      file_types_query_proto_msgTypes,
 	}.Build()
-	File_query_proto = out.File
-	file_query_proto_rawDesc = nil
-	file_query_proto_goTypes = nil
-	file_query_proto_depIdxs = nil
+	File_types_query_proto = out.File
+	file_types_query_proto_rawDesc = nil
+	file_types_query_proto_goTypes = nil
+	file_types_query_proto_depIdxs = nil
 }
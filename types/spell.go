@@ -0,0 +1,136 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// CandidateScore 候选词得分：文档频率越高、编辑距离越小，得分越高
+func CandidateScore(distance, docFreq uint64) float64 {
+	return math.Log1p(float64(docFreq)) / float64(1+distance)
+}
+
+// SortCandidates 按得分降序排列候选词并截断
+func SortCandidates(candidates []*Candidate, size uint64) []*Candidate {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Word < candidates[j].Word
+	})
+	if size > 0 && uint64(len(candidates)) > size {
+		candidates = candidates[:size]
+	}
+	return candidates
+}
+
+// MergeCorrections
+// @Description 合并多个段/多个 worker 的纠错结果。各个来源对同一段文本的切词结果相同，所以按位置合并，文档频率累加
+// @Param size 每个词保留的候选词个数
+// @Param lists 待合并的纠错结果
+// @Return 合并后的纠错结果
+func MergeCorrections(size uint64, lists ...[]*TermCorrection) []*TermCorrection {
+	var result []*TermCorrection
+	var candidates []map[string]*Candidate
+	for _, list := range lists {
+		if len(list) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make([]*TermCorrection, len(list))
+			candidates = make([]map[string]*Candidate, len(list))
+			for i, c := range list {
+				result[i] = &TermCorrection{Field: c.Field, Word: c.Word}
+				candidates[i] = make(map[string]*Candidate)
+			}
+		}
+		for i, c := range list {
+			if i >= len(result) || result[i].Word != c.Word || result[i].Field != c.Field {
+				continue
+			}
+			result[i].DocFreq += c.DocFreq
+			for _, cand := range c.Candidates {
+				if old, ok := candidates[i][cand.Word]; ok {
+					old.DocFreq += cand.DocFreq
+				} else {
+					candidates[i][cand.Word] = &Candidate{Word: cand.Word, Distance: cand.Distance, DocFreq: cand.DocFreq}
+				}
+			}
+		}
+	}
+	for i, c := range result {
+		list := make([]*Candidate, 0, len(candidates[i]))
+		for _, cand := range candidates[i] {
+			cand.Score = CandidateScore(cand.Distance, cand.DocFreq)
+			list = append(list, cand)
+		}
+		c.Candidates = SortCandidates(list, size)
+	}
+	return result
+}
+
+// Best 返回纠错后的词：原词在词典中不存在时取得分最高的候选词，否则保留原词
+func (c *TermCorrection) Best() string {
+	if c.DocFreq > 0 || len(c.Candidates) == 0 {
+		return c.Word
+	}
+	return c.Candidates[0].Word
+}
+
+// CorrectedText 把纠错后的词按顺序拼接成文本
+func CorrectedText(corrections []*TermCorrection) string {
+	sb := strings.Builder{}
+	for _, c := range corrections {
+		sb.WriteString(c.Best())
+	}
+	return sb.String()
+}
+
+// Keywords 按深度优先的顺序返回查询中所有叶子节点的 Keyword
+func (q *TermQuery) Keywords() []*Keyword {
+	if q == nil {
+		return nil
+	}
+	result := make([]*Keyword, 0)
+	if q.Keyword != nil {
+		result = append(result, q.Keyword)
+	}
+	for _, sub := range q.Must {
+		result = append(result, sub.Keywords()...)
+	}
+	for _, sub := range q.Should {
+		result = append(result, sub.Keywords()...)
+	}
+	return result
+}
+
+// Rewrite
+// @Description 按 Keywords() 的顺序把叶子节点的词替换成 words 中的词，返回新的查询，原查询不变
+// @Param words 替换后的词
+// @Return 新的查询
+func (q *TermQuery) Rewrite(words []string) *TermQuery {
+	pos := 0
+	var rewrite func(q *TermQuery) *TermQuery
+	rewrite = func(q *TermQuery) *TermQuery {
+		if q == nil {
+			return nil
+		}
+		result := &TermQuery{}
+		if q.Keyword != nil {
+			result.Keyword = &Keyword{Field: q.Keyword.Field, Word: q.Keyword.Word}
+			if pos < len(words) {
+				result.Keyword.Word = words[pos]
+			}
+			pos++
+		}
+		for _, sub := range q.Must {
+			result.Must = append(result.Must, rewrite(sub))
+		}
+		for _, sub := range q.Should {
+			result.Should = append(result.Should, rewrite(sub))
+		}
+		return result
+	}
+	return rewrite(q)
+}
@@ -0,0 +1,33 @@
+package types
+
+import "sort"
+
+// MergeSuggestions
+// @Description 合并多个段/多个 worker 返回的补全结果，同一个词只保留权重最大的一条
+// @Param size 返回的最大条数，0 表示不限制
+// @Param lists 待合并的补全结果
+// @Return 按权重降序排列的补全结果
+func MergeSuggestions(size uint64, lists ...[]*Suggestion) []*Suggestion {
+	best := make(map[string]*Suggestion)
+	for _, list := range lists {
+		for _, s := range list {
+			if old, ok := best[s.Text]; !ok || s.Weight > old.Weight {
+				best[s.Text] = s
+			}
+		}
+	}
+	result := make([]*Suggestion, 0, len(best))
+	for _, s := range best {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Weight != result[j].Weight {
+			return result[i].Weight > result[j].Weight
+		}
+		return result[i].Text < result[j].Text
+	})
+	if size > 0 && uint64(len(result)) > size {
+		result = result[:size]
+	}
+	return result
+}
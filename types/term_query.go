@@ -18,7 +18,7 @@ func (kw *Keyword) ToString() string {
 }
 
 func (q *TermQuery) Empty() bool {
-	return q.Keyword == nil && len(q.Must) == 0 && len(q.Should) == 0
+	return q == nil || q.Keyword == nil && len(q.Must) == 0 && len(q.Should) == 0
 }
 
 func (q *TermQuery) And(querys ...*TermQuery) *TermQuery {
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DATE_FORMAT_RFC3339      = "rfc3339"      // 2006-01-02T15:04:05.999999999Z07:00
+	DATE_FORMAT_EPOCH_MILLIS = "epoch_millis" // 毫秒时间戳
+	DATE_FORMAT_EPOCH_SECOND = "epoch_second" // 秒时间戳
+)
+
+// DefaultDateFormats 日期字段没有声明格式时接受的格式
+var DefaultDateFormats = []string{DATE_FORMAT_RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", DATE_FORMAT_EPOCH_MILLIS}
+
+// locations 已经加载过的时区，每次查询都读取时区文件的开销太大
+var locations sync.Map
+
+// LoadLocation function description : 加载时区，为空时使用本地时区，加载过的时区直接从缓存返回
+// params : 时区名，例如 "Asia/Shanghai"、"UTC"
+// return : 时区、错误
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// DayBounds function description : 返回某个时刻在 loc 中所在自然日的起止时间，夏令时切换的日子不是 86400 秒
+// params : 秒时间戳、时区
+// return : 当天 0 点的秒时间戳、当天最后一秒的秒时间戳
+func DayBounds(seconds int64, loc *time.Location) (int64, int64) {
+	t := time.Unix(seconds, 0).In(loc)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return start.Unix(), start.AddDate(0, 0, 1).Unix() - 1
+}
+
+// ParseDate function description : 按声明的格式依次尝试解析日期，没有时区信息的格式按 loc 解析
+// params : 日期字符串、格式列表（为空时使用 DefaultDateFormats）、时区
+// return : 毫秒时间戳、错误
+func ParseDate(value string, formats []string, loc *time.Location) (int64, error) {
+	value = strings.TrimSpace(value)
+	if len(formats) == 0 {
+		formats = DefaultDateFormats
+	}
+	for _, format := range formats {
+		switch format {
+		case DATE_FORMAT_EPOCH_MILLIS:
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return n, nil
+			}
+		case DATE_FORMAT_EPOCH_SECOND:
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return n * 1000, nil
+			}
+		case DATE_FORMAT_RFC3339:
+			if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return t.UnixMilli(), nil
+			}
+		default:
+			if t, err := time.ParseInLocation(format, value, loc); err == nil {
+				return t.UnixMilli(), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("date %q does not match formats %v", value, formats)
+}
+
+// ParseDateMath function description : 解析日期表达式，例如 "now-7d/d"、"2024-01-01||+1M/M"。
+// 锚点为 now 或者 "日期||"，之后可以跟若干个 +n单位、-n单位，最后可以跟 /单位 按日历取整。
+// 单位为 y(年) M(月) w(周) d(天) h/H(小时) m(分钟) s(秒)，取整和加减都在 loc 时区内按日历计算
+// params : 表达式、当前时间、锚点日期的格式、时区、取整时是否取该单位的最后一毫秒
+// return : 毫秒时间戳、表达式中是否带有取整、错误
+func ParseDateMath(expr string, now time.Time, formats []string, loc *time.Location, roundUp bool) (int64, bool, error) {
+	expr = strings.TrimSpace(expr)
+	var t time.Time
+	var ops string
+	if strings.HasPrefix(expr, "now") {
+		t, ops = now.In(loc), expr[len("now"):]
+	} else {
+		anchor := expr
+		if i := strings.Index(expr, "||"); i >= 0 {
+			anchor, ops = expr[:i], expr[i+2:]
+		}
+		millis, err := ParseDate(anchor, formats, loc)
+		if err != nil {
+			return 0, false, err
+		}
+		t = time.UnixMilli(millis).In(loc)
+	}
+	rounded := false
+	for len(ops) > 0 {
+		op := ops[0]
+		ops = ops[1:]
+		switch op {
+		case '+', '-':
+			i := 0
+			for i < len(ops) && ops[i] >= '0' && ops[i] <= '9' {
+				i++
+			}
+			n := 1
+			if i > 0 {
+				n, _ = strconv.Atoi(ops[:i])
+			}
+			if i >= len(ops) {
+				return 0, false, fmt.Errorf("date math %q missing unit", expr)
+			}
+			if op == '-' {
+				n = -n
+			}
+			var err error
+			if t, err = addDateUnit(t, ops[i], n); err != nil {
+				return 0, false, err
+			}
+			ops = ops[i+1:]
+		case '/':
+			if len(ops) == 0 {
+				return 0, false, fmt.Errorf("date math %q missing rounding unit", expr)
+			}
+			start, err := truncateDateUnit(t, ops[0])
+			if err != nil {
+				return 0, false, err
+			}
+			t = start
+			if roundUp {
+				next, _ := addDateUnit(start, ops[0], 1)
+				t = next.Add(-time.Millisecond)
+			}
+			rounded = true
+			ops = ops[1:]
+		default:
+			return 0, false, fmt.Errorf("date math %q has unexpected %q", expr, op)
+		}
+	}
+	return t.UnixMilli(), rounded, nil
+}
+
+// 内部方法，按日历加减，天和周按字段时区的自然日计算
+func addDateUnit(t time.Time, unit byte, n int) (time.Time, error) {
+	switch unit {
+	case 'y':
+		return addMonths(t, 12*n), nil
+	case 'M':
+		return addMonths(t, n), nil
+	case 'w':
+		return t.AddDate(0, 0, 7*n), nil
+	case 'd':
+		return t.AddDate(0, 0, n), nil
+	case 'h', 'H':
+		return t.Add(time.Duration(n) * time.Hour), nil
+	case 'm':
+		return t.Add(time.Duration(n) * time.Minute), nil
+	case 's':
+		return t.Add(time.Duration(n) * time.Second), nil
+	}
+	return t, errors.New("unknown date unit " + string(unit))
+}
+
+// 内部方法，加减月份时日期超出目标月的天数则取目标月的最后一天，例如 1 月 31 日加一个月为 2 月的最后一天
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// 内部方法，取某个单位的开始时刻，周从周一开始
+func truncateDateUnit(t time.Time, unit byte) (time.Time, error) {
+	loc := t.Location()
+	switch unit {
+	case 'y':
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, loc), nil
+	case 'M':
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc), nil
+	case 'w':
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc), nil
+	case 'd':
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
+	case 'h', 'H':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc), nil
+	case 'm':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
+	case 's':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	}
+	return t, errors.New("unknown date unit " + string(unit))
+}
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	GEO_BITS          = 31        // 经纬度各量化成 31 位，交织后的 Morton 码为 62 位非负整数
+	GEO_EARTH_RADIUS  = 6371008.8 // 地球平均半径，单位米
+	geoMaxCoverLevels = 6         // 覆盖矩形时在起始层之下最多再细分的层数
+)
+
+// ParseGeoPoint function description : 解析 "lat,lon" 格式的坐标
+// params : 坐标字符串
+// return : 纬度、经度、错误
+func ParseGeoPoint(content string) (float64, float64, error) {
+	parts := strings.Split(content, ",")
+	if len(parts) != 2 {
+		return 0, 0, errors.New("geo point must be \"lat,lon\"")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, errors.New("geo point out of range")
+	}
+	return lat, lon, nil
+}
+
+// GeoEncode function description : 把经纬度量化后按位交织成 Morton 码，相邻的点编码后大多也相邻
+// params : 纬度、经度
+// return : Morton 码
+func GeoEncode(lat, lon float64) int64 {
+	return int64(interleave(quantize(lat, -90, 90), quantize(lon, -180, 180)))
+}
+
+// GeoDecode function description : GeoEncode 的逆操作，返回量化单元的左下角
+// params : Morton 码
+// return : 纬度、经度
+func GeoDecode(code int64) (float64, float64) {
+	latBits, lonBits := deinterleave(uint64(code))
+	return dequantize(latBits, -90, 90), dequantize(lonBits, -180, 180)
+}
+
+// GeoDistance function description : 用 haversine 公式计算两点间的球面距离
+// params : 两点的经纬度
+// return : 距离，单位米
+func GeoDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * GEO_EARTH_RADIUS * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// GeoDistanceBox function description : 返回包含以 (lat, lon) 为圆心、distance 为半径的圆的矩形，跨越 180 度经线时拆成两个
+// params : 圆心经纬度、半径（米）
+// return : 矩形列表，每个矩形为 [minLat, minLon, maxLat, maxLon]
+func GeoDistanceBox(lat, lon, distance float64) [][4]float64 {
+	dLat := distance / GEO_EARTH_RADIUS * 180 / math.Pi
+	minLat, maxLat := lat-dLat, lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		// 圆覆盖了极点，经度方向不做限制
+		return [][4]float64{{math.Max(minLat, -90), -180, math.Min(maxLat, 90), 180}}
+	}
+	dLon := dLat / math.Cos(lat*math.Pi/180)
+	if dLon >= 180 {
+		return [][4]float64{{minLat, -180, maxLat, 180}}
+	}
+	return GeoBoxes(minLat, lon-dLon, maxLat, lon+dLon)
+}
+
+// GeoBoxes function description : 规范化矩形，minLon > maxLon 或者超出 [-180, 180] 时按跨越 180 度经线拆成两个
+// params : 矩形的最小、最大经纬度
+// return : 矩形列表，每个矩形为 [minLat, minLon, maxLat, maxLon]
+func GeoBoxes(minLat, minLon, maxLat, maxLon float64) [][4]float64 {
+	if minLon < -180 {
+		minLon += 360
+	}
+	if maxLon > 180 {
+		maxLon -= 360
+	}
+	if minLon > maxLon {
+		return [][4]float64{{minLat, minLon, maxLat, 180}, {minLat, -180, maxLat, maxLon}}
+	}
+	return [][4]float64{{minLat, minLon, maxLat, maxLon}}
+}
+
+// GeoCoverRanges function description : 用四叉树单元覆盖矩形，每个单元对应一段连续的 Morton 码。
+// 覆盖是近似的，范围内的点还需要按真实坐标再检查一遍
+// params : 矩形的最小、最大经纬度
+// return : 合并后的 Morton 码区间列表，每个区间为 [start, end]
+func GeoCoverRanges(minLat, minLon, maxLat, maxLon float64) [][2]int64 {
+	latMin, latMax := quantize(minLat, -90, 90), quantize(maxLat, -90, 90)
+	lonMin, lonMax := quantize(minLon, -180, 180), quantize(maxLon, -180, 180)
+	// 起始层取单元边长不小于矩形的那一层，再往下细分若干层
+	span := max(latMax-latMin, lonMax-lonMin) + 1
+	startLevel := GEO_BITS - int(math.Ceil(math.Log2(float64(span))))
+	maxLevel := min(GEO_BITS, max(startLevel, 0)+geoMaxCoverLevels)
+	ranges := make([][2]int64, 0)
+	var cover func(level int, latBits, lonBits uint64)
+	cover = func(level int, latBits, lonBits uint64) {
+		shift := uint(GEO_BITS - level)
+		cellLatMin, cellLonMin := latBits<<shift, lonBits<<shift
+		cellLatMax, cellLonMax := cellLatMin+(1<<shift)-1, cellLonMin+(1<<shift)-1
+		if cellLatMax < latMin || cellLatMin > latMax || cellLonMax < lonMin || cellLonMin > lonMax {
+			return
+		}
+		inside := cellLatMin >= latMin && cellLatMax <= latMax && cellLonMin >= lonMin && cellLonMax <= lonMax
+		if inside || level >= maxLevel {
+			start := int64(interleave(cellLatMin, cellLonMin))
+			end := int64(interleave(cellLatMax, cellLonMax))
+			if n := len(ranges); n > 0 && ranges[n-1][1]+1 == start {
+				ranges[n-1][1] = end
+			} else {
+				ranges = append(ranges, [2]int64{start, end})
+			}
+			return
+		}
+		// 按 Morton 码的顺序遍历四个子单元，保证输出的区间有序
+		for i := uint64(0); i < 4; i++ {
+			cover(level+1, latBits<<1|i>>1, lonBits<<1|i&1)
+		}
+	}
+	cover(0, 0, 0)
+	return ranges
+}
+
+// 内部方法，把 [minValue, maxValue] 内的值线性量化成 GEO_BITS 位整数
+func quantize(value, minValue, maxValue float64) uint64 {
+	scale := float64(uint64(1) << GEO_BITS)
+	q := (value - minValue) / (maxValue - minValue) * scale
+	if q < 0 {
+		return 0
+	}
+	if q >= scale {
+		return uint64(1)<<GEO_BITS - 1
+	}
+	return uint64(q)
+}
+
+func dequantize(bits uint64, minValue, maxValue float64) float64 {
+	return minValue + float64(bits)/float64(uint64(1)<<GEO_BITS)*(maxValue-minValue)
+}
+
+// 内部方法，纬度占奇数位、经度占偶数位
+func interleave(latBits, lonBits uint64) uint64 {
+	return spread(latBits)<<1 | spread(lonBits)
+}
+
+func deinterleave(code uint64) (uint64, uint64) {
+	return compact(code >> 1), compact(code)
+}
+
+// 内部方法，把低 32 位分散到偶数位上
+func spread(x uint64) uint64 {
+	x &= 0xFFFFFFFF
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+func compact(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return x
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
 	"time"
 )
@@ -13,21 +14,64 @@ const IDX_ROOT_PATH string = "./data/"
 const NexusFind string = "NexusFind"
 
 const MAX_SEGMENT_SIZE = 100000
+
+const FILTER_CACHE_SIZE uint64 = 64 << 20 // 过滤结果缓存的内存上限，单位字节
+
+// GENERATION_CACHE_TTL Sentinel 缓存各个 worker 上索引版本号的时间。
+// 不经过该 Sentinel 的写入最多延迟这么久才会让缓存的查询结果失效
+const GENERATION_CACHE_TTL = 500 * time.Millisecond
+
+const TTL_TIMESTAMP_FIELD = "_timestamp" // 只声明默认 TTL 时自动加入索引的写入时间字段
+
+// 变更类型。没有原地修改文档的操作，修改由删除加新增完成，2 保留不用
+const (
+	CHANGE_ADD    uint64 = 1 // 新增文档
+	CHANGE_DELETE uint64 = 3 // 删除文档
+)
+
+// CHANGE_LOG_SYNC_INTERVAL 变更日志合并落盘的间隔，为 0 时每条变更都落盘之后才返回
+const CHANGE_LOG_SYNC_INTERVAL = 50 * time.Millisecond
+
+const CHANGE_LOG_MAX_EVENTS uint64 = 1 << 20 // 每个索引最多保留的变更条数，超过时截掉较早的一半
+
+const CHANGE_LOG_INDEX_STEP uint64 = 1024 // 变更日志每隔多少条记录一次文件偏移
+
+// REINDEX_JOB_RETENTION 已经结束的重建索引任务保留多久，之后查询进度返回任务不存在
+const REINDEX_JOB_RETENTION = time.Hour
+
+const REINDEX_MAX_FINISHED_JOBS = 64 // 最多保留的已经结束的重建索引任务数，超过时删除最早结束的任务
+
 const (
 	IDX_TYPE_STRING     = 1 // 字符型索引[全词匹配]
 	IDX_TYPE_STRING_SEG = 2 //字符型索引[切词匹配，全文索引,hash存储倒排]
 
 	IDX_TYPE_NUMBER = 11 // 数字型索引，只支持整数，数字型索引只建立倒排
 	IDX_TYPE_FLOAT  = 12 // 数字型索引，支持浮点数，只能保留两位小数，数字型索引只建立倒排
+	IDX_TYPE_DOUBLE = 13 // 数字型索引，支持任意精度的浮点数，按保序编码存入倒排，过滤时使用 StartFloat/EndFloat
 
-	IDX_TYPE_DATE = 15 // 日期型索引 '2015-11-11 00:11:12'，日期型只建立倒排，转成时间戳存储
+	IDX_TYPE_DATE     = 15 // 日期型索引 '2015-11-11 00:11:12'，日期型只建立倒排，转成时间戳存储
+	IDX_TYPE_DATETIME = 16 // 毫秒精度的日期型索引，可以声明接受的格式和时区，过滤时支持日期表达式
+
+	IDX_TYPE_GEO = 17 // 地理坐标型索引 "lat,lon"，编码成 Morton 码存入数值倒排
 
 	IDX_TYPE_PK = 21 //主键类型，倒排正排都需要，倒排使用B+树存储
 
 	IDX_TYPE_DESC = 31 // 只存储不索引的类型
 
+	IDX_TYPE_VECTOR = 41 // 稠密向量类型，存储 float32 向量，只支持 kNN 检索
+
 )
 
+// SEGMENT_FORMAT_VERSION 段文件格式版本。1 起数值倒排的 key 按大端序并翻转符号位编码，
+// 0 为按小端序编码 key 的旧格式，旧格式的段只能逐个 key 扫描做范围查询，重新落盘时改写为新格式
+const SEGMENT_FORMAT_VERSION uint64 = 1
+
+// FUZZY_PREFIX_LENGTH 纠错时候选词必须与原词相同的前缀字符数，只扫描词典中该前缀下的词项
+const FUZZY_PREFIX_LENGTH = 1
+
+// HNSW_MIN_VECTORS 段内向量数达到该值时，序列化时构建 HNSW 图，否则只做暴力检索
+const HNSW_MIN_VECTORS = 1024
+
 const STOP_WORD_FILE_PATH = "/Users/cyl/Desktop/cyl/NexusFind/utils/stopWords.txt"
 
 // FileExist 判断文件是否存在，如果存在返回true，否则返回false
@@ -42,7 +86,9 @@ func FileExist(path string) bool {
 func IsDateTime(datetime string) (int64, error) {
 	var timestamp time.Time
 	var err error
-	if len(datetime) > 16 {
+	if t, e := time.Parse(time.RFC3339, datetime); e == nil {
+		timestamp = t
+	} else if len(datetime) > 16 {
 		timestamp, err = time.ParseInLocation("2006-01-02 15:04:05", datetime, time.Local)
 		if err != nil {
 			return -1, err
@@ -63,10 +109,30 @@ func IsDateTime(datetime string) (int64, error) {
 }
 
 const (
-	FILT_EQ    uint64 = 1 //等于
-	FILT_OVER  uint64 = 2 //大于
-	FILT_LESS  uint64 = 3 //小于
-	FILT_RANGE uint64 = 4 //范围内
+	FILT_EQ           uint64 = 1  //等于
+	FILT_OVER         uint64 = 2  //大于
+	FILT_LESS         uint64 = 3  //小于
+	FILT_RANGE        uint64 = 4  //范围内
+	FILT_GEO_DISTANCE uint64 = 5  //距离某点一定范围内
+	FILT_GEO_BBOX     uint64 = 6  //矩形范围内
+	FILT_TERMS        uint64 = 7  //取值在 Terms 集合内
+	FILT_IN           uint64 = 8  //数值在 Range 集合内，IDX_TYPE_DOUBLE 字段还可以使用 RangeFloat
+	FILT_EXISTS       uint64 = 9  //字段有值
+	FILT_MISSING      uint64 = 10 //字段没有值
+	FILT_AND          uint64 = 11 //子条件 Filters 都满足
+	FILT_OR           uint64 = 12 //子条件 Filters 满足任意一个
+	FILT_NOT          uint64 = 13 //子条件 Filters 不同时满足
+)
+
+const (
+	KNN_COSINE uint64 = 1 //余弦相似度
+	KNN_DOT    uint64 = 2 //内积
+	KNN_L2     uint64 = 3 //欧氏距离
+)
+
+const (
+	FUSION_RRF      uint64 = 1 //倒数排名融合
+	FUSION_WEIGHTED uint64 = 2 //归一化得分加权求和
 )
 
 type SearchFilters struct {
@@ -86,3 +152,33 @@ func ItoBytes(n any) []byte {
 	}
 	return buf.Bytes()
 }
+
+// FloatToSortable function description : 把 float64 编码成保序的 int64，编码后的大小关系与原来的浮点数一致
+// params : 浮点数，-0 按 0 处理
+// return : 编码后的整数
+func FloatToSortable(f float64) int64 {
+	if f == 0 {
+		f = 0
+	}
+	bits := math.Float64bits(f)
+	if bits>>63 == 1 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	// 翻转符号位，使无符号的顺序变成有符号的顺序
+	return int64(bits ^ (1 << 63))
+}
+
+// SortableToFloat function description : FloatToSortable 的逆操作
+// params : 编码后的整数
+// return : 浮点数
+func SortableToFloat(n int64) float64 {
+	bits := uint64(n) ^ (1 << 63)
+	if bits>>63 == 1 {
+		bits &^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	return math.Float64frombits(bits)
+}